@@ -10,17 +10,19 @@ import (
 )
 
 type Config struct {
-	App     App     `mapstructure:"app"`
-	Auth    Auth    `mapstructure:"auth"`
-	DB      DB      `mapstructure:"db"`
-	Cache   Cache   `mapstructure:"cache"`
-	Broker  Broker  `mapstructure:"broker"`
-	Cookie  Cookie  `mapstructure:"cookie"`
-	Session Session `mapstructure:"session"`
-	Metric  Metric  `mapstructure:"metric"`
-	Logger  Logger  `mapstructure:"logger"`
-	Jaeger  Jaeger  `mapstructure:"jaeger"`
-	Cdn     Cdn     `mapstructure:"cdn"`
+	App          App          `mapstructure:"app"`
+	Auth         Auth         `mapstructure:"auth"`
+	DB           DB           `mapstructure:"db"`
+	Cache        Cache        `mapstructure:"cache"`
+	Broker       Broker       `mapstructure:"broker"`
+	Webhook      Webhook      `mapstructure:"webhook"`
+	Notification Notification `mapstructure:"notification"`
+	Cookie       Cookie       `mapstructure:"cookie"`
+	Session      Session      `mapstructure:"session"`
+	Metric       Metric       `mapstructure:"metric"`
+	Logger       Logger       `mapstructure:"logger"`
+	Jaeger       Jaeger       `mapstructure:"jaeger"`
+	Cdn          Cdn          `mapstructure:"cdn"`
 }
 
 type App struct {
@@ -31,7 +33,12 @@ type App struct {
 }
 
 type Auth struct {
-	JwtPub string `mapstructure:"jwt_pub"`
+	JwtPub           string              `mapstructure:"jwt_pub"`
+	Issuer           string              `mapstructure:"issuer"`   // iss every token must have, not checked when empty
+	Audience         string              `mapstructure:"audience"` // aud every token must have, not checked when empty
+	AllowCookie      bool                `mapstructure:"allow_cookie"`
+	Roles            map[string][]string `mapstructure:"roles"`
+	CalendarTokenTtl int                 `mapstructure:"calendar_token_ttl"` // hours a calendar feed token is valid for, defaults to 720
 }
 
 type DB struct {
@@ -43,9 +50,40 @@ type Cache struct {
 }
 
 type Broker struct {
-	Url           string `mapstructure:"url"`
-	ConsumerGroup string `mapstructure:"consumer_group"`
-	Topic         string `mapstructure:"topic"`
+	Url             string `mapstructure:"url"`
+	ConsumerGroup   string `mapstructure:"consumer_group"`
+	Topic           string `mapstructure:"topic"`
+	RelayInterval   int    `mapstructure:"relay_interval"`    // milliseconds between two polls of the outbox
+	RelayBatchSize  int    `mapstructure:"relay_batch_size"`  // outbox events published at once
+	OutboxRetention int    `mapstructure:"outbox_retention"`  // hours published outbox events are kept for
+	HrTopic         string `mapstructure:"hr_topic"`          // employee events of the HR platform, consumed by consumer_group
+	DeadLetterTopic string `mapstructure:"dead_letter_topic"` // HR events that cannot be applied, defaults to hr_topic + ".dlq"
+}
+
+type Webhook struct {
+	Interval     int      `mapstructure:"interval"`      // milliseconds between two polls of the pending deliveries
+	BatchSize    int      `mapstructure:"batch_size"`    // deliveries attempted at once
+	Timeout      int      `mapstructure:"timeout"`       // seconds a subscriber has to answer a delivery
+	MaxAttempts  int      `mapstructure:"max_attempts"`  // attempts before a delivery fails
+	AllowedHosts []string `mapstructure:"allowed_hosts"` // hosts delivered to even when they resolve to private, loopback or link-local addresses
+}
+
+type Notification struct {
+	Interval      int    `mapstructure:"interval"`       // seconds between two scans of the upcoming shifts
+	LeadTimes     []int  `mapstructure:"lead_times"`     // minutes before a shift starts its reminders are sent
+	HandoverLead  int    `mapstructure:"handover_lead"`  // minutes before a handover its notices are sent
+	MaxAttempts   int    `mapstructure:"max_attempts"`   // attempts of a notification on a channel
+	Smtp          Smtp   `mapstructure:"smtp"`           // email channel, enabled with a host
+	WebhookUrl    string `mapstructure:"webhook_url"`    // webhook channel, enabled with a url
+	WebhookSecret string `mapstructure:"webhook_secret"` // signs the webhook channel posts when set
+}
+
+type Smtp struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	From     string `mapstructure:"from"`
 }
 
 type Cookie struct {
@@ -62,8 +100,10 @@ type Session struct {
 }
 
 type Metric struct {
-	Url     string `mapstructure:"url"`
-	Service string `mapstructure:"service"`
+	Url              string `mapstructure:"url"`
+	Service          string `mapstructure:"service"`
+	CoverageHorizon  int    `mapstructure:"coverage_horizon"`  // hours ahead the uncovered hours gauge looks at
+	CoverageInterval int    `mapstructure:"coverage_interval"` // seconds between two refreshes of the uncovered hours gauge
 }
 
 type Logger struct {
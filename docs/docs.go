@@ -19,63 +19,65 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/shift-schedule/{id}": {
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "update a shift schedule",
-                "consumes": [
-                    "application/json"
-                ],
+        "/calendar/schedules/{file}": {
+            "get": {
+                "description": "get the shifts of the shift schedule as an RFC 5545 iCalendar feed, authenticated by the calendar token in the URL",
                 "produces": [
-                    "application/json"
+                    "text/calendar"
                 ],
                 "tags": [
-                    "Shift"
+                    "Calendar"
                 ],
-                "summary": "update a shift schedule",
+                "summary": "get the calendar feed of a shift schedule",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Shift Schedule ID",
-                        "name": "id",
+                        "description": "Shift Schedule ID followed by .ics, e.g. 3.ics",
+                        "name": "file",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "update shift schedule",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.updateShiftScheduleDTO"
-                        }
+                        "type": "string",
+                        "description": "Calendar token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone the events are written in, defaults to the time zone of the schedule",
+                        "name": "tz",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "successfully updated shift schedule",
+                        "description": "iCalendar feed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "cannot get calendar due to an invalid token",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     },
-                    "400": {
-                        "description": "cannot update shift schedule due to invalid request body",
+                    "403": {
+                        "description": "cannot get calendar due to missing permission",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     },
-                    "422": {
-                        "description": "cannot update shift schedule due to invalid request body",
+                    "404": {
+                        "description": "cannot get calendar due to not found",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     },
                     "500": {
-                        "description": "cannot update shift schedule due to internal server error",
+                        "description": "cannot get calendar due to internal server error",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
@@ -83,14 +85,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/shift-schedules": {
-            "get": {
+        "/calendar/token": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "get all shift schedules with pagination, search, sort and filter options",
+                "description": "create the secret token authenticating the caller's iCalendar feeds, the previous token is revoked. The token is only returned once and expires after auth.calendar_token_ttl hours.",
                 "consumes": [
                     "application/json"
                 ],
@@ -98,190 +100,4020 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Shift"
+                    "Calendar"
                 ],
-                "summary": "get all shift schedules with filters",
-                "parameters": [
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
+                "summary": "create a calendar feed token",
+                "responses": {
+                    "200": {
+                        "description": "successfully created calendar token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot create calendar token without a user",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
                     },
+                    "500": {
+                        "description": "cannot create calendar token due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
                     {
-                        "maximum": 100,
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Page size",
-                        "name": "page_size",
-                        "in": "query"
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "revoke the secret token authenticating the caller's iCalendar feeds",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Calendar"
+                ],
+                "summary": "revoke the calendar feed token",
+                "responses": {
+                    "200": {
+                        "description": "successfully revoked calendar token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot revoke calendar token without a user",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
                     },
+                    "500": {
+                        "description": "cannot revoke calendar token due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/calendar/users/{file}": {
+            "get": {
+                "description": "get the shifts of the user across shift schedules as an RFC 5545 iCalendar feed, authenticated by the calendar token in the URL",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Calendar"
+                ],
+                "summary": "get the calendar feed of a user",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Search in alias, description, organization, manager",
-                        "name": "search",
-                        "in": "query"
+                        "description": "User ID followed by .ics, e.g. 12.ics",
+                        "name": "file",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "default": "created_at",
-                        "description": "Sort by field (alias, year, start_date, end_date, status, organization_name, manager_name)",
-                        "name": "sort_by",
-                        "in": "query"
+                        "description": "Calendar token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "default": "DESC",
-                        "description": "Sort order (ASC or DESC)",
-                        "name": "sort_order",
+                        "description": "IANA time zone the events are written in, defaults to the time zone of every schedule",
+                        "name": "tz",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar feed",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
-                    {
-                        "type": "boolean",
-                        "description": "Filter only active records (not deleted)",
-                        "name": "active",
-                        "in": "query"
+                    "401": {
+                        "description": "cannot get calendar due to an invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
                     },
-                    {
-                        "type": "integer",
-                        "description": "Filter by status (0:pending, 1:approved, 2:rejected)",
-                        "name": "status",
-                        "in": "query"
+                    "403": {
+                        "description": "cannot get calendar of another user",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
                     },
-                    {
-                        "type": "integer",
-                        "description": "Filter by year",
-                        "name": "year",
-                        "in": "query"
+                    "404": {
+                        "description": "cannot get calendar due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
                     },
+                    "500": {
+                        "description": "cannot get calendar due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/conflicts": {
+            "get": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "Filter by start date (YYYY-MM-DD)",
-                        "name": "start_date",
-                        "in": "query"
-                    },
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the double bookings of people across shift schedules, the overlapping shifts of a shift schedule and the shifts outside of their shift schedule start and end date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "get the conflicts of the shift schedules",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter by end date (YYYY-MM-DD)",
-                        "name": "end_date",
+                        "description": "Start of the period (RFC 3339), defaults to now",
+                        "name": "from",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter by organization name",
-                        "name": "organization_name",
+                        "description": "End of the period (RFC 3339), defaults to 31 days after from",
+                        "name": "to",
                         "in": "query"
                     },
                     {
+                        "enum": [
+                            "double_booking",
+                            "overlap",
+                            "out_of_range"
+                        ],
                         "type": "string",
-                        "description": "Filter by organization mail",
-                        "name": "organization_mail",
+                        "description": "Conflict type",
+                        "name": "type",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "get conflicts successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Filter by organization phone",
-                        "name": "organization_phone",
-                        "in": "query"
+                    "400": {
+                        "description": "cannot get conflicts due to invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Filter by manager name",
-                        "name": "manager_name",
-                        "in": "query"
+                    "403": {
+                        "description": "cannot get conflicts due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
                     },
+                    "500": {
+                        "description": "cannot get conflicts due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/coverage-gaps": {
+            "get": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "Filter by manager mail",
-                        "name": "manager_mail",
-                        "in": "query"
-                    },
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the periods nobody is assigned to, per shift schedule of the organization (or of a single shift schedule)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "get the coverage gaps of shift schedules",
+                "parameters": [
                     {
-                        "type": "string",
-                        "description": "Filter by manager phone",
-                        "name": "manager_phone",
+                        "type": "integer",
+                        "description": "Shift Schedule ID, defaults to every shift schedule of the organization",
+                        "name": "shift_schedule_id",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Filter by user ID",
-                        "name": "user_id",
+                        "description": "Organization ID, defaults to the caller's organization",
+                        "name": "organization_id",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter by user name",
-                        "name": "user_name",
+                        "description": "IANA time zone gaps are written in, defaults to the time zone of every schedule",
+                        "name": "tz",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter by user mail",
-                        "name": "user_mail",
+                        "description": "Start of the period (RFC 3339), defaults to now",
+                        "name": "from",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter by user phone",
-                        "name": "user_phone",
+                        "description": "End of the period (RFC 3339), defaults to 31 days after from",
+                        "name": "to",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "get coverage gaps successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot get coverage gaps due to invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot get coverage gaps due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot get coverage gaps due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
                     },
+                    "500": {
+                        "description": "cannot get coverage gaps due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/leaves": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get leaves filtered by user, status, type and period. Callers limited to their own leaves only get those and the leaves of the people they manage.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "get leaves",
+                "parameters": [
                     {
                         "type": "integer",
-                        "description": "Filter by shift ID",
-                        "name": "shift_id",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "approved",
+                            "rejected"
+                        ],
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
                         "in": "query"
                     },
                     {
+                        "enum": [
+                            "vacation",
+                            "sick",
+                            "training"
+                        ],
                         "type": "string",
-                        "description": "Filter by shift start (YYYY-MM-DD HH:MM:SS)",
-                        "name": "shift_start",
+                        "description": "Type",
+                        "name": "type",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter by shift end (YYYY-MM-DD HH:MM:SS)",
-                        "name": "shift_end",
+                        "description": "Start of the period (RFC 3339)",
+                        "name": "from",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter by shift user",
-                        "name": "shift_user",
+                        "description": "End of the period (RFC 3339)",
+                        "name": "to",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "get all shift schedules successfully",
+                        "description": "get leaves successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.ShiftScheduleListResponse"
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot get leaves due to invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot get leaves due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot get leaves due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "request a vacation, sick or training leave, the person is unavailable for shifts once it is approved",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "request a leave",
+                "parameters": [
+                    {
+                        "description": "request leave",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.createLeaveDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully requested leave",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot request leave due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot request leave due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot request leave due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/leaves/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get a leave by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "get a leave by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Leave ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "get leave by id successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot get leave due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot get leave due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot get leave due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "delete (cancel) a leave (soft delete), the person is available again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "delete a leave",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Leave ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully deleted leave",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot delete leave due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot delete leave due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot delete leave due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/leaves/{id}/{action}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "approve or reject a pending leave, approved leaves make the person unavailable for shifts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "approve or reject a leave",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Leave ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "approve",
+                            "reject"
+                        ],
+                        "type": "string",
+                        "description": "Action",
+                        "name": "action",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "note",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.decideLeaveDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully decided leave",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot decide leave due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot decide leave due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "409": {
+                        "description": "cannot decide leave that is not pending",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot decide leave due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/managers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get all managers with pagination, search, sort and filter options",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Manager"
+                ],
+                "summary": "get all managers with filters",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search in name, mail, phone, description",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "Sort by field (id, name, mail, phone, created_at, updated_at)",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "DESC",
+                        "description": "Sort order (ASC or DESC)",
+                        "name": "sort_order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter only active records (not deleted)",
+                        "name": "active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by mail",
+                        "name": "mail",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by phone",
+                        "name": "phone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by organization ID",
+                        "name": "organization_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "get all managers successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.ManagerListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot get all managers due to invalid request parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot get all managers due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot get all managers due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "create a new manager that can be referenced by id from shift schedules",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Manager"
+                ],
+                "summary": "create a new manager",
+                "parameters": [
+                    {
+                        "description": "create manager",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.createManagerDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully created manager",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot create manager due to invalid request body or unknown user",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot create manager due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot create manager due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/managers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get a manager by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Manager"
+                ],
+                "summary": "get a manager by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manager ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "get manager by id successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot get manager by id due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot get manager by id due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot get manager by id due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "update a manager, the change is reflected in every shift schedule they manage",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Manager"
+                ],
+                "summary": "update a manager",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manager ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "update manager",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.updateManagerDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully updated manager",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot update manager due to invalid request body or unknown user",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot update manager due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot update manager due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot update manager due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "delete a manager (soft delete)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Manager"
+                ],
+                "summary": "delete a manager",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manager ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully deleted manager",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot delete manager due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot delete manager due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot delete manager due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/managers/{id}/restore": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "restore a deleted manager",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Manager"
+                ],
+                "summary": "restore a manager",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manager ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully restored manager",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot restore manager due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot restore manager due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot restore manager due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/managers/{id}/shift-schedules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the shift schedules owned by a manager, accepts the filters of GET /shift-schedules",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Manager"
+                ],
+                "summary": "get shift schedules of a manager",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manager ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search in alias, description, organization, manager",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "Sort by field (alias, year, start_date, end_date, status, organization_name, manager_name)",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "DESC",
+                        "description": "Sort order (ASC or DESC)",
+                        "name": "sort_order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "get manager shift schedules successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.ShiftScheduleListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot get manager shift schedules due to invalid request parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot get manager shift schedules due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot get manager shift schedules due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot get manager shift schedules due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/on-call": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the person currently on shift per shift schedule, with the next person and the handover time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "get the person on call per shift schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Time to resolve (RFC 3339), defaults to now",
+                        "name": "at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone ` + "`" + `at` + "`" + ` is read in when it has no offset",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Organization ID, defaults to the caller's organization",
+                        "name": "organization_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "get on call successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot get on call due to invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot get on call of another organization",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot get on call due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get all organizations with pagination, search, sort and filter options",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "get all organizations with filters",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search in name, mail, phone, description",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "Sort by field (id, name, mail, phone, created_at, updated_at)",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "DESC",
+                        "description": "Sort order (ASC or DESC)",
+                        "name": "sort_order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter only active records (not deleted)",
+                        "name": "active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by mail",
+                        "name": "mail",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by phone",
+                        "name": "phone",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "get all organizations successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrganizationListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot get all organizations due to invalid request parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot get all organizations due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot get all organizations due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "create a new organization that can be referenced by id from shift schedules",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "create a new organization",
+                "parameters": [
+                    {
+                        "description": "create organization",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.createOrganizationDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully created organization",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot create organization due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot create organization due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot create organization due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get an organization by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "get an organization by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "get organization by id successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot get organization by id due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot get organization by id due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot get organization by id due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "update an organization, the change is reflected in every shift schedule it owns",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "update an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "update organization",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.updateOrganizationDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully updated organization",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot update organization due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot update organization due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot update organization due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot update organization due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "delete an organization (soft delete)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "delete an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully deleted organization",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot delete organization due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot delete organization due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot delete organization due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}/holidays": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the public holidays of the organization's calendar",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "get the holidays of an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the period (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the period (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "get holidays successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot get holidays due to invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot get holidays due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot get holidays due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "add a public holiday to the organization's calendar, the holiday of the same day is renamed instead",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "add a holiday to an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "holiday",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.createHolidayDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully added holiday",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot add holiday due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot add holiday due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot add holiday due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}/holidays/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "load public holidays from an iCalendar (.ics) or CSV (` + "`" + `date,name` + "`" + ` rows) file, sent as the ` + "`" + `file` + "`" + ` form field or as the request body. Holidays of days already in the calendar are renamed.",
+                "consumes": [
+                    "multipart/form-data",
+                    "text/calendar",
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "import the holidays of an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "ics",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "File format, defaults to the file extension or content type",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "file",
+                        "description": "holiday file",
+                        "name": "file",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully imported holidays",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot import holidays due to invalid file",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot import holidays due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot import holidays due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}/holidays/{holiday_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "remove a public holiday from the organization's calendar (soft delete)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "delete a holiday of an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Holiday ID",
+                        "name": "holiday_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully deleted holiday",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot delete holiday due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot delete holiday due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot delete holiday due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}/restore": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "restore a deleted organization",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "restore an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully restored organization",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot restore organization due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot restore organization due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot restore organization due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}/shift-schedules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the shift schedules owned by an organization, accepts the filters of GET /shift-schedules",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "get shift schedules of an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search in alias, description, organization, manager",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "Sort by field (alias, year, start_date, end_date, status, organization_name, manager_name)",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "DESC",
+                        "description": "Sort order (ASC or DESC)",
+                        "name": "sort_order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "get organization shift schedules successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.ShiftScheduleListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot get organization shift schedules due to invalid request parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot get organization shift schedules due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot get organization shift schedules due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the webhook subscriptions of an organization, secrets are never returned",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "get webhooks of an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully got webhooks",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot get webhooks due to invalid organization id",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot get webhooks due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot get webhooks due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "subscribe a url to the shift schedule events of the organization. Deliveries are signed with HMAC-SHA256 of \"\u003cX-Shyft-Timestamp\u003e.\u003cbody\u003e\" keyed by the secret in the X-Shyft-Signature header. The secret is only returned once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "create a webhook of an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "webhook",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.createWebhookDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully created webhook",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot create webhook due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot create webhook due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot create webhook due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}/webhooks/{webhook_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "update the url, event types, secret, description or activity of a webhook subscription, fields left out are kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "update a webhook of an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "webhook",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.updateWebhookDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully updated webhook",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot update webhook due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot update webhook due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot update webhook due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot update webhook due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "delete a webhook subscription (soft delete), its pending deliveries fail",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "delete a webhook of an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully deleted webhook",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot delete webhook due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot delete webhook due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot delete webhook due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}/webhooks/{webhook_id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the latest deliveries of a webhook subscription, newest first, with their attempts and last response",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "get deliveries of a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending, delivered or failed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of deliveries, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully got webhook deliveries",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot get webhook deliveries due to invalid query params",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot get webhook deliveries due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot get webhook deliveries due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot get webhook deliveries due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "queue a new delivery of the same event to the webhook subscription, attempted right away with a new delivery id and the same event id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "redeliver a webhook delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Delivery ID",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully queued webhook redelivery",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot redeliver webhook delivery due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot redeliver webhook delivery due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot redeliver webhook delivery due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}/webhooks/{webhook_id}/ping": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "queue a signed \"ping\" event to the webhook subscription, to check the receiver and its signature verification",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "ping a webhook of an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully queued webhook ping",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot ping webhook due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot ping webhook due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot ping webhook due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/shift-schedule/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "update a shift schedule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "update a shift schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shift Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "update shift schedule",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.updateShiftScheduleDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully updated shift schedule",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot update shift schedule due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "409": {
+                        "description": "cannot update shift schedule while it is approved, or due to conflicts (double bookings, overlaps, shifts out of range) listed in details",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "422": {
+                        "description": "cannot update shift schedule due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot update shift schedule due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/shift-schedules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get all shift schedules with pagination, search, sort and filter options",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "get all shift schedules with filters",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search in alias, description, organization, manager",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "Sort by field (alias, year, start_date, end_date, status, organization_name, manager_name)",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "DESC",
+                        "description": "Sort order (ASC or DESC)",
+                        "name": "sort_order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter only active records (not deleted)",
+                        "name": "active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by status (0:pending, 1:approved, 2:rejected)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by year",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by end date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by organization name",
+                        "name": "organization_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by organization mail",
+                        "name": "organization_mail",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by organization phone",
+                        "name": "organization_phone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by manager name",
+                        "name": "manager_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by manager mail",
+                        "name": "manager_mail",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by manager phone",
+                        "name": "manager_phone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by user ID",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by user name",
+                        "name": "user_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by user mail",
+                        "name": "user_mail",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by user phone",
+                        "name": "user_phone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by shift ID",
+                        "name": "shift_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by shift start (YYYY-MM-DD HH:MM:SS)",
+                        "name": "shift_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by shift end (YYYY-MM-DD HH:MM:SS)",
+                        "name": "shift_end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by shift user",
+                        "name": "shift_user",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "get all shift schedules successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.ShiftScheduleListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot get all shift schedules due to invalid request parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot get all shift schedules due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "create a new shift schedule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "create a new shift schedule",
+                "parameters": [
+                    {
+                        "description": "Alias",
+                        "name": "alias",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Description",
+                        "name": "description",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Frequency",
+                        "name": "frequency",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Start Date",
+                        "name": "start_date",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "End Date",
+                        "name": "end_date",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Year",
+                        "name": "year",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Organization",
+                        "name": "organization",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    {
+                        "description": "Manager",
+                        "name": "manager",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    {
+                        "description": "Users (full entries or references by id, e.g. [{\\",
+                        "name": "users",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    {
+                        "description": "Shifts",
+                        "name": "shifts",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    {
+                        "description": "Recurrence rules ([{\\",
+                        "name": "recurrence",
+                        "in": "body",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully created shift schedule",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot create shift schedule due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "409": {
+                        "description": "cannot create shift schedule due to conflicts (double bookings, overlaps, shifts out of range), listed in details",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "422": {
+                        "description": "cannot create shift schedule due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot create shift schedule due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/shift-schedules/deleted": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get all deleted shift schedules",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "get all deleted shift schedules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shift Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "get all shift schedules successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot get only deleted shift schedules due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "422": {
+                        "description": "cannot get only deleted shift schedules due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot get only deleted shift schedules due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/shift-schedules/id": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get a shift schedule by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "get a shift schedule by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shift Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "get shift by id successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot get shift schedule by id due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "422": {
+                        "description": "cannot get shift schedule by id due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot get shift schedule by id due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/shift-schedules/timeline": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the shift schedules overlapping the window, each with only its shifts overlapping the window (stored shifts are filtered in the database, recurrence occurrences and overrides are added)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "get the shift schedules of a date range",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start of the window (RFC 3339), defaults to now",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the window (RFC 3339), defaults to 7 days after from, at most 366 days after from",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone shifts are written in, defaults to the time zone of every schedule",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "get timeline successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot get timeline due to invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot get timeline due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot get timeline due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/shift-schedules/week": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get shift schedules by current week",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "get shift schedules by current week",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IANA time zone the week is taken in and shifts are written in, defaults to the time zone of every schedule",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "get shifts by current week successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot get shifts schedule by current week due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "422": {
+                        "description": "cannot get shifts schedule by current week due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot get shifts schedule by current week due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/shift-schedules/week/paginated": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get shift schedules by week with pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "get shift schedules by current week with pagination",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "size",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "orderBy",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone the week is taken in and shifts are written in, defaults to the time zone of every schedule",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "get shifts by current week successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot get shifts schedule by current week due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "422": {
+                        "description": "cannot get shifts schedule by current week due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot get shifts schedule by current week due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/shift-schedules/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "delete a shift schedule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "delete a shift schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shift Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully deleted shift schedule",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot delete shift schedule due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "422": {
+                        "description": "cannot delete shift schedule due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot delete shift schedule due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/shift-schedules/{id}/generate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "lay out back-to-back round-robin shifts of ` + "`" + `frequency` + "`" + ` days from the schedule users between its start and end date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "generate the shifts of a shift schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shift Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return the generated shifts without saving them",
+                        "name": "preview",
+                        "in": "query"
+                    },
+                    {
+                        "description": "rotation options",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.generateShiftScheduleDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully generated shifts",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot generate shifts due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot generate shifts due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot generate shifts due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "409": {
+                        "description": "cannot generate shifts while the shift schedule is approved or when they conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot generate shifts due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/shift-schedules/{id}/leave-conflicts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the shifts (with recurrences and overrides) of a shift schedule assigned to people on an approved leave",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "get the leave conflicts of a shift schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shift Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "get leave conflicts successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot get leave conflicts due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot get leave conflicts due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot get leave conflicts due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/shift-schedules/{id}/overrides": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the overrides of a shift schedule, optionally only those overlapping [from, to)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "get the overrides of a shift schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shift Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the period (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the period (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "get shift overrides successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot get shift overrides due to invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot get shift overrides due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot get shift overrides due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot get shift overrides due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "let a person cover the shift schedule for a period, on top of the rotation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "create a shift override",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shift Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "create shift override",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.createShiftOverrideDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully created shift override",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot create shift override due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot create shift override due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot create shift override due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "409": {
+                        "description": "cannot create shift override due to an approved shift schedule or conflicts",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot create shift override due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/shift-schedules/{id}/overrides/{override_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "delete a shift override (soft delete), the rotation applies again for its period",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "delete a shift override",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shift Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Shift Override ID",
+                        "name": "override_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully deleted shift override",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot delete shift override due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot delete shift override due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "409": {
+                        "description": "cannot delete shift override due to an approved shift schedule",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot delete shift override due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/shift-schedules/{id}/restore": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "restored a shift schedule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "restore a shift schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shift Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully restored shift schedule",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot restore shift schedule due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "422": {
+                        "description": "cannot restore shift schedule due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot restore shift schedule due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/shift-schedules/{id}/swaps": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the swap requests of a shift schedule, optionally only those in a status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift Swap"
+                ],
+                "summary": "get the swap requests of a shift schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shift Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "accepted",
+                            "declined",
+                            "cancelled",
+                            "rejected",
+                            "completed"
+                        ],
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "get shift swaps successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot get shift swaps due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot get shift swaps due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot get shift swaps due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "propose to swap one of the caller's shifts with the shift of another user of the schedule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift Swap"
+                ],
+                "summary": "propose a shift swap",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shift Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "propose shift swap",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.createShiftSwapDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully proposed shift swap",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot propose shift swap due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot propose shift swap due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot propose shift swap due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot propose shift swap due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/shift-schedules/{id}/{action}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "pending or rejected schedules are submitted, submitted schedules are approved or rejected (with a reason), approved or rejected schedules are reopened",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "submit, approve, reject or reopen a shift schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shift Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "submit",
+                            "approve",
+                            "reject",
+                            "reopen"
+                        ],
+                        "type": "string",
+                        "description": "Action",
+                        "name": "action",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "reason",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.transitionShiftScheduleDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully changed shift schedule status",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot change shift schedule status due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot change shift schedule status due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot change shift schedule status due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "409": {
+                        "description": "cannot change shift schedule status from its current status",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot change shift schedule status due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/shift-schedules/{year}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get a shift schedules by year",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift"
+                ],
+                "summary": "get a shift schedules by year",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shift Schedule Year",
+                        "name": "year",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone the year is taken in, defaults to the time zone of every schedule",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "get shifts by year successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot get shifts schedule by year due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "422": {
+                        "description": "cannot get shifts schedule by year due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot get shifts schedule by year due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/shift-swaps/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get a shift swap request by id together with its history",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift Swap"
+                ],
+                "summary": "get a shift swap request by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shift Swap ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "get shift swap by id successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot get shift swap due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot get shift swap due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "500": {
+                        "description": "cannot get shift swap due to internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/shift-swaps/{id}/{action}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "the responder accepts or declines, the requester cancels, the manager approves or rejects. The shifts are swapped in the schedule once the swap is accepted (and approved when the schedule requires it), unless the schedule is approved or the swapped shifts conflict.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shift Swap"
+                ],
+                "summary": "accept, decline, cancel, approve or reject a shift swap",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shift Swap ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "accept",
+                            "decline",
+                            "cancel",
+                            "approve",
+                            "reject"
+                        ],
+                        "type": "string",
+                        "description": "Action",
+                        "name": "action",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "comment",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.transitionShiftSwapDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully changed shift swap",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot change shift swap due to invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "403": {
+                        "description": "cannot change shift swap due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot change shift swap due to not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
                         }
                     },
-                    "400": {
-                        "description": "cannot get all shift schedules due to invalid request parameters",
+                    "409": {
+                        "description": "cannot change shift swap in its current status, of an approved schedule or with conflicting shifts",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     },
                     "500": {
-                        "description": "cannot get all shift schedules due to internal server error",
+                        "description": "cannot change shift swap due to internal server error",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/statistics/workload": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "create a new shift schedule",
+                "description": "get, per person across the shift schedules, the shift hours, the number of shifts, the weekend, holiday and night hours and the deviation from the team average",
                 "consumes": [
                     "application/json"
                 ],
@@ -291,129 +4123,72 @@ const docTemplate = `{
                 "tags": [
                     "Shift"
                 ],
-                "summary": "create a new shift schedule",
+                "summary": "get the workload statistics per person",
                 "parameters": [
                     {
-                        "description": "Alias",
-                        "name": "alias",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Description",
-                        "name": "description",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Frequency",
-                        "name": "frequency",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "integer"
-                        }
-                    },
-                    {
-                        "description": "Start Date",
-                        "name": "start_date",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "End Date",
-                        "name": "end_date",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Year",
-                        "name": "year",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "integer"
-                        }
+                        "type": "integer",
+                        "description": "Shift Schedule ID, defaults to every shift schedule of the organization",
+                        "name": "shift_schedule_id",
+                        "in": "query"
                     },
                     {
-                        "description": "Status",
-                        "name": "status",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "integer"
-                        }
+                        "type": "integer",
+                        "description": "Organization ID, defaults to the caller's organization",
+                        "name": "organization_id",
+                        "in": "query"
                     },
                     {
-                        "description": "Organization",
-                        "name": "organization",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
+                        "type": "integer",
+                        "description": "Only return the statistics of this user",
+                        "name": "user_id",
+                        "in": "query"
                     },
                     {
-                        "description": "Manager",
-                        "name": "manager",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
+                        "type": "string",
+                        "description": "IANA time zone the period is taken in, defaults to Europe/Istanbul",
+                        "name": "tz",
+                        "in": "query"
                     },
                     {
-                        "description": "Users",
-                        "name": "users",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
+                        "type": "string",
+                        "description": "Start of the period (RFC 3339), defaults to the start of the current year",
+                        "name": "from",
+                        "in": "query"
                     },
                     {
-                        "description": "Shifts",
-                        "name": "shifts",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
+                        "type": "string",
+                        "description": "End of the period (RFC 3339), defaults to one year after from",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "successfully created shift schedule",
+                        "description": "get workload statistics successfully",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     },
                     "400": {
-                        "description": "cannot create shift schedule due to invalid request body",
+                        "description": "cannot get workload statistics due to invalid query parameters",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     },
-                    "422": {
-                        "description": "cannot create shift schedule due to invalid request body",
+                    "403": {
+                        "description": "cannot get workload statistics due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot get workload statistics due to not found",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     },
                     "500": {
-                        "description": "cannot create shift schedule due to internal server error",
+                        "description": "cannot get workload statistics due to internal server error",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
@@ -421,14 +4196,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/shift-schedules/deleted": {
+        "/users": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "get all deleted shift schedules",
+                "description": "get all users with pagination, search, sort and filter options",
                 "consumes": [
                     "application/json"
                 ],
@@ -436,54 +4211,106 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Shift"
+                    "User"
                 ],
-                "summary": "get all deleted shift schedules",
+                "summary": "get all users with filters",
                 "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Shift Schedule ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Search in name, mail, phone, description",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "Sort by field (id, name, mail, phone, created_at, updated_at)",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "DESC",
+                        "description": "Sort order (ASC or DESC)",
+                        "name": "sort_order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter only active records (not deleted)",
+                        "name": "active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by mail",
+                        "name": "mail",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by phone",
+                        "name": "phone",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "get all shift schedules successfully",
+                        "description": "get all users successfully",
                         "schema": {
-                            "$ref": "#/definitions/handlers.RespondJson"
+                            "$ref": "#/definitions/models.UserListResponse"
                         }
                     },
                     "400": {
-                        "description": "cannot get only deleted shift schedules due to invalid request body",
+                        "description": "cannot get all users due to invalid request parameters",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     },
-                    "422": {
-                        "description": "cannot get only deleted shift schedules due to invalid request body",
+                    "403": {
+                        "description": "cannot get all users due to missing permission",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     },
                     "500": {
-                        "description": "cannot get only deleted shift schedules due to internal server error",
+                        "description": "cannot get all users due to internal server error",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     }
                 }
-            }
-        },
-        "/shift-schedules/id": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "get a shift schedule by id",
+                "description": "create a new user that can be referenced by id from shift schedules",
                 "consumes": [
                     "application/json"
                 ],
@@ -491,39 +4318,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Shift"
+                    "User"
                 ],
-                "summary": "get a shift schedule by id",
+                "summary": "create a new user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Shift Schedule ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "create user",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.createUserDTO"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "get shift by id successfully",
+                        "description": "successfully created user",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     },
                     "400": {
-                        "description": "cannot get shift schedule by id due to invalid request body",
+                        "description": "cannot create user due to invalid request body",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     },
-                    "422": {
-                        "description": "cannot get shift schedule by id due to invalid request body",
+                    "403": {
+                        "description": "cannot create user due to missing permission",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     },
                     "500": {
-                        "description": "cannot get shift schedule by id due to internal server error",
+                        "description": "cannot create user due to internal server error",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
@@ -531,14 +4360,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/shift-schedules/week": {
+        "/users/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "get shift schedules by current week",
+                "description": "get a user by id",
                 "consumes": [
                     "application/json"
                 ],
@@ -546,45 +4375,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Shift"
+                    "User"
+                ],
+                "summary": "get a user by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "get shift schedules by current week",
                 "responses": {
                     "200": {
-                        "description": "get shifts by current week successfully",
+                        "description": "get user by id successfully",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     },
-                    "400": {
-                        "description": "cannot get shifts schedule by current week due to invalid request body",
+                    "403": {
+                        "description": "cannot get user by id due to missing permission",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     },
-                    "422": {
-                        "description": "cannot get shifts schedule by current week due to invalid request body",
+                    "404": {
+                        "description": "cannot get user by id due to not found",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     },
                     "500": {
-                        "description": "cannot get shifts schedule by current week due to internal server error",
+                        "description": "cannot get user by id due to internal server error",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     }
                 }
-            }
-        },
-        "/shift-schedules/week/paginated": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "get shift schedules by week with pagination",
+                "description": "update a user, the change is reflected in every shift schedule the user belongs to",
                 "consumes": [
                     "application/json"
                 ],
@@ -592,69 +4428,67 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Shift"
+                    "User"
                 ],
-                "summary": "get shift schedules by current week with pagination",
+                "summary": "update a user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "size",
-                        "name": "size",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "asc",
-                            "desc"
-                        ],
-                        "type": "string",
-                        "description": "orderBy",
-                        "name": "orderBy",
-                        "in": "query"
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "update user",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.updateUserDTO"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "get shifts by current week successfully",
+                        "description": "successfully updated user",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     },
                     "400": {
-                        "description": "cannot get shifts schedule by current week due to invalid request body",
+                        "description": "cannot update user due to invalid request body",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     },
-                    "422": {
-                        "description": "cannot get shifts schedule by current week due to invalid request body",
+                    "403": {
+                        "description": "cannot update user due to missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RespondJson"
+                        }
+                    },
+                    "404": {
+                        "description": "cannot update user due to not found",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     },
                     "500": {
-                        "description": "cannot get shifts schedule by current week due to internal server error",
+                        "description": "cannot update user due to internal server error",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     }
                 }
-            }
-        },
-        "/shift-schedules/{id}": {
+            },
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "delete a shift schedule",
+                "description": "delete a user (soft delete)",
                 "consumes": [
                     "application/json"
                 ],
@@ -662,13 +4496,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Shift"
+                    "User"
                 ],
-                "summary": "delete a shift schedule",
+                "summary": "delete a user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Shift Schedule ID",
+                        "description": "User ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -676,25 +4510,25 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "successfully deleted shift schedule",
+                        "description": "successfully deleted user",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     },
-                    "400": {
-                        "description": "cannot delete shift schedule due to invalid request body",
+                    "403": {
+                        "description": "cannot delete user due to missing permission",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     },
-                    "422": {
-                        "description": "cannot delete shift schedule due to invalid request body",
+                    "404": {
+                        "description": "cannot delete user due to not found",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     },
                     "500": {
-                        "description": "cannot delete shift schedule due to internal server error",
+                        "description": "cannot delete user due to internal server error",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
@@ -702,14 +4536,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/shift-schedules/{id}/restore": {
+        "/users/{id}/restore": {
             "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "restored a shift schedule",
+                "description": "restore a deleted user",
                 "consumes": [
                     "application/json"
                 ],
@@ -717,13 +4551,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Shift"
+                    "User"
                 ],
-                "summary": "restore a shift schedule",
+                "summary": "restore a user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Shift Schedule ID",
+                        "description": "User ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -731,25 +4565,25 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "successfully restored shift schedule",
+                        "description": "successfully restored user",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     },
-                    "400": {
-                        "description": "cannot restore shift schedule due to invalid request body",
+                    "403": {
+                        "description": "cannot restore user due to missing permission",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     },
-                    "422": {
-                        "description": "cannot restore shift schedule due to invalid request body",
+                    "404": {
+                        "description": "cannot restore user due to not found",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     },
                     "500": {
-                        "description": "cannot restore shift schedule due to internal server error",
+                        "description": "cannot restore user due to internal server error",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
@@ -757,14 +4591,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/shift-schedules/{year}": {
+        "/users/{id}/swaps": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "get a shift schedules by year",
+                "description": "get the swap requests a user proposed or was asked for, optionally only those in a status",
                 "consumes": [
                     "application/json"
                 ],
@@ -772,39 +4606,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Shift"
+                    "Shift Swap"
                 ],
-                "summary": "get a shift schedules by year",
+                "summary": "get the swap requests of a user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Shift Schedule Year",
-                        "name": "year",
+                        "description": "User ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "accepted",
+                            "declined",
+                            "cancelled",
+                            "rejected",
+                            "completed"
+                        ],
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "get shifts by year successfully",
+                        "description": "get shift swaps successfully",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     },
                     "400": {
-                        "description": "cannot get shifts schedule by year due to invalid request body",
+                        "description": "cannot get shift swaps due to invalid user id",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     },
-                    "422": {
-                        "description": "cannot get shifts schedule by year due to invalid request body",
+                    "403": {
+                        "description": "cannot get shift swaps due to missing permission",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
                     },
                     "500": {
-                        "description": "cannot get shifts schedule by year due to internal server error",
+                        "description": "cannot get shift swaps due to internal server error",
                         "schema": {
                             "$ref": "#/definitions/handlers.RespondJson"
                         }
@@ -817,12 +4665,311 @@ const docTemplate = `{
         "handlers.RespondJson": {
             "type": "object",
             "properties": {
-                "intent": {
+                "details": {
+                    "description": "structured details of an error, e.g. conflicts"
+                },
+                "intent": {
+                    "type": "string"
+                },
+                "message": {},
+                "status": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handlers.createHolidayDTO": {
+            "type": "object",
+            "required": [
+                "date",
+                "name"
+            ],
+            "properties": {
+                "date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.createLeaveDTO": {
+            "type": "object",
+            "required": [
+                "end",
+                "start",
+                "type"
+            ],
+            "properties": {
+                "end": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "start": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "vacation, sick, training",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "defaults to the caller",
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.createManagerDTO": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "mail": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "type": "integer"
+                },
+                "person_id": {
+                    "description": "PersonID is the user the manager is, it defaults to the user of the organization with the same mail",
+                    "type": "integer"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.createOrganizationDTO": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "mail": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.createShiftOverrideDTO": {
+            "type": "object",
+            "required": [
+                "end",
+                "start",
+                "user_id"
+            ],
+            "properties": {
+                "end": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "start": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "person covering the shift schedule",
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.createShiftSwapDTO": {
+            "type": "object",
+            "required": [
+                "shift_id",
+                "target_shift_id"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "shift_id": {
+                    "description": "id of the requester's shift inside ` + "`" + `shifts` + "`" + `, may be 0",
+                    "type": "integer"
+                },
+                "target_shift_id": {
+                    "description": "id of the responder's shift inside ` + "`" + `shifts` + "`" + `, may be 0",
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.createUserDTO": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "mail": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "description": "OrganizationID defaults to the caller's organization",
+                    "type": "integer"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.createWebhookDTO": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "active": {
+                    "description": "defaults to true",
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "event_types": {
+                    "description": "empty subscribes to every event",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "description": "generated when empty",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.decideLeaveDTO": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.generateShiftScheduleDTO": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "description": "Balance assigns shifts toward equal load, counting the hours worked in the organization's\nshift schedules from BalanceFrom (defaults to one year before the schedule start) until the schedule start",
+                    "type": "boolean"
+                },
+                "balance_from": {
+                    "type": "string"
+                },
+                "handover_time": {
+                    "description": "HH:MM",
+                    "type": "string"
+                },
+                "holiday_order": {
+                    "description": "HolidayOrder (user ids) takes the organization's holidays out of the regular rotation",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "order": {
+                    "description": "user ids in rotation order",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "preview": {
+                    "description": "return the shifts without saving them",
+                    "type": "boolean"
+                },
+                "start_user_id": {
+                    "description": "user taking the first shift",
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.transitionShiftScheduleDTO": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "description": "required to reject",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.transitionShiftSwapDTO": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.updateManagerDTO": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "mail": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "type": "integer"
+                },
+                "person_id": {
+                    "description": "PersonID is the user the manager is, it defaults to the user of the organization with the same mail",
+                    "type": "integer"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.updateOrganizationDTO": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
                     "type": "string"
                 },
-                "message": {},
-                "status": {
-                    "type": "boolean"
+                "mail": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
                 }
             }
         },
@@ -862,6 +5009,13 @@ const docTemplate = `{
                     "type": "array",
                     "items": {}
                 },
+                "recurrence": {
+                    "description": "recurring shift patterns (RRULE)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RecurrenceRule"
+                    }
+                },
                 "shifts": {
                     "type": "array",
                     "items": {}
@@ -869,9 +5023,13 @@ const docTemplate = `{
                 "start_date": {
                     "type": "string"
                 },
-                "status": {
-                    "description": "0: pending, 1: approved, 2: rejected",
-                    "type": "integer"
+                "swap_requires_approval": {
+                    "description": "shift swaps also need the manager approval",
+                    "type": "boolean"
+                },
+                "time_zone": {
+                    "description": "IANA time zone, unchanged when empty",
+                    "type": "string"
                 },
                 "users": {
                     "type": "array",
@@ -882,6 +5040,199 @@ const docTemplate = `{
                 }
             }
         },
+        "handlers.updateUserDTO": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "mail": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.updateWebhookDTO": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "event_types": {
+                    "description": "empty subscribes to every event",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "description": "rotates the secret",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Manager": {
+            "type": "object",
+            "properties": {
+                "CreatedAt": {
+                    "type": "string"
+                },
+                "UpdatedAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mail": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "type": "integer"
+                },
+                "person_id": {
+                    "description": "Person (user) the manager is. Callers are matched against it by the user id of their token, manager ids\nand user ids are different sequences.",
+                    "type": "integer"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ManagerListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Manager"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "sort_by": {
+                    "type": "string"
+                },
+                "sort_order": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Organization": {
+            "type": "object",
+            "properties": {
+                "CreatedAt": {
+                    "type": "string"
+                },
+                "UpdatedAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mail": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OrganizationListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Organization"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "sort_by": {
+                    "type": "string"
+                },
+                "sort_order": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.RecurrenceRule": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "description": "length of each occurrence, e.g. \"8h\" or \"24h\"",
+                    "type": "string"
+                },
+                "exdates": {
+                    "description": "EXDATE exceptions, start times of skipped occurrences",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rrule": {
+                    "description": "RFC 5545 RRULE, e.g. \"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO\"",
+                    "type": "string"
+                },
+                "start": {
+                    "description": "DTSTART of the first occurrence, defaults to the schedule start date",
+                    "type": "string"
+                },
+                "user_ids": {
+                    "description": "users rotating through the occurrences, defaults to the schedule users",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
         "models.ShiftSchedule": {
             "type": "object",
             "properties": {
@@ -907,14 +5258,35 @@ const docTemplate = `{
                     "description": "1, 2, 3, 4, 5, 6, 7 (days of the week)",
                     "type": "integer"
                 },
+                "history": {
+                    "description": "Status changes, only loaded when a single shift schedule is read",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ShiftScheduleTransition"
+                    }
+                },
                 "manager": {
                     "type": "array",
                     "items": {}
                 },
+                "manager_id": {
+                    "type": "integer"
+                },
                 "organization": {
                     "type": "array",
                     "items": {}
                 },
+                "organization_id": {
+                    "description": "Owning organization and manager (first entries of the organization and manager JSONB)",
+                    "type": "integer"
+                },
+                "recurrence": {
+                    "description": "Recurring shift patterns, expanded into shifts on read",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RecurrenceRule"
+                    }
+                },
                 "shifts": {
                     "type": "array",
                     "items": {}
@@ -923,9 +5295,17 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "status": {
-                    "description": "0: pending, 1: approved, 2: rejected",
+                    "description": "0: pending, 1: approved, 2: rejected, 3: submitted",
                     "type": "integer"
                 },
+                "swap_requires_approval": {
+                    "description": "Shift swaps accepted by the responder also need the approval of the manager",
+                    "type": "boolean"
+                },
+                "time_zone": {
+                    "description": "IANA time zone the shifts, weeks and handovers of the schedule are laid out in",
+                    "type": "string"
+                },
                 "users": {
                     "type": "array",
                     "items": {}
@@ -963,6 +5343,96 @@ const docTemplate = `{
                     "type": "integer"
                 }
             }
+        },
+        "models.ShiftScheduleTransition": {
+            "type": "object",
+            "properties": {
+                "CreatedAt": {
+                    "type": "string"
+                },
+                "action": {
+                    "description": "submit, approve, reject, reopen",
+                    "type": "string"
+                },
+                "actor_id": {
+                    "description": "user id of the caller who changed the status",
+                    "type": "integer"
+                },
+                "from_status": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "shift_schedule_id": {
+                    "type": "integer"
+                },
+                "to_status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.User": {
+            "type": "object",
+            "properties": {
+                "CreatedAt": {
+                    "type": "string"
+                },
+                "UpdatedAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mail": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "description": "Organization the person belongs to",
+                    "type": "integer"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.UserListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.User"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "sort_by": {
+                    "type": "string"
+                },
+                "sort_order": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
         }
     }
 }`
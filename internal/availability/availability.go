@@ -0,0 +1,84 @@
+package availability
+
+import (
+	"time"
+
+	"shyft/internal/models"
+)
+
+// Calendar tells whether people are on an approved leave
+type Calendar struct {
+	leaves map[uint][]models.Leave
+}
+
+// NewCalendar creates a calendar of the approved leaves among leaves
+func NewCalendar(leaves []models.Leave) *Calendar {
+	c := &Calendar{leaves: map[uint][]models.Leave{}}
+	for _, leave := range leaves {
+		if leave.Status != models.LeaveApproved {
+			continue
+		}
+		c.leaves[leave.PersonID] = append(c.leaves[leave.PersonID], leave)
+	}
+	return c
+}
+
+// LeaveOf returns the approved leave of the person overlapping [start, end), nil when the person is available.
+// A nil calendar has no leaves.
+func (c *Calendar) LeaveOf(personID uint, start, end time.Time) *models.Leave {
+	if c == nil || personID == 0 {
+		return nil
+	}
+	for i, leave := range c.leaves[personID] {
+		if leave.StartAt.Before(end) && leave.EndAt.After(start) {
+			return &c.leaves[personID][i]
+		}
+	}
+	return nil
+}
+
+// Unavailable reports whether the person is on an approved leave overlapping [start, end)
+func (c *Calendar) Unavailable(personID uint, start, end time.Time) bool {
+	return c.LeaveOf(personID, start, end) != nil
+}
+
+// Conflicts returns the shift entries of the schedule assigned to people on an approved leave
+func Conflicts(schedule *models.ShiftSchedule, entries models.JSONB, c *Calendar, loc *time.Location) []models.LeaveConflict {
+	conflicts := []models.LeaveConflict{}
+	for _, entry := range entries {
+		shift, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		user, ok := shift["user"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		start, _ := shift["start"].(string)
+		end, _ := shift["end"].(string)
+		startAt, err := models.ParseShiftTime(start, loc)
+		if err != nil {
+			continue
+		}
+		endAt, err := models.ParseShiftTime(end, loc)
+		if err != nil {
+			continue
+		}
+
+		contact := models.ContactFromJSON(user)
+		leave := c.LeaveOf(contact.ID, startAt, endAt)
+		if leave == nil {
+			continue
+		}
+		conflicts = append(conflicts, models.LeaveConflict{
+			ShiftScheduleID: schedule.ID,
+			ShiftRef:        models.ShiftRefOf(shift),
+			Start:           startAt.In(loc),
+			End:             endAt.In(loc),
+			User:            contact,
+			LeaveID:         leave.ID,
+			LeaveType:       leave.Type,
+		})
+	}
+	return conflicts
+}
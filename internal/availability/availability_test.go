@@ -0,0 +1,87 @@
+package availability
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"shyft/internal/models"
+	"shyft/internal/testutil"
+)
+
+func leaves() []models.Leave {
+	return []models.Leave{
+		{ID: 1, PersonID: 1, Type: "vacation", Status: models.LeaveApproved, StartAt: testutil.At(3, 0, 0), EndAt: testutil.At(5, 0, 0)},
+		{ID: 2, PersonID: 2, Type: "sick", Status: models.LeavePending, StartAt: testutil.At(3, 0, 0), EndAt: testutil.At(5, 0, 0)},
+		{ID: 3, PersonID: 2, Type: "training", Status: models.LeaveRejected, StartAt: testutil.At(6, 0, 0), EndAt: testutil.At(7, 0, 0)},
+		{ID: 4, PersonID: 1, Type: "training", Status: models.LeaveApproved, StartAt: testutil.At(10, 0, 0), EndAt: testutil.At(11, 0, 0)},
+	}
+}
+
+func TestLeaveOf(t *testing.T) {
+	calendar := NewCalendar(leaves())
+
+	tests := []struct {
+		name       string
+		calendar   *Calendar
+		personID   uint
+		start, end time.Time
+		want       uint // id of the leave, 0 when available
+	}{
+		{"overlapping an approved leave", calendar, 1, testutil.At(2, 9, 0), testutil.At(3, 9, 0), 1},
+		{"inside of an approved leave", calendar, 1, testutil.At(3, 9, 0), testutil.At(4, 9, 0), 1},
+		{"another approved leave", calendar, 1, testutil.At(10, 9, 0), testutil.At(12, 9, 0), 4},
+		{"ending when the leave starts", calendar, 1, testutil.At(2, 0, 0), testutil.At(3, 0, 0), 0},
+		{"starting when the leave ends", calendar, 1, testutil.At(5, 0, 0), testutil.At(6, 0, 0), 0},
+		{"pending leave", calendar, 2, testutil.At(3, 9, 0), testutil.At(4, 9, 0), 0},
+		{"rejected leave", calendar, 2, testutil.At(6, 9, 0), testutil.At(6, 10, 0), 0},
+		{"person without id", calendar, 0, testutil.At(3, 9, 0), testutil.At(4, 9, 0), 0},
+		{"nil calendar", nil, 1, testutil.At(3, 9, 0), testutil.At(4, 9, 0), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got uint
+			if leave := tt.calendar.LeaveOf(tt.personID, tt.start, tt.end); leave != nil {
+				got = leave.ID
+			}
+			if got != tt.want {
+				t.Errorf("LeaveOf() = leave %d, want leave %d", got, tt.want)
+			}
+			if unavailable := tt.calendar.Unavailable(tt.personID, tt.start, tt.end); unavailable != (tt.want != 0) {
+				t.Errorf("Unavailable() = %v, want %v", unavailable, tt.want != 0)
+			}
+		})
+	}
+}
+
+func TestConflicts(t *testing.T) {
+	schedule := &models.ShiftSchedule{ID: 9}
+	user := func(id uint) map[string]interface{} {
+		return models.Contact{ID: id, Name: "user"}.Projection()
+	}
+	entries := models.JSONB{
+		map[string]interface{}{"id": 0, "start": "2026-03-02 09:00:00", "end": "2026-03-03 09:00:00", "user": user(1)},
+		map[string]interface{}{"id": 1, "start": "2026-03-03 09:00:00", "end": "2026-03-04 09:00:00", "user": user(2)},
+		map[string]interface{}{"rule": 0, "occurrence": 3, "start": "2026-03-10 09:00:00", "end": "2026-03-10 17:00:00", "user": user(1)},
+		map[string]interface{}{"override": 5, "start": "2026-03-04T09:00:00Z", "end": "2026-03-04T10:00:00Z", "user": user(1)},
+		map[string]interface{}{"id": 2, "start": "soon", "end": "2026-03-04 09:00:00", "user": user(1)},
+		map[string]interface{}{"id": 3, "start": "2026-03-03 09:00:00", "end": "2026-03-04 09:00:00"},
+		"not an object",
+	}
+
+	var got []string
+	for _, c := range Conflicts(schedule, entries, NewCalendar(leaves()), time.UTC) {
+		if c.ShiftScheduleID != schedule.ID || c.User.ID != 1 {
+			t.Errorf("conflict %+v, want one of user 1 in schedule %d", c, schedule.ID)
+		}
+		got = append(got, c.ShiftRef.String()+" "+c.LeaveType)
+	}
+	want := []string{"shift 0 vacation", "occurrence 3 of rule 0 training", "override 5 vacation"}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Conflicts() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if conflicts := Conflicts(schedule, entries, nil, time.UTC); conflicts == nil || len(conflicts) != 0 {
+		t.Errorf("Conflicts() without calendar = %v, want an empty list", conflicts)
+	}
+}
@@ -0,0 +1,155 @@
+package conflict
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"shyft/internal/models"
+)
+
+// Schedule is a shift schedule together with the shifts to check
+type Schedule struct {
+	Schedule *models.ShiftSchedule
+	Shifts   models.JSONB // stored shifts, recurrence occurrences and overrides
+}
+
+// Error carries the conflicts preventing a shift schedule from being saved
+type Error struct {
+	Conflicts []models.Conflict
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("shift schedule has %d conflict(s)", len(e.Conflicts))
+}
+
+// Details returns the conflicts, reported along with the error message
+func (e *Error) Details() interface{} {
+	return e.Conflicts
+}
+
+// Detect finds the shifts outside of their schedule start and end date, the overlapping shifts of a schedule
+// and the people on overlapping shifts of two schedules. Shift times are read in the time zone of their schedule.
+func Detect(schedules []Schedule) []models.Conflict {
+	conflicts := []models.Conflict{}
+	var all []models.ConflictShift
+	for _, s := range schedules {
+		shifts := shiftsOf(s.Schedule, s.Shifts, s.Schedule.Location())
+		conflicts = append(conflicts, outOfRange(s.Schedule, shifts)...)
+		conflicts = append(conflicts, overlaps(shifts, func(a, b models.ConflictShift) *models.Conflict {
+			return &models.Conflict{
+				Type:    models.ConflictOverlap,
+				Message: fmt.Sprintf("%s and %s of %q overlap", a.ShiftRef, b.ShiftRef, a.Alias),
+				Shifts:  []models.ConflictShift{a, b},
+			}
+		})...)
+		all = append(all, shifts...)
+	}
+
+	// Double booking: overlapping shifts of the same person in two schedules
+	byPerson := map[string][]models.ConflictShift{}
+	for _, shift := range all {
+		if key := shift.User.PersonKey(); key != "" {
+			byPerson[key] = append(byPerson[key], shift)
+		}
+	}
+	keys := make([]string, 0, len(byPerson))
+	for key := range byPerson {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		conflicts = append(conflicts, overlaps(byPerson[key], func(a, b models.ConflictShift) *models.Conflict {
+			if a.ShiftScheduleID == b.ShiftScheduleID {
+				return nil
+			}
+			return &models.Conflict{
+				Type:    models.ConflictDoubleBooking,
+				Message: fmt.Sprintf("%s is on shift in both %q and %q", a.User.Name, a.Alias, b.Alias),
+				Shifts:  []models.ConflictShift{a, b},
+			}
+		})...)
+	}
+	return conflicts
+}
+
+// Involving keeps the conflicts a shift of the given schedule takes part in
+func Involving(conflicts []models.Conflict, scheduleID uint) []models.Conflict {
+	result := []models.Conflict{}
+	for _, c := range conflicts {
+		for _, shift := range c.Shifts {
+			if shift.ShiftScheduleID == scheduleID {
+				result = append(result, c)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// shifts of the schedule outside of its start and end date
+func outOfRange(schedule *models.ShiftSchedule, shifts []models.ConflictShift) []models.Conflict {
+	var conflicts []models.Conflict
+	for _, shift := range shifts {
+		if shift.Start.Before(schedule.Start_Date) || shift.End.After(schedule.End_Date) {
+			conflicts = append(conflicts, models.Conflict{
+				Type:    models.ConflictOutOfRange,
+				Message: fmt.Sprintf("%s of %q is outside of the shift schedule start and end date", shift.ShiftRef, shift.Alias),
+				Shifts:  []models.ConflictShift{shift},
+			})
+		}
+	}
+	return conflicts
+}
+
+// overlaps calls conflict for every pair of overlapping shifts, keeping the non nil results
+func overlaps(shifts []models.ConflictShift, conflict func(a, b models.ConflictShift) *models.Conflict) []models.Conflict {
+	sorted := append([]models.ConflictShift{}, shifts...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Start.Before(sorted[j].Start)
+	})
+
+	var conflicts []models.Conflict
+	for i := range sorted {
+		for j := i + 1; j < len(sorted) && sorted[j].Start.Before(sorted[i].End); j++ {
+			if c := conflict(sorted[i], sorted[j]); c != nil {
+				conflicts = append(conflicts, *c)
+			}
+		}
+	}
+	return conflicts
+}
+
+// convert shift JSONB entries into conflict shifts, skipping entries without valid times
+func shiftsOf(schedule *models.ShiftSchedule, entries models.JSONB, loc *time.Location) []models.ConflictShift {
+	var shifts []models.ConflictShift
+	for _, entry := range entries {
+		values, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		start, _ := values["start"].(string)
+		end, _ := values["end"].(string)
+		startAt, err := models.ParseShiftTime(start, loc)
+		if err != nil {
+			continue
+		}
+		endAt, err := models.ParseShiftTime(end, loc)
+		if err != nil {
+			continue
+		}
+
+		shift := models.ConflictShift{
+			ShiftScheduleID: schedule.ID,
+			Alias:           schedule.Alias,
+			Start:           startAt.In(loc),
+			End:             endAt.In(loc),
+			ShiftRef:        models.ShiftRefOf(values),
+		}
+		if user, ok := values["user"].(map[string]interface{}); ok {
+			shift.User = models.ContactFromJSON(user)
+		}
+		shifts = append(shifts, shift)
+	}
+	return shifts
+}
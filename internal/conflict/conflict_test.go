@@ -0,0 +1,149 @@
+package conflict
+
+import (
+	"strings"
+	"testing"
+
+	"shyft/internal/models"
+	"shyft/internal/testutil"
+)
+
+func schedule(id uint, alias string, shifts ...interface{}) Schedule {
+	shiftSchedule := testutil.Schedule("UTC", "2026-03-02 09:00:00", "2026-03-16 09:00:00")
+	shiftSchedule.ID, shiftSchedule.Alias = id, alias
+	return Schedule{Schedule: shiftSchedule, Shifts: models.JSONB(shifts)}
+}
+
+func entry(key string, id int, start, end string, user models.Contact) map[string]interface{} {
+	shift := testutil.Entry(start, end, user)
+	shift[key] = id
+	return shift
+}
+
+var (
+	alice = models.Contact{ID: 1, Name: "Alice"}
+	bob   = models.Contact{ID: 2, Name: "Bob"}
+	// carol is embedded by mail only in one schedule
+	carol       = models.Contact{ID: 3, Name: "Carol", Mail: "carol@example.com"}
+	carolByMail = models.Contact{Name: "Carol", Mail: "Carol@Example.com"}
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name      string
+		schedules []Schedule
+		want      []string // "<type>: <message>"
+	}{
+		{
+			name: "back to back shifts",
+			schedules: []Schedule{schedule(1, "ops",
+				entry("id", 0, "2026-03-02 09:00:00", "2026-03-09 09:00:00", alice),
+				entry("id", 1, "2026-03-09 09:00:00", "2026-03-16 09:00:00", bob),
+			)},
+		},
+		{
+			name: "overlapping shifts of a schedule",
+			schedules: []Schedule{schedule(1, "ops",
+				entry("id", 0, "2026-03-02 09:00:00", "2026-03-09 10:00:00", alice),
+				entry("id", 1, "2026-03-09 09:00:00", "2026-03-16 09:00:00", bob),
+			)},
+			want: []string{`overlap: shift 0 and shift 1 of "ops" overlap`},
+		},
+		{
+			name: "shifts outside of the schedule",
+			schedules: []Schedule{schedule(1, "ops",
+				entry("id", 0, "2026-03-01 09:00:00", "2026-03-02 09:00:00", alice),
+				entry("id", 1, "2026-03-15T09:00:00Z", "2026-03-16T10:00:00Z", bob),
+			)},
+			want: []string{
+				`out_of_range: shift 0 of "ops" is outside of the shift schedule start and end date`,
+				`out_of_range: shift 1 of "ops" is outside of the shift schedule start and end date`,
+			},
+		},
+		{
+			name: "occurrences and overrides are told apart from stored shifts",
+			schedules: []Schedule{schedule(1, "ops",
+				entry("id", 0, "2026-03-02 09:00:00", "2026-03-03 09:00:00", alice),
+				map[string]interface{}{"rule": 0, "occurrence": 0, "start": "2026-03-02 12:00:00", "end": "2026-03-02 13:00:00", "user": bob.Projection()},
+				entry("override", 0, "2026-03-02 12:30:00", "2026-03-02 14:00:00", carol),
+			)},
+			want: []string{
+				`overlap: shift 0 and occurrence 0 of rule 0 of "ops" overlap`,
+				`overlap: shift 0 and override 0 of "ops" overlap`,
+				`overlap: occurrence 0 of rule 0 and override 0 of "ops" overlap`,
+			},
+		},
+		{
+			name: "double booking across schedules",
+			schedules: []Schedule{
+				schedule(1, "ops", entry("id", 0, "2026-03-02 09:00:00", "2026-03-09 09:00:00", alice)),
+				schedule(2, "db", entry("id", 0, "2026-03-08 09:00:00", "2026-03-09 09:00:00", alice)),
+			},
+			want: []string{`double_booking: Alice is on shift in both "ops" and "db"`},
+		},
+		{
+			name: "double booking of a person embedded by mail",
+			schedules: []Schedule{
+				schedule(1, "ops", entry("id", 0, "2026-03-02 09:00:00", "2026-03-09 09:00:00", carolByMail)),
+				schedule(2, "db", entry("id", 0, "2026-03-08 09:00:00", "2026-03-09 09:00:00", models.Contact{Name: "C", Mail: "carol@example.com"})),
+			},
+			want: []string{`double_booking: Carol is on shift in both "ops" and "db"`},
+		},
+		{
+			name: "different people on overlapping shifts of two schedules",
+			schedules: []Schedule{
+				schedule(1, "ops", entry("id", 0, "2026-03-02 09:00:00", "2026-03-09 09:00:00", alice)),
+				schedule(2, "db", entry("id", 0, "2026-03-02 09:00:00", "2026-03-09 09:00:00", bob)),
+			},
+		},
+		{
+			name: "entries without valid times are skipped",
+			schedules: []Schedule{schedule(1, "ops",
+				"not an object",
+				entry("id", 0, "soon", "2026-03-09 09:00:00", alice),
+				entry("id", 1, "2026-03-02 09:00:00", "later", alice),
+			)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, c := range Detect(tt.schedules) {
+				got = append(got, c.Type+": "+c.Message)
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Detect() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestInvolving(t *testing.T) {
+	conflicts := Detect([]Schedule{
+		schedule(1, "ops",
+			entry("id", 0, "2026-03-02 09:00:00", "2026-03-09 09:00:00", alice),
+			entry("id", 1, "2026-03-08 09:00:00", "2026-03-09 09:00:00", bob),
+		),
+		schedule(2, "db", entry("id", 0, "2026-03-08 09:00:00", "2026-03-09 09:00:00", alice)),
+		schedule(3, "web", entry("id", 0, "2026-03-01 09:00:00", "2026-03-03 09:00:00", bob)),
+	})
+
+	tests := []struct {
+		scheduleID uint
+		want       []string
+	}{
+		{1, []string{models.ConflictOverlap, models.ConflictDoubleBooking}},
+		{2, []string{models.ConflictDoubleBooking}},
+		{3, []string{models.ConflictOutOfRange}},
+		{4, nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, c := range Involving(conflicts, tt.scheduleID) {
+			got = append(got, c.Type)
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("Involving(%d) = %v, want %v", tt.scheduleID, got, tt.want)
+		}
+	}
+}
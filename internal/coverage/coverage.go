@@ -0,0 +1,99 @@
+package coverage
+
+import (
+	"sort"
+	"time"
+
+	"shyft/internal/models"
+)
+
+type interval struct {
+	start, end time.Time
+}
+
+// Report returns the periods of [from, to), within the schedule start and end date, that no shift with
+// an assignee covers: between consecutive shifts, before the first and after the last one. Shift times are read
+// in the time zone of the schedule and reported in loc.
+func Report(schedule *models.ShiftSchedule, entries models.JSONB, from, to time.Time, loc *time.Location) models.CoverageReport {
+	if from.Before(schedule.Start_Date) {
+		from = schedule.Start_Date
+	}
+	if to.After(schedule.End_Date) {
+		to = schedule.End_Date
+	}
+	report := models.CoverageReport{
+		ShiftScheduleID: schedule.ID,
+		Alias:           schedule.Alias,
+		From:            from.In(loc),
+		To:              to.In(loc),
+		Gaps:            []models.CoverageGap{},
+	}
+	if !to.After(from) {
+		return report
+	}
+
+	covered := assigned(entries, schedule.Location())
+	sort.Slice(covered, func(i, j int) bool {
+		return covered[i].start.Before(covered[j].start)
+	})
+
+	// Walk the covered intervals in order, every uncovered stretch before the next one is a gap
+	cursor := from
+	for _, c := range covered {
+		if !c.end.After(cursor) {
+			continue
+		}
+		if !c.start.Before(to) {
+			break
+		}
+		if c.start.After(cursor) {
+			report.Gaps = append(report.Gaps, gap(cursor, c.start, loc))
+		}
+		cursor = c.end
+		if !cursor.Before(to) {
+			break
+		}
+	}
+	if cursor.Before(to) {
+		report.Gaps = append(report.Gaps, gap(cursor, to, loc))
+	}
+
+	for _, g := range report.Gaps {
+		report.UncoveredHours += g.Hours
+	}
+	return report
+}
+
+func gap(start, end time.Time, loc *time.Location) models.CoverageGap {
+	return models.CoverageGap{Start: start.In(loc), End: end.In(loc), Hours: end.Sub(start).Hours()}
+}
+
+// intervals of the shift entries that have an assignee
+func assigned(entries models.JSONB, loc *time.Location) []interval {
+	var intervals []interval
+	for _, entry := range entries {
+		values, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		user, ok := values["user"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if contact := models.ContactFromJSON(user); contact.ID == 0 && contact.Name == "" && contact.Mail == "" {
+			continue
+		}
+		start, _ := values["start"].(string)
+		end, _ := values["end"].(string)
+		startAt, err := models.ParseShiftTime(start, loc)
+		if err != nil {
+			continue
+		}
+		endAt, err := models.ParseShiftTime(end, loc)
+		if err != nil || !endAt.After(startAt) {
+			continue
+		}
+		intervals = append(intervals, interval{start: startAt, end: endAt})
+	}
+	return intervals
+}
@@ -0,0 +1,131 @@
+package coverage
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"shyft/internal/models"
+	"shyft/internal/testutil"
+)
+
+var alice = models.Contact{ID: 1, Name: "Alice"}
+
+func TestReport(t *testing.T) {
+	istanbul, err := time.LoadLocation("Europe/Istanbul")
+	if err != nil {
+		t.Fatal(err)
+	}
+	schedule := &models.ShiftSchedule{
+		ID:         4,
+		Alias:      "ops",
+		Start_Date: time.Date(2026, 3, 2, 6, 0, 0, 0, time.UTC), // 09:00 in Istanbul
+		End_Date:   time.Date(2026, 3, 9, 6, 0, 0, 0, time.UTC),
+		TimeZone:   "Europe/Istanbul",
+	}
+
+	tests := []struct {
+		name     string
+		entries  models.JSONB
+		from, to time.Time
+		loc      *time.Location
+		want     []string // "<start> <end> <hours>"
+		hours    float64
+	}{
+		{
+			name: "fully covered",
+			entries: models.JSONB{
+				testutil.Entry("2026-03-02 09:00:00", "2026-03-05 09:00:00", alice),
+				testutil.Entry("2026-03-05 09:00:00", "2026-03-09 09:00:00", alice),
+			},
+		},
+		{
+			name:    "no shifts",
+			want:    []string{"2026-03-02T06:00:00Z 2026-03-09T06:00:00Z 168"},
+			hours:   168,
+			entries: models.JSONB{},
+		},
+		{
+			name: "gaps before, between and after the shifts",
+			entries: models.JSONB{
+				testutil.Entry("2026-03-03 09:00:00", "2026-03-04 09:00:00", alice),
+				testutil.Entry("2026-03-05 09:00:00", "2026-03-08 09:00:00", alice),
+			},
+			want: []string{
+				"2026-03-02T06:00:00Z 2026-03-03T06:00:00Z 24",
+				"2026-03-04T06:00:00Z 2026-03-05T06:00:00Z 24",
+				"2026-03-08T06:00:00Z 2026-03-09T06:00:00Z 24",
+			},
+			hours: 72,
+		},
+		{
+			name: "overlapping and unordered shifts",
+			entries: models.JSONB{
+				testutil.Entry("2026-03-04 09:00:00", "2026-03-09 09:00:00", alice),
+				testutil.Entry("2026-03-02 09:00:00", "2026-03-05 09:00:00", alice),
+				testutil.Entry("2026-03-03 09:00:00", "2026-03-04 09:00:00", alice),
+			},
+		},
+		{
+			name: "shifts without assignee or valid times do not cover",
+			entries: models.JSONB{
+				testutil.Entry("2026-03-02 09:00:00", "2026-03-05 09:00:00", models.Contact{}),
+				map[string]interface{}{"start": "2026-03-02 09:00:00", "end": "2026-03-05 09:00:00"},
+				testutil.Entry("2026-03-02 09:00:00", "soon", alice),
+				testutil.Entry("2026-03-05 09:00:00", "2026-03-05 09:00:00", alice),
+				"not an object",
+				testutil.Entry("2026-03-05T06:00:00Z", "2026-03-09 09:00:00", alice),
+			},
+			want:  []string{"2026-03-02T06:00:00Z 2026-03-05T06:00:00Z 72"},
+			hours: 72,
+		},
+		{
+			name:    "the range is clipped to the schedule and reported in loc",
+			entries: models.JSONB{testutil.Entry("2026-03-02 09:00:00", "2026-03-08 09:00:00", alice)},
+			from:    time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			to:      time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+			loc:     istanbul,
+			want:    []string{"2026-03-08T09:00:00+03:00 2026-03-09T09:00:00+03:00 24"},
+			hours:   24,
+		},
+		{
+			name:    "a range inside of a gap",
+			entries: models.JSONB{testutil.Entry("2026-03-02 09:00:00", "2026-03-03 09:00:00", alice)},
+			from:    time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC),
+			to:      time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC),
+			want:    []string{"2026-03-04T00:00:00Z 2026-03-04T12:00:00Z 12"},
+			hours:   12,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, loc := tt.from, tt.to, tt.loc
+			if from.IsZero() {
+				from, to = schedule.Start_Date, schedule.End_Date
+			}
+			if loc == nil {
+				loc = time.UTC
+			}
+			report := Report(schedule, tt.entries, from, to, loc)
+			var got []string
+			for _, g := range report.Gaps {
+				got = append(got, fmt.Sprintf("%s %s %g", g.Start.Format(time.RFC3339), g.End.Format(time.RFC3339), g.Hours))
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Report() gaps =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+			if report.UncoveredHours != tt.hours {
+				t.Errorf("Report() uncovered hours = %g, want %g", report.UncoveredHours, tt.hours)
+			}
+			if report.ShiftScheduleID != schedule.ID || report.Alias != schedule.Alias || report.Gaps == nil {
+				t.Errorf("Report() = %+v, want the schedule id, alias and non nil gaps", report)
+			}
+		})
+	}
+
+	empty := Report(schedule, nil, schedule.End_Date, schedule.End_Date.Add(time.Hour), time.UTC)
+	if len(empty.Gaps) != 0 || empty.UncoveredHours != 0 {
+		t.Errorf("Report() of a range after the schedule = %+v, want no gaps", empty)
+	}
+}
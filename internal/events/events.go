@@ -0,0 +1,134 @@
+package events
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"shyft/internal/models"
+	"shyft/pkg/broker"
+)
+
+// Version of the event payload, bumped on incompatible changes
+const Version = 1
+
+// Type of a shift schedule event
+type Type string
+
+const (
+	ShiftScheduleCreated   Type = "shift_schedule.created"
+	ShiftScheduleUpdated   Type = "shift_schedule.updated"
+	ShiftScheduleDeleted   Type = "shift_schedule.deleted"
+	ShiftScheduleRestored  Type = "shift_schedule.restored"
+	ShiftScheduleSubmitted Type = "shift_schedule.submitted"
+	ShiftScheduleApproved  Type = "shift_schedule.approved"
+	ShiftScheduleRejected  Type = "shift_schedule.rejected"
+	ShiftScheduleReopened  Type = "shift_schedule.reopened"
+
+	ShiftScheduleOverrideCreated Type = "shift_schedule.override_created"
+	ShiftScheduleOverrideDeleted Type = "shift_schedule.override_deleted"
+)
+
+// Types lists every type of shift schedule event
+var Types = []Type{
+	ShiftScheduleCreated, ShiftScheduleUpdated, ShiftScheduleDeleted, ShiftScheduleRestored,
+	ShiftScheduleSubmitted, ShiftScheduleApproved, ShiftScheduleRejected, ShiftScheduleReopened,
+	ShiftScheduleOverrideCreated, ShiftScheduleOverrideDeleted,
+}
+
+// Headers of the published messages, consumers can route on them without decoding the payload
+const (
+	HeaderType    = "event-type"
+	HeaderVersion = "event-version"
+)
+
+// Actor is the caller that made the change
+type Actor struct {
+	UserID int    `json:"user_id"`
+	Name   string `json:"name"`
+	Mail   string `json:"mail"`
+	Role   string `json:"role"`
+}
+
+// ActorOf returns the actor of the claims, nil without claims
+func ActorOf(claims *models.Claims) *Actor {
+	if claims == nil {
+		return nil
+	}
+	return &Actor{UserID: claims.UserID, Name: claims.Name, Mail: claims.Mail, Role: claims.Role}
+}
+
+// Event is a change of a shift schedule, Before is nil on creation and After is nil on deletion. Override
+// is the created or deleted override of the override events.
+type Event struct {
+	ID              string                `json:"id"`
+	Version         int                   `json:"version"`
+	Type            Type                  `json:"type"`
+	OccurredAt      time.Time             `json:"occurred_at"`
+	ShiftScheduleID uint                  `json:"shift_schedule_id"`
+	OrganizationID  *uint                 `json:"organization_id"`
+	Actor           *Actor                `json:"actor"`
+	Before          *models.ShiftSchedule `json:"before"`
+	After           *models.ShiftSchedule `json:"after"`
+	Override        *models.ShiftOverride `json:"override,omitempty"`
+}
+
+// New creates an event of the change of a shift schedule from before to after
+func New(eventType Type, actor *Actor, before, after *models.ShiftSchedule) Event {
+	event := Event{
+		ID:         newID(),
+		Version:    Version,
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		Actor:      actor,
+		Before:     before,
+		After:      after,
+	}
+	for _, schedule := range []*models.ShiftSchedule{after, before} {
+		if schedule != nil {
+			event.ShiftScheduleID = schedule.ID
+			event.OrganizationID = schedule.OrganizationID
+			break
+		}
+	}
+	return event
+}
+
+// Outbox encodes the event as an outbox entry, published by the relay
+func (e Event) Outbox() (*models.OutboxEvent, error) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return &models.OutboxEvent{
+		EventID:         e.ID,
+		ShiftScheduleID: e.ShiftScheduleID,
+		EventType:       string(e.Type),
+		EventVersion:    e.Version,
+		Payload:         string(payload),
+	}, nil
+}
+
+// Message returns the message of an outbox entry in topic, keyed by the shift schedule so the events of a
+// schedule keep their order
+func Message(entry models.OutboxEvent, topic string) broker.Message {
+	return broker.Message{
+		Topic: topic,
+		Key:   []byte(strconv.FormatUint(uint64(entry.ShiftScheduleID), 10)),
+		Value: []byte(entry.Payload),
+		Headers: map[string]string{
+			HeaderType:    entry.EventType,
+			HeaderVersion: strconv.Itoa(entry.EventVersion),
+		},
+	}
+}
+
+func newID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(id)
+}
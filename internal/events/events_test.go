@@ -0,0 +1,114 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"shyft/internal/models"
+)
+
+func TestNew(t *testing.T) {
+	organizationID, otherID := uint(3), uint(4)
+	before := &models.ShiftSchedule{ID: 7, OrganizationID: &organizationID}
+	after := &models.ShiftSchedule{ID: 7, OrganizationID: &otherID}
+
+	tests := []struct {
+		name           string
+		before, after  *models.ShiftSchedule
+		scheduleID     uint
+		organizationID *uint
+	}{
+		{"created", nil, after, 7, &otherID},
+		{"updated, the schedule after the change wins", before, after, 7, &otherID},
+		{"deleted", before, nil, 7, &organizationID},
+		{"without schedules", nil, nil, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := New(ShiftScheduleUpdated, ActorOf(&models.Claims{UserID: 1, Name: "Ada", Role: "admin"}), tt.before, tt.after)
+			if event.ID == "" || event.Version != Version || event.Type != ShiftScheduleUpdated || time.Since(event.OccurredAt) > time.Minute {
+				t.Errorf("New() = %+v, want an id, the version, the type and the time", event)
+			}
+			if event.ShiftScheduleID != tt.scheduleID || !reflect.DeepEqual(event.OrganizationID, tt.organizationID) {
+				t.Errorf("New() schedule %d of organization %v, want %d of %v", event.ShiftScheduleID, event.OrganizationID, tt.scheduleID, tt.organizationID)
+			}
+			if event.Actor == nil || event.Actor.UserID != 1 || event.Actor.Role != "admin" {
+				t.Errorf("New() actor = %+v, want the caller", event.Actor)
+			}
+		})
+	}
+
+	if ActorOf(nil) != nil {
+		t.Error("ActorOf(nil) != nil")
+	}
+	if a, b := New(ShiftScheduleCreated, nil, nil, after), New(ShiftScheduleCreated, nil, nil, after); a.ID == b.ID {
+		t.Errorf("New() ids %s and %s are the same", a.ID, b.ID)
+	}
+}
+
+func TestOutboxMessage(t *testing.T) {
+	event := New(ShiftScheduleApproved, nil, nil, &models.ShiftSchedule{ID: 42, Alias: "ops"})
+	entry, err := event.Outbox()
+	if err != nil {
+		t.Fatalf("Outbox() error = %v", err)
+	}
+	if entry.EventID != event.ID || entry.ShiftScheduleID != 42 || entry.EventType != "shift_schedule.approved" || entry.EventVersion != Version {
+		t.Errorf("Outbox() = %+v, want the id, schedule, type and version of the event", entry)
+	}
+	var decoded Event
+	if err := json.Unmarshal([]byte(entry.Payload), &decoded); err != nil || decoded.ID != event.ID || decoded.After == nil || decoded.After.Alias != "ops" {
+		t.Errorf("Outbox() payload = %s, %v, want the event", entry.Payload, err)
+	}
+
+	message := Message(*entry, "shyft.events")
+	want := map[string]string{HeaderType: "shift_schedule.approved", HeaderVersion: fmt.Sprint(Version)}
+	if message.Topic != "shyft.events" || string(message.Key) != "42" || string(message.Value) != entry.Payload || !reflect.DeepEqual(message.Headers, want) {
+		t.Errorf("Message() = %+v, want the payload keyed by schedule with headers %v", message, want)
+	}
+}
+
+func TestBySchedule(t *testing.T) {
+	pending := []models.OutboxEvent{
+		{ID: 1, ShiftScheduleID: 7},
+		{ID: 2, ShiftScheduleID: 8},
+		{ID: 3, ShiftScheduleID: 7},
+		{ID: 4, ShiftScheduleID: 9},
+		{ID: 5, ShiftScheduleID: 8},
+	}
+	var got [][]uint64
+	for _, group := range bySchedule(pending) {
+		var ids []uint64
+		for _, entry := range group {
+			ids = append(ids, entry.ID)
+		}
+		got = append(got, ids)
+	}
+	if want := [][]uint64{{1, 3}, {2, 5}, {4}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("bySchedule() = %v, want %v", got, want)
+	}
+	if groups := bySchedule(nil); len(groups) != 0 {
+		t.Errorf("bySchedule(nil) = %v, want no groups", groups)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{5, 16 * time.Second},
+		{9, 256 * time.Second},
+		{10, 5 * time.Minute},
+		{50, 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"shyft/internal/models"
+	"shyft/internal/repository"
+	"shyft/pkg/broker"
+	"shyft/pkg/logger"
+	"shyft/pkg/metric"
+)
+
+const (
+	defaultRelayInterval   = time.Second
+	defaultRelayBatchSize  = 100
+	defaultOutboxRetention = 7 * 24 * time.Hour
+
+	relayPublishTimeout = 10 * time.Second
+	relayClaimLease     = 5 * time.Minute
+	relayCleanupPeriod  = time.Hour
+	minRelayBackoff     = time.Second
+	maxRelayBackoff     = 5 * time.Minute
+)
+
+// RelayOptions of the relay, zero values take the defaults
+type RelayOptions struct {
+	Topic     string        // events are published to
+	Interval  time.Duration // between two polls of the outbox
+	BatchSize int           // events taken from the outbox at once
+	Retention time.Duration // published events are kept in the outbox for
+}
+
+// Relay publishes the events of the outbox to the broker. Events of a shift schedule are published in the
+// order they were written, a failed event is retried with exponential backoff and the later events of its
+// schedule wait behind it. Events are published at least once, consumers deduplicate them by id.
+type Relay struct {
+	outbox  *repository.OutboxRepository
+	broker  broker.Broker
+	options RelayOptions
+}
+
+func NewRelay(db *gorm.DB, b broker.Broker, options RelayOptions) *Relay {
+	if options.Interval <= 0 {
+		options.Interval = defaultRelayInterval
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = defaultRelayBatchSize
+	}
+	if options.Retention <= 0 {
+		options.Retention = defaultOutboxRetention
+	}
+	return &Relay{outbox: repository.NewOutboxRepository(db), broker: b, options: options}
+}
+
+// Run drains the outbox every interval until ctx is done, metrics may be nil
+func (r *Relay) Run(ctx context.Context, metrics metric.Metrics) {
+	ticker := time.NewTicker(r.options.Interval)
+	defer ticker.Stop()
+
+	var cleanedAt time.Time
+	for {
+		// full batches are drained back to back
+		for {
+			drained, err := r.drain(ctx, metrics)
+			if err != nil {
+				logger.CLogger.Errorf("Cannot relay outbox events: %v", err)
+				break
+			}
+			if drained < r.options.BatchSize {
+				break
+			}
+		}
+		r.report(metrics)
+
+		if time.Since(cleanedAt) >= relayCleanupPeriod {
+			if _, err := r.outbox.DeletePublished(time.Now().Add(-r.options.Retention)); err != nil {
+				logger.CLogger.Errorf("Cannot delete published outbox events: %v", err)
+			}
+			cleanedAt = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// drain publishes a batch of pending events and returns the number of events taken from the outbox. The
+// events are claimed first and published outside of the claim transaction, so the outbox lock is not held
+// while waiting for the broker.
+func (r *Relay) drain(ctx context.Context, metrics metric.Metrics) (int, error) {
+	claimedAt := time.Now()
+	pending, _, err := r.outbox.Claim(r.options.BatchSize, claimedAt, relayClaimLease)
+	if err != nil {
+		return 0, err
+	}
+
+	// events left when the lease runs out are taken by the next claim, publishing them later could reorder them
+	ctx, cancel := context.WithDeadline(ctx, claimedAt.Add(relayClaimLease))
+	defer cancel()
+	for _, group := range bySchedule(pending) {
+		ids := make([]uint64, 0, len(group))
+		messages := make([]broker.Message, 0, len(group))
+		for _, entry := range group {
+			ids = append(ids, entry.ID)
+			messages = append(messages, Message(entry, r.options.Topic))
+		}
+
+		publishCtx, cancel := context.WithTimeout(ctx, relayPublishTimeout)
+		err := r.broker.Publish(publishCtx, messages...)
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				break // stopped or out of lease, the events are taken again by the next claim
+			}
+			attempts := group[0].Attempts + 1
+			logger.CLogger.Errorf("Cannot publish %d events of shift schedule %d (attempt %d): %v", len(group), group[0].ShiftScheduleID, attempts, err)
+			if metrics != nil {
+				metrics.IncOutboxFailures()
+			}
+			if err := r.outbox.MarkFailed(ids, time.Now().Add(backoff(attempts)), err.Error()); err != nil {
+				return len(pending), err
+			}
+			continue
+		}
+
+		if err := r.outbox.MarkPublished(ids, time.Now()); err != nil {
+			return len(pending), err
+		}
+		if metrics != nil {
+			metrics.AddOutboxPublished(len(ids))
+		}
+	}
+	return len(pending), nil
+}
+
+// report sets the backlog size and the relay lag (age of the oldest pending event)
+func (r *Relay) report(metrics metric.Metrics) {
+	if metrics == nil {
+		return
+	}
+	count, oldest, err := r.outbox.Backlog()
+	if err != nil {
+		logger.CLogger.Errorf("Cannot get outbox backlog: %v", err)
+		return
+	}
+	lag := 0.0
+	if oldest != nil {
+		lag = time.Since(*oldest).Seconds()
+	}
+	metrics.SetOutboxBacklog(float64(count))
+	metrics.SetOutboxLag(lag)
+}
+
+// bySchedule groups the pending events by shift schedule, keeping their order
+func bySchedule(pending []models.OutboxEvent) [][]models.OutboxEvent {
+	var groups [][]models.OutboxEvent
+	index := map[uint]int{}
+	for _, entry := range pending {
+		i, ok := index[entry.ShiftScheduleID]
+		if !ok {
+			i = len(groups)
+			index[entry.ShiftScheduleID] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], entry)
+	}
+	return groups
+}
+
+// backoff before the next attempt after the given number of failed attempts
+func backoff(attempts int) time.Duration {
+	delay := minRelayBackoff
+	for i := 1; i < attempts && delay < maxRelayBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxRelayBackoff {
+		delay = maxRelayBackoff
+	}
+	return delay
+}
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+
+	"shyft/config"
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+	"shyft/pkg/logger"
+	"shyft/pkg/utils"
+)
+
+const (
+	// claims are stored in the gin context under this key
+	ClaimsContextKey = "claims"
+)
+
+// Accepted JWT signing algorithms
+var allowedSigningMethods = map[string]bool{
+	jwt.SigningMethodRS256.Alg(): true,
+	jwt.SigningMethodES256.Alg(): true,
+}
+
+// AuthMiddleware verifies RS256/ES256 bearer tokens against config.Auth.JwtPub, requires them to expire
+// and to be issued by config.Auth.Issuer for config.Auth.Audience, and stores the parsed claims in the
+// request context
+func (ss *ShiftService) AuthMiddleware() gin.HandlerFunc {
+	publicKey, err := utils.ParseJWTPublicKey(config.C.Auth.JwtPub)
+	if err != nil {
+		logger.CLogger.Errorf("Cannot parse auth.jwt_pub, all requests will be rejected: %v", err)
+	}
+
+	issuer, audience := config.C.Auth.Issuer, config.C.Auth.Audience
+
+	return func(ctx *gin.Context) {
+		if publicKey == nil {
+			abortUnauthorized(ctx, httpErrors.InvalidJWTToken)
+			return
+		}
+
+		// Step 1: Get token from the Authorization header or the jwt cookie
+		tokenString := bearerToken(ctx)
+		if tokenString == "" {
+			abortUnauthorized(ctx, httpErrors.InvalidJWTToken)
+			return
+		}
+
+		// Step 2: Verify token signature and standard claims (exp, nbf, iat)
+		claims := &models.Claims{}
+		_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+			if !allowedSigningMethods[token.Method.Alg()] {
+				return nil, httpErrors.InvalidJWTToken
+			}
+			return publicKey, nil
+		})
+		if err != nil {
+			var validationErr *jwt.ValidationError
+			if errors.As(err, &validationErr) && validationErr.Errors&(jwt.ValidationErrorExpired|jwt.ValidationErrorNotValidYet|jwt.ValidationErrorIssuedAt|jwt.ValidationErrorClaimsInvalid) != 0 {
+				abortUnauthorized(ctx, httpErrors.InvalidJWTClaims)
+				return
+			}
+			abortUnauthorized(ctx, httpErrors.InvalidJWTToken)
+			return
+		}
+
+		// Step 3: Validate the claims the library leaves optional and the shift service claims
+		now := time.Now().Unix()
+		if !claims.VerifyExpiresAt(now, true) ||
+			(issuer != "" && !claims.VerifyIssuer(issuer, true)) ||
+			(audience != "" && !claims.VerifyAudience(audience, true)) {
+			abortUnauthorized(ctx, httpErrors.InvalidJWTClaims)
+			return
+		}
+		if claims.UserID == 0 {
+			abortUnauthorized(ctx, httpErrors.InvalidJWTClaims)
+			return
+		}
+
+		// Step 4: Put claims into the request context
+		ctx.Set(ClaimsContextKey, claims)
+		ctx.Request = ctx.Request.WithContext(context.WithValue(ctx.Request.Context(), utils.UserCtxKey{}, claims))
+		ctx.Next()
+	}
+}
+
+// CalendarTokenMiddleware authenticates calendar feeds by the secret token of the `token` query parameter,
+// calendar clients cannot send bearer tokens. Tokens of deleted people, of people who moved to another
+// organization and of roles no longer allowed to read schedules are rejected. The claims of the person are
+// stored in the request context.
+func (ss *ShiftService) CalendarTokenMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		// Step 1: Get token from query
+		token := ctx.Query("token")
+		if token == "" {
+			abortUnauthorized(ctx, httpErrors.Unauthorized)
+			return
+		}
+
+		// Step 2: Find the unexpired token by its hash
+		calendarToken, err := repository.NewCalendarRepository(ss.db).FindToken(hashCalendarToken(token), time.Now())
+		if err != nil {
+			abortUnauthorized(ctx, httpErrors.Unauthorized)
+			return
+		}
+
+		// Step 3: Load the person of the token, who must still be in the organization the token was issued in
+		person, err := repository.NewUserRepository(ss.db).FindByID(strconv.Itoa(int(calendarToken.PersonID)))
+		if err != nil || person.OrganizationID == nil || int(*person.OrganizationID) != calendarToken.OrganizationID {
+			abortUnauthorized(ctx, httpErrors.Unauthorized)
+			return
+		}
+		claims := calendarToken.Claims(person)
+		if ss.policy.Scope(claims.Role, policy.ReadSchedules) == policy.ScopeNone {
+			abortUnauthorized(ctx, httpErrors.Unauthorized)
+			return
+		}
+
+		// Step 4: Put the claims of the person into the request context
+		ctx.Set(ClaimsContextKey, claims)
+		ctx.Request = ctx.Request.WithContext(context.WithValue(ctx.Request.Context(), utils.UserCtxKey{}, claims))
+		ctx.Next()
+	}
+}
+
+// get bearer token from the Authorization header, or from the jwt cookie when allowed
+func bearerToken(ctx *gin.Context) string {
+	header := ctx.GetHeader("Authorization")
+	if len(header) > 7 && strings.EqualFold(header[:7], "Bearer ") {
+		return strings.TrimSpace(header[7:])
+	}
+
+	if config.C.Auth.AllowCookie && config.C.Cookie.Name != "" {
+		if cookie, err := ctx.Cookie(config.C.Cookie.Name); err == nil {
+			return cookie
+		}
+	}
+
+	return ""
+}
+
+// get claims of the authenticated caller from the gin context
+func claimsFromContext(ctx *gin.Context) *models.Claims {
+	value, exists := ctx.Get(ClaimsContextKey)
+	if !exists {
+		return nil
+	}
+	claims, _ := value.(*models.Claims)
+	return claims
+}
+
+func abortUnauthorized(ctx *gin.Context, err error) {
+	respondJson(ctx, http.StatusUnauthorized, RN_PREFIX+"/auth", nil, err)
+	ctx.Abort()
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+
+	"shyft/config"
+	"shyft/internal/models"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	public, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := config.C.Auth
+	defer func() { config.C.Auth = saved }()
+	config.C.Auth.JwtPub = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: public}))
+	config.C.Auth.Issuer = "https://auth.example.com"
+	config.C.Auth.Audience = "shyft"
+
+	router := gin.New()
+	router.GET("/", (&ShiftService{}).AuthMiddleware(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	expires := time.Now().Add(time.Hour).Unix()
+	valid := func() models.Claims {
+		return models.Claims{UserID: 1, Role: "admin", StandardClaims: jwt.StandardClaims{
+			ExpiresAt: expires, Issuer: "https://auth.example.com", Audience: "shyft",
+		}}
+	}
+	tests := []struct {
+		name   string
+		claims func(*models.Claims)
+		want   int
+	}{
+		{"valid token", func(*models.Claims) {}, http.StatusOK},
+		{"without exp", func(c *models.Claims) { c.ExpiresAt = 0 }, http.StatusUnauthorized},
+		{"expired", func(c *models.Claims) { c.ExpiresAt = time.Now().Add(-time.Minute).Unix() }, http.StatusUnauthorized},
+		{"another issuer", func(c *models.Claims) { c.Issuer = "https://other.example.com" }, http.StatusUnauthorized},
+		{"without issuer", func(c *models.Claims) { c.Issuer = "" }, http.StatusUnauthorized},
+		{"another audience", func(c *models.Claims) { c.Audience = "billing" }, http.StatusUnauthorized},
+		{"without user id", func(c *models.Claims) { c.UserID = 0; c.Subject = "42" }, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := valid()
+			tt.claims(&claims)
+			token, err := jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+
+	t.Run("unsigned token", func(t *testing.T) {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodNone, valid()).SignedString(jwt.UnsafeAllowNoneSignatureType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+// authorize checks that the caller is granted permission, on schedule when given
+func (ss *ShiftService) authorize(c *gin.Context, permission policy.Permission, schedule *models.ShiftSchedule) error {
+	if !ss.policy.Allows(claimsFromContext(c), permission, schedule) {
+		return httpErrors.Forbidden
+	}
+	return nil
+}
+
+// scheduleRepository returns a shift schedule repository limited to the caller's organization
+func (ss *ShiftService) scheduleRepository(c *gin.Context) (*repository.ShiftScheduleRepository, error) {
+	claims := claimsFromContext(c)
+	if claims == nil || claims.OrganizationID == 0 {
+		return nil, httpErrors.Forbidden
+	}
+	return repository.NewShiftScheduleRepository(ss.db).ForOrganization(claims.OrganizationID), nil
+}
+
+// readableSchedules returns a shift schedule repository limited to the schedules the caller may read
+func (ss *ShiftService) readableSchedules(c *gin.Context) (*repository.ShiftScheduleRepository, error) {
+	if err := ss.authorize(c, policy.ReadSchedules, nil); err != nil {
+		return nil, err
+	}
+	repo, err := ss.scheduleRepository(c)
+	if err != nil {
+		return nil, err
+	}
+	claims := claimsFromContext(c)
+	if ss.policy.Scope(claims.Role, policy.ReadSchedules) == policy.ScopeOwn {
+		repo = repo.VisibleTo(claims.UserID, claims.Mail)
+	}
+	return repo, nil
+}
+
+// authorizeOrganization checks that the caller is granted permission on the given organization
+func (ss *ShiftService) authorizeOrganization(c *gin.Context, permission policy.Permission, organizationID *uint) error {
+	if !ss.policy.AllowsOrganization(claimsFromContext(c), permission, organizationID) {
+		return httpErrors.Forbidden
+	}
+	return nil
+}
+
+// ownOrganization returns the caller's organization when permission is only granted on it
+func (ss *ShiftService) ownOrganization(c *gin.Context, permission policy.Permission) (*uint, error) {
+	claims := claimsFromContext(c)
+	if claims == nil {
+		return nil, httpErrors.Forbidden
+	}
+	switch ss.policy.Scope(claims.Role, permission) {
+	case policy.ScopeAny:
+		return nil, nil
+	case policy.ScopeOwn:
+		if claims.OrganizationID == 0 {
+			return nil, httpErrors.Forbidden
+		}
+		organizationID := uint(claims.OrganizationID)
+		return &organizationID, nil
+	default:
+		return nil, httpErrors.Forbidden
+	}
+}
+
+// swapRepository returns a shift swap repository limited to the caller's organization
+func (ss *ShiftService) swapRepository(c *gin.Context) (*repository.ShiftSwapRepository, error) {
+	claims := claimsFromContext(c)
+	if claims == nil || claims.OrganizationID == 0 {
+		return nil, httpErrors.Forbidden
+	}
+	return repository.NewShiftSwapRepository(ss.db).ForOrganization(claims.OrganizationID), nil
+}
+
+// leaveRepository returns a leave repository limited to the leaves of the caller's organization on which the
+// caller is granted permission, callers limited to their own leaves also get those of the people they manage
+func (ss *ShiftService) leaveRepository(c *gin.Context, permission policy.Permission) (*repository.LeaveRepository, error) {
+	claims := claimsFromContext(c)
+	if claims == nil || claims.OrganizationID == 0 {
+		return nil, httpErrors.Forbidden
+	}
+	repo := repository.NewLeaveRepository(ss.db).ForOrganization(claims.OrganizationID)
+	switch ss.policy.Scope(claims.Role, permission) {
+	case policy.ScopeAny:
+		return repo, nil
+	case policy.ScopeOwn:
+		if claims.UserID == 0 {
+			return nil, httpErrors.Forbidden
+		}
+		return repo.VisibleTo(claims.UserID, claims.Mail), nil
+	default:
+		return nil, httpErrors.Forbidden
+	}
+}
+
+// actorID returns the user id of the caller, recorded as the author of changes
+func actorID(c *gin.Context) *int {
+	claims := claimsFromContext(c)
+	if claims == nil || claims.UserID == 0 {
+		return nil
+	}
+	id := claims.UserID
+	return &id
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"shyft/config"
+	"shyft/internal/models"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+// defaultCalendarTokenTtl is the number of hours a calendar token is valid for when auth.calendar_token_ttl is not set
+const defaultCalendarTokenTtl = 720
+
+// calendarTokenResponse is returned once, only the hash of the token is stored
+type calendarTokenResponse struct {
+	Token     string    `json:"token"`
+	UserFeed  string    `json:"user_feed"` // path of the caller's feed, shift schedule feeds take the same token
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// HandleCreateCalendarToken godoc
+// HandleCreateCalendarToken handles the request to create the secret token of the caller's calendar feeds
+// @Summary create a calendar feed token
+// @Schemes
+// @Description create the secret token authenticating the caller's iCalendar feeds, the previous token is revoked. The token is only returned once and expires after auth.calendar_token_ttl hours.
+// @Tags Calendar
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} RespondJson "successfully created calendar token"
+// @Failure 403 {object} RespondJson "cannot create calendar token without a user"
+// @Failure 500 {object} RespondJson "cannot create calendar token due to internal server error"
+// @Router /calendar/token [post]
+func (ss *ShiftService) HandleCreateCalendarToken(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get the caller, feeds are issued to users of an organization
+	claims := claimsFromContext(c)
+	if claims == nil || claims.UserID == 0 || claims.OrganizationID == 0 {
+		return http.StatusForbidden, nil, httpErrors.Forbidden
+	}
+
+	// Step 2: Generate a random token
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+	token := hex.EncodeToString(secret)
+
+	// Step 3: Save the hash of the token with the caller's claims, revoking the previous token
+	ttl := config.C.Auth.CalendarTokenTtl
+	if ttl <= 0 {
+		ttl = defaultCalendarTokenTtl
+	}
+	calendarToken := models.CalendarToken{
+		PersonID:       uint(claims.UserID),
+		TokenHash:      hashCalendarToken(token),
+		Role:           claims.Role,
+		Mail:           claims.Mail,
+		OrganizationID: claims.OrganizationID,
+		ExpiresAt:      time.Now().Add(time.Duration(ttl) * time.Hour),
+	}
+	if err := repository.NewCalendarRepository(ss.db).ReplaceToken(&calendarToken); err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot create calendar token due to internal server error")
+	}
+
+	// Step 4: Return the token
+	return http.StatusOK, calendarTokenResponse{
+		Token:     token,
+		UserFeed:  fmt.Sprintf("%s/calendar/users/%d.ics?token=%s", API_PREFIX, claims.UserID, token),
+		ExpiresAt: calendarToken.ExpiresAt,
+	}, nil
+}
+
+// hashCalendarToken returns the hex SHA-256 of a calendar token
+func hashCalendarToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+type createLeaveDTO struct {
+	UserID int       `json:"user_id"`                 // defaults to the caller
+	Type   string    `json:"type" binding:"required"` // vacation, sick, training
+	Start  time.Time `json:"start" binding:"required"`
+	End    time.Time `json:"end" binding:"required"`
+	Reason string    `json:"reason"`
+}
+
+// HandleCreateLeave godoc
+// HandleCreateLeave handles the request to request a leave
+// @Summary request a leave
+// @Schemes
+// @Description request a vacation, sick or training leave, the person is unavailable for shifts once it is approved
+// @Tags Leave
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body createLeaveDTO true "request leave"
+// @Success 200 {object} RespondJson "successfully requested leave"
+// @Failure 400 {object} RespondJson "cannot request leave due to invalid request body"
+// @Failure 403 {object} RespondJson "cannot request leave due to missing permission"
+// @Failure 500 {object} RespondJson "cannot request leave due to internal server error"
+// @Router /leaves [post]
+func (ss *ShiftService) HandleCreateLeave(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get leave from request body and validate it
+	var params createLeaveDTO
+	if err := c.ShouldBindJSON(&params); err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	if !models.IsLeaveType(params.Type) {
+		return http.StatusBadRequest, nil, errors.New("leave type must be one of vacation, sick, training")
+	}
+	if !params.End.After(params.Start) {
+		return http.StatusBadRequest, nil, errors.New("leave end must be after its start")
+	}
+	if params.UserID == 0 {
+		if claims := claimsFromContext(c); claims != nil {
+			params.UserID = claims.UserID
+		}
+	}
+
+	// Step 2: Check that the caller may request a leave for the person
+	person, err := repository.NewUserRepository(ss.db).FindByID(strconv.Itoa(params.UserID))
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusBadRequest, i, errors.New("cannot request leave due to unknown user")
+		}
+		return r, i, errors.New("cannot request leave due to internal server error")
+	}
+	claims := claimsFromContext(c)
+	if claims == nil || person.OrganizationID == nil || *person.OrganizationID != uint(claims.OrganizationID) {
+		return http.StatusBadRequest, nil, errors.New("cannot request leave due to unknown user")
+	}
+	if !ss.policy.AllowsPerson(claims, policy.CreateLeaves, person.ID) {
+		return http.StatusForbidden, nil, httpErrors.Forbidden
+	}
+
+	// Step 3: Create leave in database, waiting for approval
+	leave := models.Leave{
+		PersonID:  person.ID,
+		Person:    *person,
+		Type:      params.Type,
+		StartAt:   params.Start,
+		EndAt:     params.End,
+		Status:    models.LeavePending,
+		Reason:    params.Reason,
+		CreatedBy: actorID(c),
+	}
+	if err := repository.NewLeaveRepository(ss.db).Create(&leave); err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot request leave due to internal server error")
+	}
+
+	// Step 4: Return leave
+	return http.StatusOK, leave, nil
+}
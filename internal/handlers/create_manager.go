@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+type createManagerDTO struct {
+	Name           string `json:"name" binding:"required"`
+	Mail           string `json:"mail" binding:"omitempty,email"`
+	Phone          string `json:"phone"`
+	Description    string `json:"description"`
+	OrganizationID *uint  `json:"organization_id"`
+
+	// PersonID is the user the manager is, it defaults to the user of the organization with the same mail
+	PersonID *uint `json:"person_id"`
+}
+
+// HandleCreateManager godoc
+// HandleCreateManager handles the request to create a new manager
+// @Summary create a new manager
+// @Schemes
+// @Description create a new manager that can be referenced by id from shift schedules
+// @Tags Manager
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body createManagerDTO true "create manager"
+// @Success 200 {object} RespondJson "successfully created manager"
+// @Failure 400 {object} RespondJson "cannot create manager due to invalid request body or unknown user"
+// @Failure 403 {object} RespondJson "cannot create manager due to missing permission"
+// @Failure 500 {object} RespondJson "cannot create manager due to internal server error"
+// @Router /managers [post]
+func (ss *ShiftService) HandleCreateManager(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get manager from request body
+	var params createManagerDTO
+	if err := c.ShouldBindJSON(&params); err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	if err := ss.authorizeOrganization(c, policy.CreateManagers, params.OrganizationID); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 2: Create manager in database
+	manager := models.Manager{
+		Contact: models.Contact{
+			Name:        params.Name,
+			Mail:        params.Mail,
+			Phone:       params.Phone,
+			Description: params.Description,
+		},
+		OrganizationID: params.OrganizationID,
+		PersonID:       params.PersonID,
+	}
+	if err := repository.NewManagerRepository(ss.db).Create(&manager); err != nil {
+		if errors.Is(err, repository.ErrUnknownContact) {
+			return http.StatusBadRequest, nil, err
+		}
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot create manager due to internal server error")
+	}
+
+	// Step 3: Return manager
+	return http.StatusOK, manager, nil
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+type createOrganizationDTO struct {
+	Name        string `json:"name" binding:"required"`
+	Mail        string `json:"mail" binding:"omitempty,email"`
+	Phone       string `json:"phone"`
+	Description string `json:"description"`
+}
+
+// HandleCreateOrganization godoc
+// HandleCreateOrganization handles the request to create a new organization
+// @Summary create a new organization
+// @Schemes
+// @Description create a new organization that can be referenced by id from shift schedules
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body createOrganizationDTO true "create organization"
+// @Success 200 {object} RespondJson "successfully created organization"
+// @Failure 400 {object} RespondJson "cannot create organization due to invalid request body"
+// @Failure 403 {object} RespondJson "cannot create organization due to missing permission"
+// @Failure 500 {object} RespondJson "cannot create organization due to internal server error"
+// @Router /organizations [post]
+func (ss *ShiftService) HandleCreateOrganization(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get organization from request body
+	var params createOrganizationDTO
+	if err := c.ShouldBindJSON(&params); err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	if err := ss.authorizeOrganization(c, policy.CreateOrganizations, nil); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 2: Create organization in database
+	organization := models.Organization{Contact: models.Contact{
+		Name:        params.Name,
+		Mail:        params.Mail,
+		Phone:       params.Phone,
+		Description: params.Description,
+	}}
+	if err := repository.NewOrganizationRepository(ss.db).Create(&organization); err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot create organization due to internal server error")
+	}
+
+	// Step 3: Return organization
+	return http.StatusOK, organization, nil
+}
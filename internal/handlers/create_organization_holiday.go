@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+type createHolidayDTO struct {
+	Date string `json:"date" binding:"required"` // YYYY-MM-DD
+	Name string `json:"name" binding:"required"`
+}
+
+// HandleCreateOrganizationHoliday godoc
+// HandleCreateOrganizationHoliday handles the request to add a holiday to the calendar of an organization
+// @Summary add a holiday to an organization
+// @Schemes
+// @Description add a public holiday to the organization's calendar, the holiday of the same day is renamed instead
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Organization ID"
+// @Param body body createHolidayDTO true "holiday"
+// @Success 200 {object} RespondJson "successfully added holiday"
+// @Failure 400 {object} RespondJson "cannot add holiday due to invalid request body"
+// @Failure 403 {object} RespondJson "cannot add holiday due to missing permission"
+// @Failure 500 {object} RespondJson "cannot add holiday due to internal server error"
+// @Router /organizations/{id}/holidays [post]
+func (ss *ShiftService) HandleCreateOrganizationHoliday(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get organization id from path and holiday from request body
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 1 {
+		return http.StatusBadRequest, nil, httpErrors.BadQueryParams
+	}
+	organizationID := uint(id)
+	var params createHolidayDTO
+	if err := c.ShouldBindJSON(&params); err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	date, err := time.Parse(models.HolidayDateLayout, params.Date)
+	if err != nil {
+		return http.StatusBadRequest, nil, errors.New("holiday date must be formatted as YYYY-MM-DD")
+	}
+
+	// Step 2: Check that the caller may update the organization
+	if err := ss.authorizeOrganization(c, policy.UpdateOrganizations, &organizationID); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 3: Save holiday to database
+	holidays := []models.Holiday{{OrganizationID: organizationID, Date: date, Name: strings.TrimSpace(params.Name)}}
+	if err := repository.NewHolidayRepository(ss.db).Save(holidays); err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot add holiday due to internal server error")
+	}
+
+	// Step 4: Return holiday
+	return http.StatusOK, holidays[0], nil
+}
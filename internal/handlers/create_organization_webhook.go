@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"shyft/config"
+	"shyft/internal/events"
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/internal/webhook"
+	"shyft/pkg/httpErrors"
+)
+
+type createWebhookDTO struct {
+	Url         string   `json:"url" binding:"required"`
+	EventTypes  []string `json:"event_types"` // empty subscribes to every event
+	Secret      string   `json:"secret"`      // generated when empty
+	Description string   `json:"description"`
+	Active      *bool    `json:"active"` // defaults to true
+}
+
+// webhookResponse returns the secret of a subscription along with it, only when it is created
+type webhookResponse struct {
+	models.WebhookSubscription
+	Secret string `json:"secret"`
+}
+
+// HandleCreateOrganizationWebhook godoc
+// HandleCreateOrganizationWebhook handles the request to subscribe a webhook to the events of an organization
+// @Summary create a webhook of an organization
+// @Schemes
+// @Description subscribe a url to the shift schedule events of the organization. Deliveries are signed with HMAC-SHA256 of "<X-Shyft-Timestamp>.<body>" keyed by the secret in the X-Shyft-Signature header. The secret is only returned once.
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Organization ID"
+// @Param body body createWebhookDTO true "webhook"
+// @Success 200 {object} RespondJson "successfully created webhook"
+// @Failure 400 {object} RespondJson "cannot create webhook due to invalid request body"
+// @Failure 403 {object} RespondJson "cannot create webhook due to missing permission"
+// @Failure 500 {object} RespondJson "cannot create webhook due to internal server error"
+// @Router /organizations/{id}/webhooks [post]
+func (ss *ShiftService) HandleCreateOrganizationWebhook(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get organization id from path and webhook from request body
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 1 {
+		return http.StatusBadRequest, nil, httpErrors.BadQueryParams
+	}
+	organizationID := uint(id)
+	var params createWebhookDTO
+	if err := c.ShouldBindJSON(&params); err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	if err := validateWebhookUrl(c.Request.Context(), params.Url); err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	if err := validateWebhookEventTypes(params.EventTypes); err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+
+	// Step 2: Check that the caller may update the organization
+	if err := ss.authorizeOrganization(c, policy.UpdateOrganizations, &organizationID); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 3: Generate a secret when none is given
+	secret := params.Secret
+	if secret == "" {
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return http.StatusInternalServerError, nil, err
+		}
+		secret = hex.EncodeToString(key)
+	}
+
+	// Step 4: Save webhook subscription to database
+	subscription := models.WebhookSubscription{
+		OrganizationID: organizationID,
+		Url:            strings.TrimSpace(params.Url),
+		EventTypes:     models.StringList(params.EventTypes),
+		Secret:         secret,
+		Description:    strings.TrimSpace(params.Description),
+		Active:         params.Active == nil || *params.Active,
+	}
+	if err := repository.NewWebhookRepository(ss.db).Create(&subscription); err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot create webhook due to internal server error")
+	}
+
+	// Step 5: Return webhook subscription with its secret
+	return http.StatusOK, webhookResponse{WebhookSubscription: subscription, Secret: secret}, nil
+}
+
+// validateWebhookUrl checks that a webhook url is an absolute http or https url of a public host, or of a
+// host of webhook.allowed_hosts
+func validateWebhookUrl(ctx context.Context, rawUrl string) error {
+	return webhook.NewGuard(config.C.Webhook.AllowedHosts).CheckUrl(ctx, rawUrl)
+}
+
+// validateWebhookEventTypes checks that webhooks subscribe to known shift schedule events
+func validateWebhookEventTypes(eventTypes []string) error {
+	for _, eventType := range eventTypes {
+		known := false
+		for _, t := range events.Types {
+			if eventType == string(t) {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("unknown event type %q", eventType)
+		}
+	}
+	return nil
+}
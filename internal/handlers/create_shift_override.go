@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/conflict"
+	"shyft/internal/events"
+	"shyft/internal/models"
+	"shyft/internal/override"
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/internal/workflow"
+	"shyft/pkg/httpErrors"
+)
+
+type createShiftOverrideDTO struct {
+	UserID int       `json:"user_id" binding:"required"` // person covering the shift schedule
+	Start  time.Time `json:"start" binding:"required"`
+	End    time.Time `json:"end" binding:"required"`
+	Reason string    `json:"reason"`
+}
+
+// HandleCreateShiftOverride godoc
+// HandleCreateShiftOverride handles the request to create an override of a shift schedule
+// @Summary create a shift override
+// @Schemes
+// @Description let a person cover the shift schedule for a period, on top of the rotation
+// @Tags Shift
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Shift Schedule ID"
+// @Param body body createShiftOverrideDTO true "create shift override"
+// @Success 200 {object} RespondJson "successfully created shift override"
+// @Failure 400 {object} RespondJson "cannot create shift override due to invalid request body"
+// @Failure 403 {object} RespondJson "cannot create shift override due to missing permission"
+// @Failure 404 {object} RespondJson "cannot create shift override due to not found"
+// @Failure 409 {object} RespondJson "cannot create shift override due to an approved shift schedule or conflicts"
+// @Failure 500 {object} RespondJson "cannot create shift override due to internal server error"
+// @Router /shift-schedules/{id}/overrides [post]
+func (ss *ShiftService) HandleCreateShiftOverride(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get shift schedule id from path and override from request body
+	id := c.Param("id")
+	if id == "" {
+		return http.StatusBadRequest, nil, nil
+	}
+	var params createShiftOverrideDTO
+	if err := c.ShouldBindJSON(&params); err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+
+	// Step 2: Get shift schedule and check that the caller may update it
+	repo, err := ss.scheduleRepository(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	shiftSchedule, err := repo.FindByID(id)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot create shift override due to not found")
+		}
+		return r, i, errors.New("cannot create shift override due to internal server error")
+	}
+	if err := ss.authorize(c, policy.UpdateSchedules, shiftSchedule); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	if err := workflow.CheckEditable(shiftSchedule); err != nil {
+		return http.StatusConflict, nil, err
+	}
+
+	// Step 3: Validate the override period and the covering person
+	if err := override.Validate(shiftSchedule, params.Start, params.End); err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	person, err := repository.NewUserRepository(ss.db).FindByID(strconv.Itoa(params.UserID))
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusBadRequest, i, errors.New("cannot create shift override due to unknown user")
+		}
+		return r, i, errors.New("cannot create shift override due to internal server error")
+	}
+	if person.OrganizationID == nil || shiftSchedule.OrganizationID == nil || *person.OrganizationID != *shiftSchedule.OrganizationID {
+		return http.StatusBadRequest, nil, errors.New("cannot create shift override due to unknown user")
+	}
+
+	// Step 4: Check that the covering person is not double booked by the override, then create it in database
+	shiftOverride := models.ShiftOverride{
+		ShiftScheduleID: shiftSchedule.ID,
+		PersonID:        person.ID,
+		Person:          *person,
+		StartAt:         params.Start,
+		EndAt:           params.End,
+		Reason:          params.Reason,
+		CreatedBy:       actorID(c),
+	}
+	if err := ss.checkConflicts(repo, shiftSchedule, shiftOverride); err != nil {
+		var conflicts *conflict.Error
+		if errors.As(err, &conflicts) {
+			return http.StatusConflict, nil, err
+		}
+		return http.StatusInternalServerError, nil, err
+	}
+	event := ss.overrideEvent(c, events.ShiftScheduleOverrideCreated, &shiftOverride)
+	if err := repository.NewShiftOverrideRepository(ss.db).Create(shiftSchedule, &shiftOverride, event); err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot create shift override due to internal server error")
+	}
+
+	// Step 5: Return override
+	return http.StatusOK, shiftOverride, nil
+}
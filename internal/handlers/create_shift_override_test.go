@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+
+	"shyft/internal/conflict"
+	"shyft/internal/models"
+	"shyft/internal/testutil"
+	"shyft/internal/workflow"
+)
+
+var scheduleColumns = []string{"id", "alias", "status", "start_date", "end_date", "time_zone", "organization_id", "users", "shifts"}
+
+// scheduleRow adds a shift schedule of March 2026 of the organization 1 with the shifts to rows
+func scheduleRow(rows *sqlmock.Rows, id uint, status int, shifts string) *sqlmock.Rows {
+	return rows.AddRow(id, "ops", status, testutil.At(2, 9, 0), testutil.At(16, 9, 0), "UTC", 1, []byte(`[]`), []byte(shifts))
+}
+
+func TestHandleCreateShiftOverride(t *testing.T) {
+	admin := &models.Claims{UserID: 1, Role: "admin", OrganizationID: 1}
+	body := `{"user_id": 5, "start": "2026-03-03T09:00:00Z", "end": "2026-03-04T09:00:00Z"}`
+	rotation := `[{"id": 0, "start": "2026-03-02 09:00:00", "end": "2026-03-16 09:00:00", "user": {"id": 2}}]`
+
+	// expectSchedule expects the shift schedule to be read in the organization of the caller
+	expectSchedule := func(mock sqlmock.Sqlmock, status int) {
+		mock.ExpectQuery(`SELECT \* FROM "shift_schedule" WHERE id = \$1 AND shift_schedule.organization_id = \$2`).
+			WithArgs("3", 1).
+			WillReturnRows(scheduleRow(sqlmock.NewRows(scheduleColumns), 3, status, rotation))
+	}
+	expectPerson := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`SELECT \* FROM "people" WHERE id = \$1`).WithArgs("5").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "organization_id"}).AddRow(5, "Erin", 1))
+	}
+	// expectOthers expects the other shift schedules of the period and the stored overrides to be read
+	expectOthers := func(mock sqlmock.Sqlmock, others *sqlmock.Rows) {
+		mock.ExpectQuery(`SELECT \* FROM "shift_schedule" WHERE .*deleted_at IS NULL AND start_date < \$\d+ AND end_date > \$\d+ AND id <> \$\d+`).
+			WillReturnRows(others)
+		mock.ExpectQuery(`SELECT \* FROM "shift_overrides" WHERE \(shift_schedule_id IN`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	}
+
+	t.Run("the override and its event are created in a transaction", func(t *testing.T) {
+		ss, mock := newTestService(t)
+		expectSchedule(mock, models.StatusPending)
+		expectPerson(mock)
+		expectOthers(mock, sqlmock.NewRows(scheduleColumns))
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "shift_overrides"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(12))
+		mock.ExpectQuery(`INSERT INTO "outbox_events"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectCommit()
+
+		code, result, err := ss.HandleCreateShiftOverride(newTestContext(admin, body, gin.Param{Key: "id", Value: "3"}))
+		if code != http.StatusOK || err != nil {
+			t.Fatalf("HandleCreateShiftOverride() = %d, %v", code, err)
+		}
+		if created := result.(models.ShiftOverride); created.ID != 12 || created.PersonID != 5 {
+			t.Errorf("HandleCreateShiftOverride() = %+v, want override 12 of person 5", created)
+		}
+	})
+
+	t.Run("an approved shift schedule cannot be overridden", func(t *testing.T) {
+		ss, mock := newTestService(t)
+		expectSchedule(mock, models.StatusApproved)
+
+		code, _, err := ss.HandleCreateShiftOverride(newTestContext(admin, body, gin.Param{Key: "id", Value: "3"}))
+		if code != http.StatusConflict || !errors.Is(err, workflow.ErrLocked) {
+			t.Errorf("HandleCreateShiftOverride() = %d, %v, want %d", code, err, http.StatusConflict)
+		}
+	})
+
+	t.Run("the covering person cannot be double booked", func(t *testing.T) {
+		ss, mock := newTestService(t)
+		expectSchedule(mock, models.StatusPending)
+		expectPerson(mock)
+		expectOthers(mock, scheduleRow(sqlmock.NewRows(scheduleColumns), 4, models.StatusApproved,
+			`[{"id": 0, "start": "2026-03-03 12:00:00", "end": "2026-03-05 12:00:00", "user": {"id": 5}}]`))
+
+		code, _, err := ss.HandleCreateShiftOverride(newTestContext(admin, body, gin.Param{Key: "id", Value: "3"}))
+		var conflicts *conflict.Error
+		if code != http.StatusConflict || !errors.As(err, &conflicts) {
+			t.Errorf("HandleCreateShiftOverride() = %d, %v, want %d", code, err, http.StatusConflict)
+		}
+	})
+
+	t.Run("the caller must be allowed to update the shift schedule", func(t *testing.T) {
+		ss, mock := newTestService(t)
+		expectSchedule(mock, models.StatusPending)
+
+		member := &models.Claims{UserID: 9, Role: "user", OrganizationID: 1}
+		code, _, _ := ss.HandleCreateShiftOverride(newTestContext(member, body, gin.Param{Key: "id", Value: "3"}))
+		if code != http.StatusForbidden {
+			t.Errorf("HandleCreateShiftOverride() = %d, want %d", code, http.StatusForbidden)
+		}
+	})
+}
@@ -8,7 +8,12 @@ import (
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 
+	"shyft/internal/conflict"
+	"shyft/internal/events"
 	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/internal/recurrence"
+	"shyft/internal/repository"
 	"shyft/pkg/httpErrors"
 )
 
@@ -19,11 +24,14 @@ type createShiftScheduleDTO struct {
 	Start_Date   time.Time    `json:"start_date" binding:"required"`
 	End_Date     time.Time    `json:"end_date" binding:"required"`
 	Year         int          `json:"year" binding:"required"`
-	Status       int          `json:"status"` // 0: pending, 1: approved, 2: rejected
 	Organization models.JSONB `json:"organization" binding:"required"`
 	Manager      models.JSONB `json:"manager" binding:"required"`
 	Users        models.JSONB `json:"users" binding:"required"`
 	Shifts       models.JSONB `json:"shifts" binding:"required"`
+
+	Recurrence           models.RecurrenceRules `json:"recurrence"`             // recurring shift patterns (RRULE)
+	SwapRequiresApproval bool                   `json:"swap_requires_approval"` // shift swaps also need the manager approval
+	TimeZone             string                 `json:"time_zone"`              // IANA time zone, defaults to Europe/Istanbul
 }
 
 // HandleCreateShiftSchedule godoc
@@ -41,13 +49,14 @@ type createShiftScheduleDTO struct {
 // @Param start_date body string true "Start Date"
 // @Param end_date body string true "End Date"
 // @Param year body int true "Year"
-// @Param status body int true "Status"
 // @Param organization body object true "Organization"
 // @Param manager body object true "Manager"
-// @Param users body object true "Users"
+// @Param users body object true "Users (full entries or references by id, e.g. [{\"id\": 1}])"
 // @Param shifts body object true "Shifts"
+// @Param recurrence body object false "Recurrence rules ([{\"rrule\": \"FREQ=WEEKLY;BYDAY=MO\", \"duration\": \"24h\", \"exdates\": []}])"
 // @Success 200 {object} RespondJson "successfully created shift schedule"
 // @Failure 400 {object} RespondJson "cannot create shift schedule due to invalid request body"
+// @Failure 409 {object} RespondJson "cannot create shift schedule due to conflicts (double bookings, overlaps, shifts out of range), listed in details"
 // @Failure 422 {object} RespondJson "cannot create shift schedule due to invalid request body"
 // @Failure 500 {object} RespondJson "cannot create shift schedule due to internal server error"
 // @Router /shift-schedules [post]
@@ -62,11 +71,39 @@ func (ss *ShiftService) HandleCreateShiftSchedule(c *gin.Context) (int, interfac
 	// }
 
 	// Step 2: Validate shift schedule
+	if err := recurrence.Validate(params.Recurrence); err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	if params.TimeZone != "" {
+		if _, err := models.LoadLocation(params.TimeZone); err != nil {
+			return http.StatusBadRequest, nil, err
+		}
+	}
 	var shiftSchedule models.ShiftSchedule
 	createParamsToShiftSchedule(&params, &shiftSchedule)
+	if err := ss.authorize(c, policy.CreateSchedules, &shiftSchedule); err != nil {
+		return http.StatusForbidden, nil, err
+	}
 
-	// Step 3: Create shift schedule in database
-	if err := ss.db.Create(&shiftSchedule).Error; err != nil {
+	// Step 3: Create shift schedule in the caller's organization
+	repo, err := ss.scheduleRepository(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	if !shiftSchedule.OwnedBy(claimsFromContext(c).OrganizationID) {
+		return http.StatusForbidden, nil, errors.New("cannot create shift schedule unless the caller's organization is its only organization")
+	}
+	if err := ss.checkConflicts(repo, &shiftSchedule); err != nil {
+		var conflicts *conflict.Error
+		if errors.As(err, &conflicts) {
+			return http.StatusConflict, nil, err
+		}
+		return http.StatusInternalServerError, nil, err
+	}
+	if err := repo.Create(&shiftSchedule, ss.event(c, events.ShiftScheduleCreated, nil)); err != nil {
+		if errors.Is(err, repository.ErrUnknownContact) {
+			return http.StatusBadRequest, nil, err
+		}
 		r, i := httpErrors.ErrorResponse(err)
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return r, i, errors.New("cannot create shift schedule due to not found")
@@ -85,9 +122,15 @@ func createParamsToShiftSchedule(params *createShiftScheduleDTO, shift *models.S
 	shift.Start_Date = params.Start_Date
 	shift.End_Date = params.End_Date
 	shift.Year = params.Year
-	shift.Status = params.Status
+	shift.Status = models.StatusPending // changed through the submit, approve, reject and reopen endpoints
 	shift.Organization = params.Organization
 	shift.Manager = params.Manager
 	shift.Users = params.Users
 	shift.Shifts = params.Shifts
+	shift.Recurrence = params.Recurrence
+	shift.SwapRequiresApproval = params.SwapRequiresApproval
+	shift.TimeZone = params.TimeZone
+	if shift.TimeZone == "" {
+		shift.TimeZone = models.DefaultTimeZone
+	}
 }
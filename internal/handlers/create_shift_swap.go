@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/internal/swap"
+	"shyft/pkg/httpErrors"
+)
+
+type createShiftSwapDTO struct {
+	ShiftID       *int   `json:"shift_id" binding:"required"`        // id of the requester's shift inside `shifts`, may be 0
+	TargetShiftID *int   `json:"target_shift_id" binding:"required"` // id of the responder's shift inside `shifts`, may be 0
+	Comment       string `json:"comment"`
+}
+
+// HandleCreateShiftSwap godoc
+// HandleCreateShiftSwap handles the request to propose a shift swap
+// @Summary propose a shift swap
+// @Schemes
+// @Description propose to swap one of the caller's shifts with the shift of another user of the schedule
+// @Tags Shift Swap
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Shift Schedule ID"
+// @Param body body createShiftSwapDTO true "propose shift swap"
+// @Success 200 {object} RespondJson "successfully proposed shift swap"
+// @Failure 400 {object} RespondJson "cannot propose shift swap due to invalid request body"
+// @Failure 403 {object} RespondJson "cannot propose shift swap due to missing permission"
+// @Failure 404 {object} RespondJson "cannot propose shift swap due to not found"
+// @Failure 500 {object} RespondJson "cannot propose shift swap due to internal server error"
+// @Router /shift-schedules/{id}/swaps [post]
+func (ss *ShiftService) HandleCreateShiftSwap(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get shift schedule id from path and swap from request body
+	id := c.Param("id")
+	if id == "" {
+		return http.StatusBadRequest, nil, nil
+	}
+	var params createShiftSwapDTO
+	if err := c.ShouldBindJSON(&params); err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+
+	// Step 2: Get shift schedule and check that the caller may read it
+	repo, err := ss.scheduleRepository(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	shiftSchedule, err := repo.FindByID(id)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot propose shift swap due to not found")
+		}
+		return r, i, errors.New("cannot propose shift swap due to internal server error")
+	}
+	if err := ss.authorize(c, policy.ReadSchedules, shiftSchedule); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 3: Get the users of both shifts, only the requester (or whoever may update the schedule) may propose
+	requester, err := swap.AssignedUser(shiftSchedule, *params.ShiftID)
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	responder, err := swap.AssignedUser(shiftSchedule, *params.TargetShiftID)
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	if requester.ID == responder.ID {
+		return http.StatusBadRequest, nil, swap.ErrSameUser
+	}
+	if !policy.IsCaller(claimsFromContext(c), requester) {
+		if err := ss.authorize(c, policy.UpdateSchedules, shiftSchedule); err != nil {
+			return http.StatusForbidden, nil, err
+		}
+	}
+
+	// Step 4: Create swap request in database
+	request := models.ShiftSwap{
+		ShiftScheduleID:  shiftSchedule.ID,
+		RequesterID:      requester.ID,
+		RequesterShiftID: *params.ShiftID,
+		ResponderID:      responder.ID,
+		ResponderShiftID: *params.TargetShiftID,
+		RequiresApproval: shiftSchedule.SwapRequiresApproval,
+		Comment:          params.Comment,
+	}
+	swaps, err := ss.swapRepository(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	if err := swaps.Create(&request, actorID(c)); err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot propose shift swap due to internal server error")
+	}
+
+	// Step 5: Return swap request
+	return http.StatusOK, request, nil
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+type createUserDTO struct {
+	Name        string `json:"name" binding:"required"`
+	Mail        string `json:"mail" binding:"omitempty,email"`
+	Phone       string `json:"phone"`
+	Description string `json:"description"`
+	// OrganizationID defaults to the caller's organization
+	OrganizationID *uint `json:"organization_id"`
+}
+
+// HandleCreateUser godoc
+// HandleCreateUser handles the request to create a new user
+// @Summary create a new user
+// @Schemes
+// @Description create a new user that can be referenced by id from shift schedules
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body createUserDTO true "create user"
+// @Success 200 {object} RespondJson "successfully created user"
+// @Failure 400 {object} RespondJson "cannot create user due to invalid request body"
+// @Failure 403 {object} RespondJson "cannot create user due to missing permission"
+// @Failure 500 {object} RespondJson "cannot create user due to internal server error"
+// @Router /users [post]
+func (ss *ShiftService) HandleCreateUser(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get user from request body
+	var params createUserDTO
+	if err := c.ShouldBindJSON(&params); err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	if params.OrganizationID == nil {
+		if claims := claimsFromContext(c); claims != nil && claims.OrganizationID != 0 {
+			organizationID := uint(claims.OrganizationID)
+			params.OrganizationID = &organizationID
+		}
+	}
+	if err := ss.authorizeOrganization(c, policy.CreateUsers, params.OrganizationID); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 2: Create user in database
+	user := models.User{
+		Contact: models.Contact{
+			Name:        params.Name,
+			Mail:        params.Mail,
+			Phone:       params.Phone,
+			Description: params.Description,
+		},
+		OrganizationID: params.OrganizationID,
+	}
+	if err := repository.NewUserRepository(ss.db).Create(&user); err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot create user due to internal server error")
+	}
+
+	// Step 3: Return user
+	return http.StatusOK, user, nil
+}
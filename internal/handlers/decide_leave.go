@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+type decideLeaveDTO struct {
+	Note string `json:"note"`
+}
+
+// HandleDecideLeave godoc
+// HandleDecideLeave handles the requests to approve or reject a leave
+// @Summary approve or reject a leave
+// @Schemes
+// @Description approve or reject a pending leave, approved leaves make the person unavailable for shifts
+// @Tags Leave
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Leave ID"
+// @Param action path string true "Action" Enums(approve, reject)
+// @Param body body decideLeaveDTO false "note"
+// @Success 200 {object} RespondJson "successfully decided leave"
+// @Failure 403 {object} RespondJson "cannot decide leave due to missing permission"
+// @Failure 404 {object} RespondJson "cannot decide leave due to not found"
+// @Failure 409 {object} RespondJson "cannot decide leave that is not pending"
+// @Failure 500 {object} RespondJson "cannot decide leave due to internal server error"
+// @Router /leaves/{id}/{action} [post]
+func (ss *ShiftService) HandleDecideLeave(c *gin.Context, status string) (int, interface{}, error) {
+	// Step 1: Get leave id from path and note from request body
+	id := c.Param("id")
+	if id == "" {
+		return http.StatusBadRequest, nil, nil
+	}
+	var params decideLeaveDTO
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&params); err != nil {
+			return http.StatusBadRequest, nil, err
+		}
+	}
+
+	// Step 2: Get leave from the leaves the caller may approve, nobody limited to their own leaves decides theirs
+	claims := claimsFromContext(c)
+	leaves, err := ss.leaveRepository(c, policy.ApproveLeaves)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	leave, err := leaves.FindByID(id)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot decide leave due to not found")
+		}
+		return r, i, errors.New("cannot decide leave due to internal server error")
+	}
+	if ss.policy.Scope(claims.Role, policy.ApproveLeaves) == policy.ScopeOwn && leave.PersonID == uint(claims.UserID) {
+		return http.StatusForbidden, nil, httpErrors.Forbidden
+	}
+	if leave.Status != models.LeavePending {
+		return http.StatusConflict, nil, errors.New("only pending leaves can be approved or rejected")
+	}
+
+	// Step 3: Save the decision
+	now := time.Now()
+	leave.Status = status
+	leave.DecidedBy = actorID(c)
+	leave.DecidedAt = &now
+	leave.DecisionNote = params.Note
+	if err := repository.NewLeaveRepository(ss.db).Save(leave); err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot decide leave due to internal server error")
+	}
+
+	// Step 4: Return leave
+	return http.StatusOK, leave, nil
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleDeleteCalendarToken godoc
+// HandleDeleteCalendarToken handles the request to revoke the secret token of the caller's calendar feeds
+// @Summary revoke the calendar feed token
+// @Schemes
+// @Description revoke the secret token authenticating the caller's iCalendar feeds
+// @Tags Calendar
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} RespondJson "successfully revoked calendar token"
+// @Failure 403 {object} RespondJson "cannot revoke calendar token without a user"
+// @Failure 500 {object} RespondJson "cannot revoke calendar token due to internal server error"
+// @Router /calendar/token [delete]
+func (ss *ShiftService) HandleDeleteCalendarToken(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get the caller
+	claims := claimsFromContext(c)
+	if claims == nil || claims.UserID == 0 {
+		return http.StatusForbidden, nil, httpErrors.Forbidden
+	}
+
+	// Step 2: Delete the caller's token
+	if err := repository.NewCalendarRepository(ss.db).DeleteToken(uint(claims.UserID)); err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot revoke calendar token due to internal server error")
+	}
+
+	// Step 3: Return result
+	return http.StatusOK, "Calendar Token Successfully Revoked", nil
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleDeleteLeave godoc
+// HandleDeleteLeave handles the request to delete a leave
+// @Summary delete a leave
+// @Schemes
+// @Description delete (cancel) a leave (soft delete), the person is available again
+// @Tags Leave
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Leave ID"
+// @Success 200 {object} RespondJson "successfully deleted leave"
+// @Failure 403 {object} RespondJson "cannot delete leave due to missing permission"
+// @Failure 404 {object} RespondJson "cannot delete leave due to not found"
+// @Failure 500 {object} RespondJson "cannot delete leave due to internal server error"
+// @Router /leaves/{id} [delete]
+func (ss *ShiftService) HandleDeleteLeave(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get leave id from path and validate
+	id := c.Param("id")
+	if id == "" {
+		return http.StatusBadRequest, nil, nil
+	}
+
+	// Step 2: Get leave from the leaves the caller may delete
+	leaves, err := ss.leaveRepository(c, policy.DeleteLeaves)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	leave, err := leaves.FindByID(id)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot delete leave due to not found")
+		}
+		return r, i, errors.New("cannot delete leave due to internal server error")
+	}
+
+	// Step 3: Delete leave from database (soft delete)
+	if err := repository.NewLeaveRepository(ss.db).Delete(leave); err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot delete leave due to internal server error")
+	}
+
+	// Step 4: Return result
+	return http.StatusOK, "Leave Successfully Deleted", nil
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleDeleteManager godoc
+// HandleDeleteManager handles the request to delete a manager
+// @Summary delete a manager
+// @Schemes
+// @Description delete a manager (soft delete)
+// @Tags Manager
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Manager ID"
+// @Success 200 {object} RespondJson "successfully deleted manager"
+// @Failure 403 {object} RespondJson "cannot delete manager due to missing permission"
+// @Failure 404 {object} RespondJson "cannot delete manager due to not found"
+// @Failure 500 {object} RespondJson "cannot delete manager due to internal server error"
+// @Router /managers/{id} [delete]
+func (ss *ShiftService) HandleDeleteManager(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get manager id from path and validate
+	id := c.Param("id")
+	if id == "" {
+		return http.StatusBadRequest, nil, nil
+	}
+
+	// Step 2: Get manager and check that the caller may delete it
+	repo := repository.NewManagerRepository(ss.db)
+	manager, err := repo.FindByID(id)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot delete manager due to not found")
+		}
+		return r, i, errors.New("cannot delete manager due to internal server error")
+	}
+	if err := ss.authorizeOrganization(c, policy.DeleteManagers, manager.OrganizationID); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 3: Delete manager from database (soft delete)
+	if err := repo.Delete(manager); err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot delete manager due to internal server error")
+	}
+
+	// Step 4: Return result
+	return http.StatusOK, "Manager Successfully Deleted", nil
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleDeleteOrganization godoc
+// HandleDeleteOrganization handles the request to delete an organization
+// @Summary delete an organization
+// @Schemes
+// @Description delete an organization (soft delete)
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Organization ID"
+// @Success 200 {object} RespondJson "successfully deleted organization"
+// @Failure 403 {object} RespondJson "cannot delete organization due to missing permission"
+// @Failure 404 {object} RespondJson "cannot delete organization due to not found"
+// @Failure 500 {object} RespondJson "cannot delete organization due to internal server error"
+// @Router /organizations/{id} [delete]
+func (ss *ShiftService) HandleDeleteOrganization(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get organization id from path and validate
+	id := c.Param("id")
+	if id == "" {
+		return http.StatusBadRequest, nil, nil
+	}
+
+	// Step 2: Get organization and check that the caller may delete it
+	repo := repository.NewOrganizationRepository(ss.db)
+	organization, err := repo.FindByID(id)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot delete organization due to not found")
+		}
+		return r, i, errors.New("cannot delete organization due to internal server error")
+	}
+	if err := ss.authorizeOrganization(c, policy.DeleteOrganizations, &organization.ID); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 3: Delete organization from database (soft delete)
+	if err := repo.Delete(organization); err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot delete organization due to internal server error")
+	}
+
+	// Step 4: Return result
+	return http.StatusOK, "Organization Successfully Deleted", nil
+}
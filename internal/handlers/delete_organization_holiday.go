@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleDeleteOrganizationHoliday godoc
+// HandleDeleteOrganizationHoliday handles the request to remove a holiday from the calendar of an organization
+// @Summary delete a holiday of an organization
+// @Schemes
+// @Description remove a public holiday from the organization's calendar (soft delete)
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Organization ID"
+// @Param holiday_id path string true "Holiday ID"
+// @Success 200 {object} RespondJson "successfully deleted holiday"
+// @Failure 403 {object} RespondJson "cannot delete holiday due to missing permission"
+// @Failure 404 {object} RespondJson "cannot delete holiday due to not found"
+// @Failure 500 {object} RespondJson "cannot delete holiday due to internal server error"
+// @Router /organizations/{id}/holidays/{holiday_id} [delete]
+func (ss *ShiftService) HandleDeleteOrganizationHoliday(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get organization and holiday id from path
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 1 {
+		return http.StatusBadRequest, nil, httpErrors.BadQueryParams
+	}
+	organizationID := uint(id)
+	holidayID := c.Param("holiday_id")
+	if holidayID == "" {
+		return http.StatusBadRequest, nil, nil
+	}
+
+	// Step 2: Check that the caller may update the organization
+	if err := ss.authorizeOrganization(c, policy.UpdateOrganizations, &organizationID); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 3: Delete holiday from database (soft delete)
+	holidays := repository.NewHolidayRepository(ss.db)
+	holiday, err := holidays.FindByID(organizationID, holidayID)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot delete holiday due to not found")
+		}
+		return r, i, errors.New("cannot delete holiday due to internal server error")
+	}
+	if err := holidays.Delete(holiday); err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot delete holiday due to internal server error")
+	}
+
+	// Step 4: Return result
+	return http.StatusOK, "Holiday Successfully Deleted", nil
+}
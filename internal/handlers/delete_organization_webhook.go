@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleDeleteOrganizationWebhook godoc
+// HandleDeleteOrganizationWebhook handles the request to delete a webhook subscription of an organization
+// @Summary delete a webhook of an organization
+// @Schemes
+// @Description delete a webhook subscription (soft delete), its pending deliveries fail
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Organization ID"
+// @Param webhook_id path string true "Webhook ID"
+// @Success 200 {object} RespondJson "successfully deleted webhook"
+// @Failure 403 {object} RespondJson "cannot delete webhook due to missing permission"
+// @Failure 404 {object} RespondJson "cannot delete webhook due to not found"
+// @Failure 500 {object} RespondJson "cannot delete webhook due to internal server error"
+// @Router /organizations/{id}/webhooks/{webhook_id} [delete]
+func (ss *ShiftService) HandleDeleteOrganizationWebhook(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get organization and webhook id from path
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 1 {
+		return http.StatusBadRequest, nil, httpErrors.BadQueryParams
+	}
+	organizationID := uint(id)
+	webhookID := c.Param("webhook_id")
+
+	// Step 2: Check that the caller may update the organization
+	if err := ss.authorizeOrganization(c, policy.UpdateOrganizations, &organizationID); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 3: Delete webhook subscription from database (soft delete)
+	webhooks := repository.NewWebhookRepository(ss.db)
+	subscription, err := webhooks.FindByID(organizationID, webhookID)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot delete webhook due to not found")
+		}
+		return r, i, errors.New("cannot delete webhook due to internal server error")
+	}
+	if err := webhooks.Delete(subscription); err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot delete webhook due to internal server error")
+	}
+
+	// Step 4: Return result
+	return http.StatusOK, "Webhook Successfully Deleted", nil
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/events"
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/internal/workflow"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleDeleteShiftOverride godoc
+// HandleDeleteShiftOverride handles the request to delete an override of a shift schedule
+// @Summary delete a shift override
+// @Schemes
+// @Description delete a shift override (soft delete), the rotation applies again for its period
+// @Tags Shift
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Shift Schedule ID"
+// @Param override_id path string true "Shift Override ID"
+// @Success 200 {object} RespondJson "successfully deleted shift override"
+// @Failure 403 {object} RespondJson "cannot delete shift override due to missing permission"
+// @Failure 404 {object} RespondJson "cannot delete shift override due to not found"
+// @Failure 409 {object} RespondJson "cannot delete shift override due to an approved shift schedule"
+// @Failure 500 {object} RespondJson "cannot delete shift override due to internal server error"
+// @Router /shift-schedules/{id}/overrides/{override_id} [delete]
+func (ss *ShiftService) HandleDeleteShiftOverride(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get shift schedule and override id from path
+	id := c.Param("id")
+	overrideID := c.Param("override_id")
+	if id == "" || overrideID == "" {
+		return http.StatusBadRequest, nil, nil
+	}
+
+	// Step 2: Get shift schedule and check that the caller may update it
+	repo, err := ss.scheduleRepository(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	shiftSchedule, err := repo.FindByID(id)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot delete shift override due to not found")
+		}
+		return r, i, errors.New("cannot delete shift override due to internal server error")
+	}
+	if err := ss.authorize(c, policy.UpdateSchedules, shiftSchedule); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	if err := workflow.CheckEditable(shiftSchedule); err != nil {
+		return http.StatusConflict, nil, err
+	}
+
+	// Step 3: Delete override from database (soft delete)
+	overrides := repository.NewShiftOverrideRepository(ss.db)
+	shiftOverride, err := overrides.FindByID(shiftSchedule.ID, overrideID)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot delete shift override due to not found")
+		}
+		return r, i, errors.New("cannot delete shift override due to internal server error")
+	}
+	event := ss.overrideEvent(c, events.ShiftScheduleOverrideDeleted, shiftOverride)
+	if err := overrides.Delete(shiftSchedule, shiftOverride, event); err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot delete shift override due to internal server error")
+	}
+
+	// Step 4: Return result
+	return http.StatusOK, "Shift Override Successfully Deleted", nil
+}
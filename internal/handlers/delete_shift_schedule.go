@@ -7,7 +7,8 @@ import (
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 
-	"shyft/internal/models"
+	"shyft/internal/events"
+	"shyft/internal/policy"
 	"shyft/pkg/httpErrors"
 )
 
@@ -33,9 +34,25 @@ func (ss *ShiftService) HandleDeleteShiftSchedule(c *gin.Context) (int, interfac
 		return http.StatusBadRequest, nil, nil
 	}
 
-	// Step 2: Delete shift schedule by id from database (soft delete)
-	var shiftSchedule models.ShiftSchedule
-	if err := ss.db.Where("id = ?", id).Delete(&shiftSchedule).Error; err != nil {
+	// Step 2: Get shift schedule and check that the caller may delete it
+	repo, err := ss.scheduleRepository(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	shiftSchedule, err := repo.FindByID(id)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot delete shift schedule due to not found")
+		}
+		return r, i, errors.New("cannot delete shift schedule due to internal server error")
+	}
+	if err := ss.authorize(c, policy.DeleteSchedules, shiftSchedule); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 3: Delete shift schedule by id from database (soft delete)
+	if err := repo.Delete(shiftSchedule, ss.event(c, events.ShiftScheduleDeleted, shiftSchedule.Clone())); err != nil {
 		r, i := httpErrors.ErrorResponse(err)
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return r, i, errors.New("cannot delete shift schedule due to not found")
@@ -43,6 +60,6 @@ func (ss *ShiftService) HandleDeleteShiftSchedule(c *gin.Context) (int, interfac
 		return r, i, errors.New("cannot delete shift schedule due to internal server error")
 	}
 
-	// Step 3: Return shift schedule by id
+	// Step 4: Return shift schedule by id
 	return http.StatusOK, "Shift Schedule Successfully Deleted", nil
 }
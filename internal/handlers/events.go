@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"shyft/internal/events"
+	"shyft/internal/models"
+	"shyft/internal/repository"
+	"shyft/internal/workflow"
+)
+
+// transitionEvents maps the status changes of a shift schedule to their events
+var transitionEvents = map[workflow.Action]events.Type{
+	workflow.ActionSubmit:  events.ShiftScheduleSubmitted,
+	workflow.ActionApprove: events.ShiftScheduleApproved,
+	workflow.ActionReject:  events.ShiftScheduleRejected,
+	workflow.ActionReopen:  events.ShiftScheduleReopened,
+}
+
+// event returns the event of a change of a shift schedule made by the caller, from before to the saved
+// shift schedule (nil once deleted). It is written to the outbox in the transaction of the change and
+// published by the relay.
+func (ss *ShiftService) event(c *gin.Context, eventType events.Type, before *models.ShiftSchedule) repository.EventFunc {
+	actor := events.ActorOf(claimsFromContext(c))
+	return func(schedule *models.ShiftSchedule) (*models.OutboxEvent, error) {
+		after := schedule
+		if eventType == events.ShiftScheduleDeleted {
+			after = nil
+		}
+		return events.New(eventType, actor, before, after).Outbox()
+	}
+}
+
+// overrideEvent returns the event of the creation or deletion of an override of a shift schedule made by
+// the caller, the shift schedule itself is unchanged
+func (ss *ShiftService) overrideEvent(c *gin.Context, eventType events.Type, shiftOverride *models.ShiftOverride) repository.EventFunc {
+	actor := events.ActorOf(claimsFromContext(c))
+	return func(schedule *models.ShiftSchedule) (*models.OutboxEvent, error) {
+		event := events.New(eventType, actor, schedule, schedule)
+		event.Override = shiftOverride
+		return event.Outbox()
+	}
+}
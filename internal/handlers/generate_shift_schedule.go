@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/conflict"
+	"shyft/internal/events"
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/internal/rotation"
+	"shyft/internal/workflow"
+	"shyft/internal/workload"
+	"shyft/pkg/httpErrors"
+)
+
+type generateShiftScheduleDTO struct {
+	Order        []int  `json:"order"`         // user ids in rotation order
+	StartUserID  int    `json:"start_user_id"` // user taking the first shift
+	HandoverTime string `json:"handover_time"` // HH:MM
+	Preview      bool   `json:"preview"`       // return the shifts without saving them
+
+	// Balance assigns shifts toward equal load, counting the hours worked in the organization's
+	// shift schedules from BalanceFrom (defaults to one year before the schedule start) until the schedule start
+	Balance     bool       `json:"balance"`
+	BalanceFrom *time.Time `json:"balance_from"`
+
+	// HolidayOrder (user ids) takes the organization's holidays out of the regular rotation
+	HolidayOrder []int `json:"holiday_order"`
+}
+
+// HandleGenerateShiftSchedule godoc
+// HandleGenerateShiftSchedule handles the request to generate the round-robin shifts of a shift schedule
+// @Summary generate the shifts of a shift schedule
+// @Schemes
+// @Description lay out back-to-back round-robin shifts of `frequency` days from the schedule users between its start and end date
+// @Tags Shift
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Shift Schedule ID"
+// @Param preview query bool false "Return the generated shifts without saving them"
+// @Param body body generateShiftScheduleDTO false "rotation options"
+// @Success 200 {object} RespondJson "successfully generated shifts"
+// @Failure 400 {object} RespondJson "cannot generate shifts due to invalid request body"
+// @Failure 403 {object} RespondJson "cannot generate shifts due to missing permission"
+// @Failure 404 {object} RespondJson "cannot generate shifts due to not found"
+// @Failure 409 {object} RespondJson "cannot generate shifts while the shift schedule is approved or when they conflict"
+// @Failure 500 {object} RespondJson "cannot generate shifts due to internal server error"
+// @Router /shift-schedules/{id}/generate [post]
+func (ss *ShiftService) HandleGenerateShiftSchedule(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get shift schedule id from path and rotation options from request body
+	id := c.Param("id")
+	if id == "" {
+		return http.StatusBadRequest, nil, nil
+	}
+	var params generateShiftScheduleDTO
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&params); err != nil {
+			return http.StatusBadRequest, nil, err
+		}
+	}
+	if c.Query("preview") == "true" {
+		params.Preview = true
+	}
+
+	// Step 2: Get shift schedule and check that the caller may read (preview) or update it
+	repo, err := ss.scheduleRepository(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	shiftSchedule, err := repo.FindByID(id)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot generate shifts due to not found")
+		}
+		return r, i, errors.New("cannot generate shifts due to internal server error")
+	}
+	permission := policy.UpdateSchedules
+	if params.Preview {
+		permission = policy.ReadSchedules
+	}
+	if err := ss.authorize(c, permission, shiftSchedule); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 3: Get the hours worked before the schedule start when balancing
+	var load map[uint]float64
+	if params.Balance {
+		from := shiftSchedule.Start_Date.AddDate(-1, 0, 0)
+		if params.BalanceFrom != nil {
+			from = *params.BalanceFrom
+		}
+		if !shiftSchedule.Start_Date.After(from) {
+			return http.StatusBadRequest, nil, errors.New("balance_from must be before the shift schedule start date")
+		}
+		others, err := repo.ListOverlapping(from, shiftSchedule.Start_Date, shiftSchedule.ID)
+		if err != nil {
+			return http.StatusInternalServerError, nil, err
+		}
+		report, err := ss.workloadReport(others, from, shiftSchedule.Start_Date, shiftSchedule.Location())
+		if err != nil {
+			return http.StatusInternalServerError, nil, err
+		}
+		load = workload.Hours(report)
+	}
+
+	// Step 4: Generate the rotation, skipping people on leave and handing holidays to the holiday rotation
+	leaves, err := ss.leaveCalendar([]models.ShiftSchedule{*shiftSchedule}, nil, shiftSchedule.Start_Date, shiftSchedule.End_Date)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+	var isHoliday func(time.Time) bool
+	if len(params.HolidayOrder) > 0 {
+		holidays, err := ss.holidayCalendar([]models.ShiftSchedule{*shiftSchedule}, shiftSchedule.Start_Date, shiftSchedule.End_Date)
+		if err != nil {
+			return http.StatusInternalServerError, nil, err
+		}
+		isHoliday = holidays.Of(shiftSchedule.OrganizationID)
+	}
+	shifts, err := rotation.Generate(shiftSchedule, rotation.Options{
+		Order:        params.Order,
+		StartUserID:  params.StartUserID,
+		HandoverTime: params.HandoverTime,
+		Unavailable:  leaves.Unavailable,
+		Balance:      params.Balance,
+		Load:         load,
+		HolidayOrder: params.HolidayOrder,
+		IsHoliday:    isHoliday,
+	})
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	if params.Preview {
+		return http.StatusOK, shifts, nil
+	}
+	if err := workflow.CheckEditable(shiftSchedule); err != nil {
+		return http.StatusConflict, nil, err
+	}
+
+	// Step 5: Save the generated shifts unless they conflict with other schedules
+	before := shiftSchedule.Clone()
+	shiftSchedule.Shifts, err = rotation.ToJSONB(shifts)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+	if err := ss.checkConflicts(repo, shiftSchedule); err != nil {
+		var conflicts *conflict.Error
+		if errors.As(err, &conflicts) {
+			return http.StatusConflict, nil, err
+		}
+		return http.StatusInternalServerError, nil, err
+	}
+	if err := repo.Save(shiftSchedule, ss.event(c, events.ShiftScheduleUpdated, before)); err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot generate shifts due to internal server error")
+	}
+
+	// Step 6: Return the saved shifts
+	return http.StatusOK, shiftSchedule.Shifts, nil
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleGetAllLeaves godoc
+// HandleGetAllLeaves handles the request to get leaves
+// @Summary get leaves
+// @Schemes
+// @Description get leaves filtered by user, status, type and period. Callers limited to their own leaves only get those and the leaves of the people they manage.
+// @Tags Leave
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param user_id query int false "User ID"
+// @Param status query string false "Status" Enums(pending, approved, rejected)
+// @Param type query string false "Type" Enums(vacation, sick, training)
+// @Param from query string false "Start of the period (RFC 3339)"
+// @Param to query string false "End of the period (RFC 3339)"
+// @Success 200 {object} RespondJson "get leaves successfully"
+// @Failure 400 {object} RespondJson "cannot get leaves due to invalid query parameters"
+// @Failure 403 {object} RespondJson "cannot get leaves due to missing permission"
+// @Failure 500 {object} RespondJson "cannot get leaves due to internal server error"
+// @Router /leaves [get]
+func (ss *ShiftService) HandleGetAllLeaves(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get filters from query
+	var params models.LeaveListParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+
+	// Step 2: Callers only get the leaves of their organization they may read
+	repo, err := ss.leaveRepository(c, policy.ReadLeaves)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 3: Get leaves from database
+	leaves, err := repo.List(params)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot get leaves due to internal server error")
+	}
+
+	// Step 4: Return leaves
+	return http.StatusOK, leaves, nil
+}
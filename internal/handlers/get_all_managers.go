@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleGetAllManagers godoc
+// HandleGetAllManagers handles the request to get all managers with filtering, sorting and pagination
+// @Summary get all managers with filters
+// @Description get all managers with pagination, search, sort and filter options
+// @Tags Manager
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" minimum(1) default(1)
+// @Param page_size query int false "Page size" minimum(1) maximum(100) default(10)
+// @Param search query string false "Search in name, mail, phone, description"
+// @Param sort_by query string false "Sort by field (id, name, mail, phone, created_at, updated_at)" default(created_at)
+// @Param sort_order query string false "Sort order (ASC or DESC)" default(DESC)
+// @Param active query bool false "Filter only active records (not deleted)"
+// @Param name query string false "Filter by name"
+// @Param mail query string false "Filter by mail"
+// @Param phone query string false "Filter by phone"
+// @Param organization_id query int false "Filter by organization ID"
+// @Success 200 {object} models.ManagerListResponse "get all managers successfully"
+// @Failure 400 {object} RespondJson "cannot get all managers due to invalid request parameters"
+// @Failure 403 {object} RespondJson "cannot get all managers due to missing permission"
+// @Failure 500 {object} RespondJson "cannot get all managers due to internal server error"
+// @Router /managers [get]
+func (ss *ShiftService) HandleGetAllManagers(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Check that the caller may read managers (only of their own organization for ":own")
+	organizationID, err := ss.ownOrganization(c, policy.ReadManagers)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 2: Parse query parameters and set default values
+	var params models.ContactListParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		return http.StatusBadRequest, nil, errors.New("invalid query parameters: " + err.Error())
+	}
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 || params.PageSize > 100 {
+		params.PageSize = 10
+	}
+
+	// Step 3: Use repository for listing
+	result, err := repository.NewManagerRepository(ss.db).List(params, organizationID)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot get managers: " + err.Error())
+	}
+	return http.StatusOK, result, nil
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"shyft/internal/models"
-	"shyft/internal/repository"
 	"shyft/pkg/httpErrors"
 )
 
@@ -48,6 +47,11 @@ import (
 // @Failure 500 {object} RespondJson "cannot get all shift schedules due to internal server error"
 // @Router /shift-schedules [get]
 func (ss *ShiftService) HandleGetAllShiftSchedules(c *gin.Context) (int, interface{}, error) {
+	return ss.listShiftSchedules(c, nil)
+}
+
+// listShiftSchedules lists the shift schedules matching the query parameters, filter may narrow them further
+func (ss *ShiftService) listShiftSchedules(c *gin.Context, filter func(params *models.ListParams)) (int, interface{}, error) {
 	// Step 1: Parse query parameters
 	var params models.ListParams
 	if err := c.ShouldBindQuery(&params); err != nil {
@@ -61,9 +65,15 @@ func (ss *ShiftService) HandleGetAllShiftSchedules(c *gin.Context) (int, interfa
 	if params.PageSize < 1 || params.PageSize > 100 {
 		params.PageSize = 10
 	}
+	if filter != nil {
+		filter(&params)
+	}
 
-	// Step 3: Use repository for listing
-	repo := repository.NewShiftScheduleRepository(ss.db)
+	// Step 3: Use repository (limited to the schedules the caller may read) for listing
+	repo, err := ss.readableSchedules(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
 	result, err := repo.List(params)
 	if err != nil {
 		r, i := httpErrors.ErrorResponse(err)
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"shyft/internal/conflict"
+	"shyft/internal/models"
+	"shyft/internal/override"
+	"shyft/internal/recurrence"
+	"shyft/internal/repository"
+)
+
+// conflictsPeriod is the period reported by the conflicts endpoint when no end is given
+const conflictsPeriod = 31 * 24 * time.Hour
+
+// HandleGetConflicts godoc
+// HandleGetConflicts handles the request to get the conflicts of the shift schedules
+// @Summary get the conflicts of the shift schedules
+// @Schemes
+// @Description get the double bookings of people across shift schedules, the overlapping shifts of a shift schedule and the shifts outside of their shift schedule start and end date
+// @Tags Shift
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "Start of the period (RFC 3339), defaults to now"
+// @Param to query string false "End of the period (RFC 3339), defaults to 31 days after from"
+// @Param type query string false "Conflict type" Enums(double_booking, overlap, out_of_range)
+// @Success 200 {object} RespondJson "get conflicts successfully"
+// @Failure 400 {object} RespondJson "cannot get conflicts due to invalid query parameters"
+// @Failure 403 {object} RespondJson "cannot get conflicts due to missing permission"
+// @Failure 500 {object} RespondJson "cannot get conflicts due to internal server error"
+// @Router /conflicts [get]
+func (ss *ShiftService) HandleGetConflicts(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get the period and the conflict type from query
+	from, err := optionalTimeQuery(c, "from")
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	to, err := optionalTimeQuery(c, "to")
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	if from == nil {
+		now := time.Now()
+		from = &now
+	}
+	if to == nil {
+		end := from.Add(conflictsPeriod)
+		to = &end
+	}
+	conflictType := c.Query("type")
+
+	// Step 2: Get the shift schedules the caller may read overlapping the period
+	repo, err := ss.readableSchedules(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	shiftSchedules, err := repo.ListOverlapping(*from, *to, 0)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	// Step 3: Detect the conflicts of the effective shifts
+	schedules, err := ss.effectiveShifts(shiftSchedules, *from, *to)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+	conflicts := []models.Conflict{}
+	for _, c := range conflict.Detect(schedules) {
+		if (conflictType == "" || c.Type == conflictType) && inPeriod(c, *from, *to) {
+			conflicts = append(conflicts, c)
+		}
+	}
+
+	// Step 4: Return conflicts
+	return http.StatusOK, conflicts, nil
+}
+
+// checkConflicts returns a *conflict.Error when the shift schedule, with the pending overrides that are not
+// stored yet, conflicts with itself or with the other shift schedules of the repository
+func (ss *ShiftService) checkConflicts(repo *repository.ShiftScheduleRepository, shiftSchedule *models.ShiftSchedule, pending ...models.ShiftOverride) error {
+	from, to := shiftSchedule.Start_Date, shiftSchedule.End_Date
+	others, err := repo.ListOverlapping(from, to, shiftSchedule.ID)
+	if err != nil {
+		return err
+	}
+	schedules, err := ss.effectiveShifts(append([]models.ShiftSchedule{*shiftSchedule}, others...), from, to, pending...)
+	if err != nil {
+		return err
+	}
+
+	conflicts := conflict.Involving(conflict.Detect(schedules), shiftSchedule.ID)
+	if len(conflicts) > 0 {
+		return &conflict.Error{Conflicts: conflicts}
+	}
+	return nil
+}
+
+// effectiveShifts gets the stored shifts, recurrence occurrences and overrides of the shift schedules in [from, to),
+// the pending overrides apply on top of the stored ones
+func (ss *ShiftService) effectiveShifts(shiftSchedules []models.ShiftSchedule, from, to time.Time, pending ...models.ShiftOverride) ([]conflict.Schedule, error) {
+	overrides, err := ss.overridesOf(shiftSchedules, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range pending {
+		overrides[o.ShiftScheduleID] = append(overrides[o.ShiftScheduleID], o)
+	}
+
+	schedules := make([]conflict.Schedule, 0, len(shiftSchedules))
+	for i := range shiftSchedules {
+		shifts, err := recurrence.WithOccurrences(&shiftSchedules[i], from, to)
+		if err != nil {
+			return nil, err
+		}
+		shifts = override.Apply(shifts, overrides[shiftSchedules[i].ID], shiftSchedules[i].Location())
+		schedules = append(schedules, conflict.Schedule{Schedule: &shiftSchedules[i], Shifts: shifts})
+	}
+	return schedules, nil
+}
+
+// inPeriod reports whether a shift of the conflict overlaps [from, to)
+func inPeriod(c models.Conflict, from, to time.Time) bool {
+	for _, shift := range c.Shifts {
+		if shift.Start.Before(to) && shift.End.After(from) {
+			return true
+		}
+	}
+	return false
+}
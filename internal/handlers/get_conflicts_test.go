@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"shyft/internal/models"
+)
+
+func TestHandleGetConflicts(t *testing.T) {
+	ss, mock := newTestService(t)
+
+	// A caller reading only their own schedules gets the conflicts of the schedules they belong to or manage,
+	// managers by the person they are linked to
+	mock.ExpectQuery(`SELECT \* FROM "shift_schedule" WHERE .*shift_schedule.organization_id = \$\d+ AND .*m->>'person_id' = \$\d+ .*u->>'id' = \$\d+`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 1, "5", "erin@example.com", "erin@example.com", "5", "erin@example.com", "erin@example.com").
+		WillReturnRows(scheduleRow(scheduleRow(sqlmock.NewRows(scheduleColumns),
+			3, models.StatusPending, `[{"id": 0, "start": "2026-03-02 09:00:00", "end": "2026-03-09 09:00:00", "user": {"id": 5}}]`),
+			4, models.StatusPending, `[{"id": 0, "start": "2026-03-04 09:00:00", "end": "2026-03-05 09:00:00", "user": {"id": 5}}]`))
+	mock.ExpectQuery(`SELECT \* FROM "shift_overrides"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	c := newTestContext(&models.Claims{UserID: 5, Mail: "erin@example.com", Role: "user", OrganizationID: 1}, "")
+	c.Request.URL.RawQuery = url.Values{"from": {"2026-03-02T00:00:00Z"}, "to": {"2026-03-16T00:00:00Z"}}.Encode()
+	code, result, err := ss.HandleGetConflicts(c)
+	if code != http.StatusOK || err != nil {
+		t.Fatalf("HandleGetConflicts() = %d, %v", code, err)
+	}
+	conflicts := result.([]models.Conflict)
+	if len(conflicts) != 1 || conflicts[0].Type != "double_booking" {
+		t.Errorf("HandleGetConflicts() = %+v, want a double booking", conflicts)
+	}
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/config"
+	"shyft/internal/coverage"
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+	"shyft/pkg/logger"
+)
+
+const (
+	// coveragePeriod is the period reported by the coverage gaps endpoint when no end is given
+	coveragePeriod = 31 * 24 * time.Hour
+
+	// defaults of the uncovered hours gauge
+	defaultCoverageHorizon  = 7 * 24 // hours
+	defaultCoverageInterval = 300    // seconds
+)
+
+// HandleGetCoverageGaps godoc
+// HandleGetCoverageGaps handles the request to get the coverage gaps of shift schedules
+// @Summary get the coverage gaps of shift schedules
+// @Schemes
+// @Description get the periods nobody is assigned to, per shift schedule of the organization (or of a single shift schedule)
+// @Tags Shift
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param shift_schedule_id query int false "Shift Schedule ID, defaults to every shift schedule of the organization"
+// @Param organization_id query int false "Organization ID, defaults to the caller's organization"
+// @Param tz query string false "IANA time zone gaps are written in, defaults to the time zone of every schedule"
+// @Param from query string false "Start of the period (RFC 3339), defaults to now"
+// @Param to query string false "End of the period (RFC 3339), defaults to 31 days after from"
+// @Success 200 {object} RespondJson "get coverage gaps successfully"
+// @Failure 400 {object} RespondJson "cannot get coverage gaps due to invalid query parameters"
+// @Failure 403 {object} RespondJson "cannot get coverage gaps due to missing permission"
+// @Failure 404 {object} RespondJson "cannot get coverage gaps due to not found"
+// @Failure 500 {object} RespondJson "cannot get coverage gaps due to internal server error"
+// @Router /coverage-gaps [get]
+func (ss *ShiftService) HandleGetCoverageGaps(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get the time zone and the period from query
+	loc, err := requestLocation(c)
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	from, err := optionalTimeQuery(c, "from")
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	to, err := optionalTimeQuery(c, "to")
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	if from == nil {
+		now := time.Now()
+		from = &now
+	}
+	if to == nil {
+		end := from.Add(coveragePeriod)
+		to = &end
+	}
+
+	// Step 2: Get the shift schedules the caller may read, only the caller's organization can be asked for
+	if value := c.Query("organization_id"); value != "" {
+		organizationID, err := strconv.Atoi(value)
+		if err != nil {
+			return http.StatusBadRequest, nil, errors.New("invalid organization_id")
+		}
+		if claims := claimsFromContext(c); claims == nil || claims.OrganizationID != organizationID {
+			return http.StatusForbidden, nil, httpErrors.Forbidden
+		}
+	}
+	repo, err := ss.readableSchedules(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	var shiftSchedules []models.ShiftSchedule
+	if id := c.Query("shift_schedule_id"); id != "" {
+		shiftSchedule, err := repo.FindByID(id)
+		if err != nil {
+			r, i := httpErrors.ErrorResponse(err)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return http.StatusNotFound, i, errors.New("cannot get coverage gaps due to not found")
+			}
+			return r, i, errors.New("cannot get coverage gaps due to internal server error")
+		}
+		if err := ss.authorize(c, policy.ReadSchedules, shiftSchedule); err != nil {
+			return http.StatusForbidden, nil, err
+		}
+		shiftSchedules = append(shiftSchedules, *shiftSchedule)
+	} else {
+		shiftSchedules, err = repo.ListOverlapping(*from, *to, 0)
+		if err != nil {
+			return http.StatusInternalServerError, nil, err
+		}
+	}
+
+	// Step 3: Compute the coverage gaps of the effective shifts
+	reports, err := ss.coverageReports(shiftSchedules, *from, *to, loc)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	// Step 4: Return coverage gaps
+	return http.StatusOK, reports, nil
+}
+
+// coverageReports computes the coverage gaps of the effective shifts of the shift schedules in [from, to), reported
+// in loc (the time zone of every schedule when nil)
+func (ss *ShiftService) coverageReports(shiftSchedules []models.ShiftSchedule, from, to time.Time, loc *time.Location) ([]models.CoverageReport, error) {
+	schedules, err := ss.effectiveShifts(shiftSchedules, from, to)
+	if err != nil {
+		return nil, err
+	}
+	reports := []models.CoverageReport{}
+	for _, s := range schedules {
+		reports = append(reports, coverage.Report(s.Schedule, s.Shifts, from, to, viewLocation(loc, s.Schedule)))
+	}
+	return reports, nil
+}
+
+// reportUncoveredHours periodically exports the upcoming uncovered hours of every shift schedule
+func (ss *ShiftService) reportUncoveredHours() {
+	horizon := config.C.Metric.CoverageHorizon
+	if horizon <= 0 {
+		horizon = defaultCoverageHorizon
+	}
+	interval := config.C.Metric.CoverageInterval
+	if interval <= 0 {
+		interval = defaultCoverageInterval
+	}
+
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+	for ; true; <-ticker.C {
+		from := time.Now()
+		to := from.Add(time.Duration(horizon) * time.Hour)
+		shiftSchedules, err := repository.NewShiftScheduleRepository(ss.db).ListOverlapping(from, to, 0)
+		if err != nil {
+			logger.CLogger.Warn("Cannot list shift schedules for the uncovered hours metric: ", err)
+			continue
+		}
+		reports, err := ss.coverageReports(shiftSchedules, from, to, nil)
+		if err != nil {
+			logger.CLogger.Warn("Cannot compute the uncovered hours metric: ", err)
+			continue
+		}
+
+		ss.metrics.ResetUncoveredHours()
+		for i, report := range reports {
+			organizationID := ""
+			if shiftSchedules[i].OrganizationID != nil {
+				organizationID = strconv.FormatUint(uint64(*shiftSchedules[i].OrganizationID), 10)
+			}
+			ss.metrics.SetUncoveredHours(organizationID, strconv.FormatUint(uint64(report.ShiftScheduleID), 10), report.UncoveredHours)
+		}
+	}
+}
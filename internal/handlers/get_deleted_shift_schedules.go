@@ -7,7 +7,6 @@ import (
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 
-	"shyft/internal/models"
 	"shyft/pkg/httpErrors"
 )
 
@@ -27,9 +26,13 @@ import (
 // @Failure 500 {object} RespondJson "cannot get only deleted shift schedules due to internal server error"
 // @Router /shift-schedules/deleted [get]
 func (ss *ShiftService) HandleGetOnlyDeletedShiftSchedules(c *gin.Context) (int, interface{}, error) {
-	// Step 1: Get all shift schedules from database
-	var shiftSchedules []models.ShiftSchedule
-	if err := ss.db.Unscoped().Where("deleted_at IS NOT NULL").Find(&shiftSchedules).Error; err != nil {
+	// Step 1: Get all deleted shift schedules the caller may read from database
+	repo, err := ss.readableSchedules(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	shiftSchedules, err := repo.ListDeleted()
+	if err != nil {
 		r, i := httpErrors.ErrorResponse(err)
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return r, i, errors.New("cannot get all shift schedules due to not found")
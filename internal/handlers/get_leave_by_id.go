@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/policy"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleGetLeaveByID godoc
+// HandleGetLeaveByID handles the request to get a leave by id
+// @Summary get a leave by id
+// @Schemes
+// @Description get a leave by id
+// @Tags Leave
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Leave ID"
+// @Success 200 {object} RespondJson "get leave by id successfully"
+// @Failure 403 {object} RespondJson "cannot get leave due to missing permission"
+// @Failure 404 {object} RespondJson "cannot get leave due to not found"
+// @Failure 500 {object} RespondJson "cannot get leave due to internal server error"
+// @Router /leaves/{id} [get]
+func (ss *ShiftService) HandleGetLeaveByID(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get leave id from path and validate
+	id := c.Param("id")
+	if id == "" {
+		return http.StatusBadRequest, nil, nil
+	}
+
+	// Step 2: Get leave by id from the leaves the caller may read
+	repo, err := ss.leaveRepository(c, policy.ReadLeaves)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	leave, err := repo.FindByID(id)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot get leave due to not found")
+		}
+		return r, i, errors.New("cannot get leave due to internal server error")
+	}
+
+	// Step 3: Return leave
+	return http.StatusOK, leave, nil
+}
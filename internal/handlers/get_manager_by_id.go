@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleGetManagerByID godoc
+// HandleGetManagerByID handles the request to get a manager by id
+// @Summary get a manager by id
+// @Schemes
+// @Description get a manager by id
+// @Tags Manager
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Manager ID"
+// @Success 200 {object} RespondJson "get manager by id successfully"
+// @Failure 403 {object} RespondJson "cannot get manager by id due to missing permission"
+// @Failure 404 {object} RespondJson "cannot get manager by id due to not found"
+// @Failure 500 {object} RespondJson "cannot get manager by id due to internal server error"
+// @Router /managers/{id} [get]
+func (ss *ShiftService) HandleGetManagerByID(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get manager id from path and validate
+	id := c.Param("id")
+	if id == "" {
+		return http.StatusBadRequest, nil, nil
+	}
+
+	// Step 2: Get manager by id from database
+	manager, err := repository.NewManagerRepository(ss.db).FindByID(id)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot get manager by id due to not found")
+		}
+		return r, i, errors.New("cannot get manager by id due to internal server error")
+	}
+
+	// Step 3: Check that the caller may read the manager
+	if err := ss.authorizeOrganization(c, policy.ReadManagers, manager.OrganizationID); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 4: Return manager by id
+	return http.StatusOK, manager, nil
+}
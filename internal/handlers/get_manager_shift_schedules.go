@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleGetManagerShiftSchedules godoc
+// HandleGetManagerShiftSchedules handles the request to get the shift schedules owned by a manager
+// @Summary get shift schedules of a manager
+// @Description get the shift schedules owned by a manager, accepts the filters of GET /shift-schedules
+// @Tags Manager
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Manager ID"
+// @Param page query int false "Page number" minimum(1) default(1)
+// @Param page_size query int false "Page size" minimum(1) maximum(100) default(10)
+// @Param search query string false "Search in alias, description, organization, manager"
+// @Param sort_by query string false "Sort by field (alias, year, start_date, end_date, status, organization_name, manager_name)" default(created_at)
+// @Param sort_order query string false "Sort order (ASC or DESC)" default(DESC)
+// @Success 200 {object} models.ShiftScheduleListResponse "get manager shift schedules successfully"
+// @Failure 400 {object} RespondJson "cannot get manager shift schedules due to invalid request parameters"
+// @Failure 403 {object} RespondJson "cannot get manager shift schedules due to missing permission"
+// @Failure 404 {object} RespondJson "cannot get manager shift schedules due to not found"
+// @Failure 500 {object} RespondJson "cannot get manager shift schedules due to internal server error"
+// @Router /managers/{id}/shift-schedules [get]
+func (ss *ShiftService) HandleGetManagerShiftSchedules(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get manager id from path and validate
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 1 {
+		return http.StatusBadRequest, nil, httpErrors.BadQueryParams
+	}
+
+	// Step 2: Get manager and check that the caller may read it
+	manager, err := repository.NewManagerRepository(ss.db).FindByID(c.Param("id"))
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot get manager shift schedules due to not found")
+		}
+		return r, i, errors.New("cannot get manager shift schedules due to internal server error")
+	}
+	if err := ss.authorizeOrganization(c, policy.ReadManagers, manager.OrganizationID); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 3: List the shift schedules owned by the manager
+	return ss.listShiftSchedules(c, func(params *models.ListParams) {
+		params.ManagerID = &id
+	})
+}
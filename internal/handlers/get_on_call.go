@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"shyft/internal/models"
+	"shyft/internal/oncall"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleGetOnCall godoc
+// HandleGetOnCall handles the request to get the person on call per shift schedule
+// @Summary get the person on call per shift schedule
+// @Schemes
+// @Description get the person currently on shift per shift schedule, with the next person and the handover time
+// @Tags Shift
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param at query string false "Time to resolve (RFC 3339), defaults to now"
+// @Param tz query string false "IANA time zone `at` is read in when it has no offset"
+// @Param organization_id query int false "Organization ID, defaults to the caller's organization"
+// @Success 200 {object} RespondJson "get on call successfully"
+// @Failure 400 {object} RespondJson "cannot get on call due to invalid query parameters"
+// @Failure 403 {object} RespondJson "cannot get on call of another organization"
+// @Failure 500 {object} RespondJson "cannot get on call due to internal server error"
+// @Router /on-call [get]
+func (ss *ShiftService) HandleGetOnCall(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get the time to resolve from query
+	at := time.Now()
+	loc, err := requestLocation(c)
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	if value := c.Query("at"); value != "" {
+		parsed, err := models.ParseShiftTime(value, viewLocation(loc, nil))
+		if err != nil {
+			return http.StatusBadRequest, nil, errors.New("invalid at, expected an RFC 3339 timestamp")
+		}
+		at = parsed
+	}
+
+	// Step 2: Get shift schedules the caller may read, only the caller's organization can be asked for
+	if value := c.Query("organization_id"); value != "" {
+		organizationID, err := strconv.Atoi(value)
+		if err != nil {
+			return http.StatusBadRequest, nil, errors.New("invalid organization_id")
+		}
+		if claims := claimsFromContext(c); claims == nil || claims.OrganizationID != organizationID {
+			return http.StatusForbidden, nil, httpErrors.Forbidden
+		}
+	}
+	repo, err := ss.readableSchedules(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	shiftSchedules, err := repo.ListActiveAt(at)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	// Step 3: Get the overrides of the shift schedules
+	overrides, err := ss.overridesOf(shiftSchedules, at, at.Add(oncall.Lookahead))
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	// Step 4: Get the approved leaves of the people of the shift schedules
+	leaves, err := ss.leaveCalendar(shiftSchedules, overrides, at, at.Add(oncall.Lookahead))
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	// Step 5: Resolve the person on call per shift schedule, skipping people on leave
+	data := []models.OnCall{}
+	for i := range shiftSchedules {
+		onCall, err := oncall.Resolve(&shiftSchedules[i], overrides[shiftSchedules[i].ID], leaves, at)
+		if err != nil {
+			return http.StatusInternalServerError, nil, err
+		}
+		data = append(data, onCall)
+	}
+
+	// Step 6: Return on call
+	return http.StatusOK, data, nil
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleGetOrganizationByID godoc
+// HandleGetOrganizationByID handles the request to get an organization by id
+// @Summary get an organization by id
+// @Schemes
+// @Description get an organization by id
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Organization ID"
+// @Success 200 {object} RespondJson "get organization by id successfully"
+// @Failure 403 {object} RespondJson "cannot get organization by id due to missing permission"
+// @Failure 404 {object} RespondJson "cannot get organization by id due to not found"
+// @Failure 500 {object} RespondJson "cannot get organization by id due to internal server error"
+// @Router /organizations/{id} [get]
+func (ss *ShiftService) HandleGetOrganizationByID(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get organization id from path and validate
+	id := c.Param("id")
+	if id == "" {
+		return http.StatusBadRequest, nil, nil
+	}
+
+	// Step 2: Get organization by id from database
+	organization, err := repository.NewOrganizationRepository(ss.db).FindByID(id)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot get organization by id due to not found")
+		}
+		return r, i, errors.New("cannot get organization by id due to internal server error")
+	}
+
+	// Step 3: Check that the caller may read the organization
+	if err := ss.authorizeOrganization(c, policy.ReadOrganizations, &organization.ID); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 4: Return organization by id
+	return http.StatusOK, organization, nil
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"shyft/internal/holiday"
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleGetOrganizationHolidays godoc
+// HandleGetOrganizationHolidays handles the request to get the holiday calendar of an organization
+// @Summary get the holidays of an organization
+// @Schemes
+// @Description get the public holidays of the organization's calendar
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Organization ID"
+// @Param from query string false "Start of the period (RFC 3339)"
+// @Param to query string false "End of the period (RFC 3339)"
+// @Success 200 {object} RespondJson "get holidays successfully"
+// @Failure 400 {object} RespondJson "cannot get holidays due to invalid query parameters"
+// @Failure 403 {object} RespondJson "cannot get holidays due to missing permission"
+// @Failure 500 {object} RespondJson "cannot get holidays due to internal server error"
+// @Router /organizations/{id}/holidays [get]
+func (ss *ShiftService) HandleGetOrganizationHolidays(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get organization id from path and the period from query
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 1 {
+		return http.StatusBadRequest, nil, httpErrors.BadQueryParams
+	}
+	organizationID := uint(id)
+	from, err := optionalTimeQuery(c, "from")
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	to, err := optionalTimeQuery(c, "to")
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+
+	// Step 2: Check that the caller may read the organization
+	if err := ss.authorizeOrganization(c, policy.ReadOrganizations, &organizationID); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 3: Get holidays from database
+	holidays, err := repository.NewHolidayRepository(ss.db).ListByOrganization(organizationID, from, to)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	// Step 4: Return holidays
+	return http.StatusOK, holidays, nil
+}
+
+// holidayCalendar gets the holidays of the organizations of the shift schedules around [from, to)
+func (ss *ShiftService) holidayCalendar(shiftSchedules []models.ShiftSchedule, from, to time.Time) (*holiday.Calendar, error) {
+	seen := map[uint]bool{}
+	var organizationIDs []uint
+	for _, shiftSchedule := range shiftSchedules {
+		if id := shiftSchedule.OrganizationID; id != nil && !seen[*id] {
+			seen[*id] = true
+			organizationIDs = append(organizationIDs, *id)
+		}
+	}
+
+	holidays, err := repository.NewHolidayRepository(ss.db).ListForOrganizations(organizationIDs, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return holiday.NewCalendar(holidays), nil
+}
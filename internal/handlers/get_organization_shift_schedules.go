@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleGetOrganizationShiftSchedules godoc
+// HandleGetOrganizationShiftSchedules handles the request to get the shift schedules owned by an organization
+// @Summary get shift schedules of an organization
+// @Description get the shift schedules owned by an organization, accepts the filters of GET /shift-schedules
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Organization ID"
+// @Param page query int false "Page number" minimum(1) default(1)
+// @Param page_size query int false "Page size" minimum(1) maximum(100) default(10)
+// @Param search query string false "Search in alias, description, organization, manager"
+// @Param sort_by query string false "Sort by field (alias, year, start_date, end_date, status, organization_name, manager_name)" default(created_at)
+// @Param sort_order query string false "Sort order (ASC or DESC)" default(DESC)
+// @Success 200 {object} models.ShiftScheduleListResponse "get organization shift schedules successfully"
+// @Failure 400 {object} RespondJson "cannot get organization shift schedules due to invalid request parameters"
+// @Failure 403 {object} RespondJson "cannot get organization shift schedules due to missing permission"
+// @Failure 500 {object} RespondJson "cannot get organization shift schedules due to internal server error"
+// @Router /organizations/{id}/shift-schedules [get]
+func (ss *ShiftService) HandleGetOrganizationShiftSchedules(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get organization id from path and validate
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 1 {
+		return http.StatusBadRequest, nil, httpErrors.BadQueryParams
+	}
+	organizationID := uint(id)
+	if err := ss.authorizeOrganization(c, policy.ReadOrganizations, &organizationID); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 2: List the shift schedules owned by the organization
+	return ss.listShiftSchedules(c, func(params *models.ListParams) {
+		params.OrganizationID = &id
+	})
+}
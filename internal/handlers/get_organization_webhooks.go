@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleGetOrganizationWebhooks godoc
+// HandleGetOrganizationWebhooks handles the request to get the webhook subscriptions of an organization
+// @Summary get webhooks of an organization
+// @Schemes
+// @Description get the webhook subscriptions of an organization, secrets are never returned
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Organization ID"
+// @Success 200 {object} RespondJson "successfully got webhooks"
+// @Failure 400 {object} RespondJson "cannot get webhooks due to invalid organization id"
+// @Failure 403 {object} RespondJson "cannot get webhooks due to missing permission"
+// @Failure 500 {object} RespondJson "cannot get webhooks due to internal server error"
+// @Router /organizations/{id}/webhooks [get]
+func (ss *ShiftService) HandleGetOrganizationWebhooks(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get organization id from path
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 1 {
+		return http.StatusBadRequest, nil, httpErrors.BadQueryParams
+	}
+	organizationID := uint(id)
+
+	// Step 2: Check that the caller may read the organization
+	if err := ss.authorizeOrganization(c, policy.ReadOrganizations, &organizationID); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 3: Get webhook subscriptions from database
+	subscriptions, err := repository.NewWebhookRepository(ss.db).ListByOrganization(organizationID)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot get webhooks due to internal server error")
+	}
+
+	// Step 4: Return webhook subscriptions
+	return http.StatusOK, subscriptions, nil
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleGetShiftOverrides godoc
+// HandleGetShiftOverrides handles the request to get the overrides of a shift schedule
+// @Summary get the overrides of a shift schedule
+// @Schemes
+// @Description get the overrides of a shift schedule, optionally only those overlapping [from, to)
+// @Tags Shift
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Shift Schedule ID"
+// @Param from query string false "Start of the period (RFC 3339)"
+// @Param to query string false "End of the period (RFC 3339)"
+// @Success 200 {object} RespondJson "get shift overrides successfully"
+// @Failure 400 {object} RespondJson "cannot get shift overrides due to invalid query parameters"
+// @Failure 403 {object} RespondJson "cannot get shift overrides due to missing permission"
+// @Failure 404 {object} RespondJson "cannot get shift overrides due to not found"
+// @Failure 500 {object} RespondJson "cannot get shift overrides due to internal server error"
+// @Router /shift-schedules/{id}/overrides [get]
+func (ss *ShiftService) HandleGetShiftOverrides(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get shift schedule id from path and period from query
+	id := c.Param("id")
+	if id == "" {
+		return http.StatusBadRequest, nil, nil
+	}
+	from, err := optionalTimeQuery(c, "from")
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	to, err := optionalTimeQuery(c, "to")
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+
+	// Step 2: Get shift schedule and check that the caller may read it
+	repo, err := ss.scheduleRepository(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	shiftSchedule, err := repo.FindByID(id)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot get shift overrides due to not found")
+		}
+		return r, i, errors.New("cannot get shift overrides due to internal server error")
+	}
+	if err := ss.authorize(c, policy.ReadSchedules, shiftSchedule); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 3: Get overrides from database
+	overrides, err := repository.NewShiftOverrideRepository(ss.db).ListBySchedule(shiftSchedule.ID, from, to)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot get shift overrides due to internal server error")
+	}
+
+	// Step 4: Return overrides
+	return http.StatusOK, overrides, nil
+}
+
+// overridesOf gets the overrides of the shift schedules overlapping [from, to), grouped by schedule
+func (ss *ShiftService) overridesOf(shiftSchedules []models.ShiftSchedule, from, to time.Time) (map[uint][]models.ShiftOverride, error) {
+	ids := make([]uint, 0, len(shiftSchedules))
+	for _, shiftSchedule := range shiftSchedules {
+		ids = append(ids, shiftSchedule.ID)
+	}
+	return repository.NewShiftOverrideRepository(ss.db).ListForSchedules(ids, from, to)
+}
+
+// get an optional RFC 3339 (or shift time layout, in the `tz` time zone) time from query
+func optionalTimeQuery(c *gin.Context, key string) (*time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+	loc, err := requestLocation(c)
+	if err != nil {
+		return nil, err
+	}
+	t, err := models.ParseShiftTime(value, viewLocation(loc, nil))
+	if err != nil {
+		return nil, errors.New("invalid " + key + ", expected an RFC 3339 timestamp")
+	}
+	return &t, nil
+}
+
+// requestLocation returns the IANA time zone of the `tz` query parameter, nil when it is not given
+func requestLocation(c *gin.Context) (*time.Location, error) {
+	if c.Query("tz") == "" {
+		return nil, nil
+	}
+	return models.LoadLocation(c.Query("tz"))
+}
+
+// viewLocation returns the requested time zone, or else the time zone of the shift schedule (DefaultLocation without one)
+func viewLocation(requested *time.Location, shiftSchedule *models.ShiftSchedule) *time.Location {
+	switch {
+	case requested != nil:
+		return requested
+	case shiftSchedule != nil:
+		return shiftSchedule.Location()
+	default:
+		return models.DefaultLocation
+	}
+}
+
+// inLocation returns a copy of the shift entry with its start and end written in loc
+func inLocation(shift map[string]interface{}, start, end time.Time, loc *time.Location) map[string]interface{} {
+	result := make(map[string]interface{}, len(shift))
+	for key, value := range shift {
+		result[key] = value
+	}
+	result["start"] = start.In(loc).Format(time.RFC3339)
+	result["end"] = end.In(loc).Format(time.RFC3339)
+	return result
+}
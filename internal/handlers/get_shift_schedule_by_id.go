@@ -7,7 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 
-	"shyft/internal/models"
+	"shyft/internal/policy"
 	"shyft/pkg/httpErrors"
 )
 
@@ -34,15 +34,31 @@ func (ss *ShiftService) HandleGetShiftScheduleByID(c *gin.Context) (int, interfa
 	}
 
 	// Step 2: Get shift schedule by id from database
-	var shiftSchedule models.ShiftSchedule
-	if err := ss.db.Where("deleted_at IS NULL AND id = ?", id).Find(&shiftSchedule).Error; err != nil {
+	repo, err := ss.scheduleRepository(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	shiftSchedule, err := repo.FindByID(id)
+	if err != nil {
 		r, i := httpErrors.ErrorResponse(err)
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return r, i, errors.New("cannot get shift schedule by id due to not found")
+			return http.StatusNotFound, i, errors.New("cannot get shift schedule by id due to not found")
 		}
 		return r, i, errors.New("cannot get shift schedule by id due to internal server error")
 	}
 
-	// Step 3: Return shift schedule by id
+	// Step 3: Check that the caller may read the shift schedule
+	if err := ss.authorize(c, policy.ReadSchedules, shiftSchedule); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 4: Get the status changes of the shift schedule
+	shiftSchedule.History, err = repo.ListTransitions(shiftSchedule)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot get shift schedule by id due to internal server error")
+	}
+
+	// Step 5: Return shift schedule by id
 	return http.StatusOK, shiftSchedule, nil
 }
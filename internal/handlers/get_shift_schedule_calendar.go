@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleGetShiftScheduleCalendar godoc
+// HandleGetShiftScheduleCalendar handles the request to get the iCalendar feed of the shifts of a shift schedule
+// @Summary get the calendar feed of a shift schedule
+// @Schemes
+// @Description get the shifts of the shift schedule as an RFC 5545 iCalendar feed, authenticated by the calendar token in the URL
+// @Tags Calendar
+// @Produce text/calendar
+// @Param file path string true "Shift Schedule ID followed by .ics, e.g. 3.ics"
+// @Param token query string true "Calendar token"
+// @Param tz query string false "IANA time zone the events are written in, defaults to the time zone of the schedule"
+// @Success 200 {string} string "iCalendar feed"
+// @Failure 401 {object} RespondJson "cannot get calendar due to an invalid token"
+// @Failure 403 {object} RespondJson "cannot get calendar due to missing permission"
+// @Failure 404 {object} RespondJson "cannot get calendar due to not found"
+// @Failure 500 {object} RespondJson "cannot get calendar due to internal server error"
+// @Router /calendar/schedules/{file} [get]
+func (ss *ShiftService) HandleGetShiftScheduleCalendar(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get shift schedule id from path and the time zone from query
+	id, err := calendarFileID(c.Param("file"))
+	if err != nil {
+		return http.StatusNotFound, nil, err
+	}
+	loc, err := requestLocation(c)
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+
+	// Step 2: Get shift schedule and check that the token holder may read it
+	repo, err := ss.scheduleRepository(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	shiftSchedule, err := repo.FindByID(strconv.Itoa(id))
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot get calendar due to not found")
+		}
+		return r, i, errors.New("cannot get calendar due to internal server error")
+	}
+	if err := ss.authorize(c, policy.ReadSchedules, shiftSchedule); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 3: Render the shifts of the shift schedule
+	data, err := ss.calendarOf(shiftSchedule.Alias, []models.ShiftSchedule{*shiftSchedule}, loc, nil)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	// Step 4: Return calendar
+	return http.StatusOK, data, nil
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/availability"
+	"shyft/internal/models"
+	"shyft/internal/override"
+	"shyft/internal/policy"
+	"shyft/internal/recurrence"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleGetShiftScheduleLeaveConflicts godoc
+// HandleGetShiftScheduleLeaveConflicts handles the request to get the shifts of a shift schedule assigned to people on leave
+// @Summary get the leave conflicts of a shift schedule
+// @Schemes
+// @Description get the shifts (with recurrences and overrides) of a shift schedule assigned to people on an approved leave
+// @Tags Leave
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Shift Schedule ID"
+// @Success 200 {object} RespondJson "get leave conflicts successfully"
+// @Failure 403 {object} RespondJson "cannot get leave conflicts due to missing permission"
+// @Failure 404 {object} RespondJson "cannot get leave conflicts due to not found"
+// @Failure 500 {object} RespondJson "cannot get leave conflicts due to internal server error"
+// @Router /shift-schedules/{id}/leave-conflicts [get]
+func (ss *ShiftService) HandleGetShiftScheduleLeaveConflicts(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get shift schedule id from path and validate
+	id := c.Param("id")
+	if id == "" {
+		return http.StatusBadRequest, nil, nil
+	}
+
+	// Step 2: Get shift schedule and check that the caller may read it
+	repo, err := ss.scheduleRepository(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	shiftSchedule, err := repo.FindByID(id)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot get leave conflicts due to not found")
+		}
+		return r, i, errors.New("cannot get leave conflicts due to internal server error")
+	}
+	if err := ss.authorize(c, policy.ReadSchedules, shiftSchedule); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 3: Get the effective shifts of the shift schedule
+	from, to := shiftSchedule.Start_Date, shiftSchedule.End_Date
+	shifts, err := recurrence.WithOccurrences(shiftSchedule, from, to)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+	overrides, err := ss.overridesOf([]models.ShiftSchedule{*shiftSchedule}, from, to)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+	shifts = override.Apply(shifts, overrides[shiftSchedule.ID], shiftSchedule.Location())
+
+	// Step 4: Flag the shifts of people on leave
+	leaves, err := ss.leaveCalendar([]models.ShiftSchedule{*shiftSchedule}, overrides, from, to)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+	return http.StatusOK, availability.Conflicts(shiftSchedule, shifts, leaves, shiftSchedule.Location()), nil
+}
+
+// leaveCalendar gets the approved leaves overlapping [from, to) of the people of the shift schedules and overrides
+func (ss *ShiftService) leaveCalendar(shiftSchedules []models.ShiftSchedule, overrides map[uint][]models.ShiftOverride, from, to time.Time) (*availability.Calendar, error) {
+	seen := map[uint]bool{}
+	var personIDs []uint
+	add := func(id uint) {
+		if id != 0 && !seen[id] {
+			seen[id] = true
+			personIDs = append(personIDs, id)
+		}
+	}
+	for _, shiftSchedule := range shiftSchedules {
+		for _, entry := range shiftSchedule.Users {
+			if values, ok := entry.(map[string]interface{}); ok {
+				add(models.ContactFromJSON(values).ID)
+			}
+		}
+		for _, entry := range shiftSchedule.Shifts {
+			if values, ok := entry.(map[string]interface{}); ok {
+				if user, ok := values["user"].(map[string]interface{}); ok {
+					add(models.ContactFromJSON(user).ID)
+				}
+			}
+		}
+		for _, shiftOverride := range overrides[shiftSchedule.ID] {
+			add(shiftOverride.PersonID)
+		}
+	}
+
+	leaves, err := repository.NewLeaveRepository(ss.db).ListApproved(personIDs, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return availability.NewCalendar(leaves), nil
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/policy"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleGetShiftScheduleSwaps godoc
+// HandleGetShiftScheduleSwaps handles the request to get the swap requests of a shift schedule
+// @Summary get the swap requests of a shift schedule
+// @Schemes
+// @Description get the swap requests of a shift schedule, optionally only those in a status
+// @Tags Shift Swap
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Shift Schedule ID"
+// @Param status query string false "Status" Enums(pending, accepted, declined, cancelled, rejected, completed)
+// @Success 200 {object} RespondJson "get shift swaps successfully"
+// @Failure 403 {object} RespondJson "cannot get shift swaps due to missing permission"
+// @Failure 404 {object} RespondJson "cannot get shift swaps due to not found"
+// @Failure 500 {object} RespondJson "cannot get shift swaps due to internal server error"
+// @Router /shift-schedules/{id}/swaps [get]
+func (ss *ShiftService) HandleGetShiftScheduleSwaps(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get shift schedule id from path and validate
+	id := c.Param("id")
+	if id == "" {
+		return http.StatusBadRequest, nil, nil
+	}
+
+	// Step 2: Get shift schedule and check that the caller may read it
+	repo, err := ss.scheduleRepository(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	shiftSchedule, err := repo.FindByID(id)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot get shift swaps due to not found")
+		}
+		return r, i, errors.New("cannot get shift swaps due to internal server error")
+	}
+	if err := ss.authorize(c, policy.ReadSchedules, shiftSchedule); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 3: Get swap requests from database
+	swaps, err := ss.swapRepository(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	requests, err := swaps.ListBySchedule(shiftSchedule.ID, c.Query("status"))
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot get shift swaps due to internal server error")
+	}
+
+	// Step 4: Return swap requests
+	return http.StatusOK, requests, nil
+}
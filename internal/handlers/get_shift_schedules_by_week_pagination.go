@@ -9,6 +9,9 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"shyft/internal/models"
+	"shyft/internal/override"
+	"shyft/internal/recurrence"
+	"shyft/pkg/utils"
 )
 
 // HandleGetShiftScheduleByWeekWithPagination godoc
@@ -23,6 +26,7 @@ import (
 // @Param page query string false "page"
 // @Param size query string false "size"
 // @Param orderBy query string false "orderBy" Enums(asc, desc)
+// @Param tz query string false "IANA time zone the week is taken in and shifts are written in, defaults to the time zone of every schedule"
 // @Success 200 {object} RespondJson "get shifts by current week successfully"
 // @Failure 400 {object} RespondJson "cannot get shifts schedule by current week due to invalid request body"
 // @Failure 422 {object} RespondJson "cannot get shifts schedule by current week due to invalid request body"
@@ -47,26 +51,49 @@ func (ss *ShiftService) HandleGetShiftScheduleByWeekWithPagination(c *gin.Contex
 		params.OrderBy = fmt.Sprintf("created_at %s", params.OrderBy)
 	}
 
-	// Step 3: Get all shift schedules
-	var shiftSchedules []models.ShiftSchedule
-	if err := ss.db.Where("deleted_at IS NULL").Find(&shiftSchedules).Error; err != nil {
+	// Step 3: Get all shift schedules the caller may read
+	repo, err := ss.readableSchedules(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	shiftSchedules, err := repo.ListActive()
+	if err != nil {
 		return http.StatusInternalServerError, nil, err
 	}
 
-	// Step 4: Get current date and time this week format
-	today := time.Now()
-	weekStart := today.AddDate(0, 0, -int(today.Weekday()))
-	if today.Weekday() == time.Sunday {
-		weekStart = weekStart.AddDate(0, 0, -6)
+	// Step 4: Get the current week, in the requested time zone or else in the time zone of every schedule
+	loc, err := requestLocation(c)
+	if err != nil {
+		return http.StatusBadRequest, nil, err
 	}
-	weekStart = time.Date(weekStart.Year(), weekStart.Month(), weekStart.Day(), 0, 0, 0, 0, weekStart.Location())
-	weekEnd := weekStart.AddDate(0, 0, 6)
-	weekEnd = time.Date(weekEnd.Year(), weekEnd.Month(), weekEnd.Day(), 23, 59, 59, 0, weekEnd.Location())
+	now := time.Now()
+	// overrides and holidays are loaded for the current week of every time zone
+	windowStart, windowEnd := utils.WeekRange(now, time.UTC)
+	windowStart, windowEnd = windowStart.AddDate(0, 0, -1), windowEnd.AddDate(0, 0, 1)
 
 	// Step 5: Filter shift schedules by current week
+	overrides, err := ss.overridesOf(shiftSchedules, windowStart, windowEnd)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+	holidays, err := ss.holidayCalendar(shiftSchedules, windowStart, windowEnd)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
 	var data []map[string]interface{}
 	for _, shiftSchedule := range shiftSchedules {
-		//
+		scheduleLoc := shiftSchedule.Location()
+		viewLoc := viewLocation(loc, &shiftSchedule)
+		weekStart, weekEnd := utils.WeekRange(now, viewLoc)
+
+		// Stored shifts and recurrence occurrences of the current week, with the overrides on top and the holidays marked
+		shifts, err := recurrence.WithOccurrences(&shiftSchedule, weekStart, weekEnd)
+		if err != nil {
+			return http.StatusInternalServerError, nil, err
+		}
+		shifts = override.Apply(shifts, overrides[shiftSchedule.ID], scheduleLoc)
+		shifts = holidays.Mark(shiftSchedule.OrganizationID, shifts, scheduleLoc)
+
 		temp := map[string]interface{}{
 			"id":           shiftSchedule.ID,
 			"alias":        shiftSchedule.Alias,
@@ -81,20 +108,23 @@ func (ss *ShiftService) HandleGetShiftScheduleByWeekWithPagination(c *gin.Contex
 			"shifts":       []interface{}{},
 		}
 
-		for _, shift := range shiftSchedule.Shifts {
+		for _, shift := range shifts {
 			// Unmarshal shift to map
 			shiftMap, ok := shift.(map[string]interface{})
 			if !ok {
 				continue // Skip if shift is not a map
 			}
-			startTime, err := time.Parse(time.RFC3339, shiftMap["start"].(string))
+			start, _ := shiftMap["start"].(string)
+			end, _ := shiftMap["end"].(string)
+			startTime, err := models.ParseShiftTime(start, scheduleLoc)
 			if err != nil {
 				continue // Skip invalid date formats
 			}
-			endTime, err := time.Parse(time.RFC3339, shiftMap["end"].(string))
+			endTime, err := models.ParseShiftTime(end, scheduleLoc)
 			if err != nil {
 				continue // Skip invalid date formats
 			}
+			shift = inLocation(shiftMap, startTime, endTime, viewLoc)
 
 			// Check if shift is in current week range
 			if (startTime.After(weekStart) || startTime.Equal(weekStart)) && (endTime.Before(weekEnd) || endTime.Equal(weekEnd)) {
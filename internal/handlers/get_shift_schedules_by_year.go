@@ -3,11 +3,13 @@ package handlers
 import (
 	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 
-	"shyft/internal/models"
+	"shyft/internal/recurrence"
 )
 
 // HandleGetShiftScheduleByYear godoc
@@ -20,6 +22,7 @@ import (
 // @Produce json
 // @Security BearerAuth
 // @Param year path string true "Shift Schedule Year"
+// @Param tz query string false "IANA time zone the year is taken in, defaults to the time zone of every schedule"
 // @Success 200 {object} RespondJson "get shifts by year successfully"
 // @Failure 400 {object} RespondJson "cannot get shifts schedule by year due to invalid request body"
 // @Failure 422 {object} RespondJson "cannot get shifts schedule by year due to invalid request body"
@@ -31,16 +34,38 @@ func (ss *ShiftService) HandleGetShiftScheduleByYear(c *gin.Context) (int, inter
 	if year == "" {
 		return http.StatusBadRequest, nil, nil
 	}
+	loc, err := requestLocation(c)
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
 
-	// Step 2: Get shift schedules by year from database
-	var shiftSchedules []models.ShiftSchedule
-	if err := ss.db.Where("deleted_at IS NULL AND year = ?", year).Find(&shiftSchedules).Error; err != nil {
+	// Step 2: Get shift schedules the caller may read by year from database
+	repo, err := ss.readableSchedules(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	shiftSchedules, err := repo.ListByYear(year)
+	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return http.StatusNotFound, nil, errors.New("cannot get shift schedule by year due to not found")
 		}
 		return http.StatusInternalServerError, nil, errors.New("cannot get shift schedule by year due to internal server error")
 	}
 
-	// Step 3: Return shift schedules by year
+	// Step 3: Add the recurrence occurrences of the year, in the requested time zone or else in the time zone
+	// of the schedule, to the stored shifts
+	if yearNumber, err := strconv.Atoi(year); err == nil {
+		for i := range shiftSchedules {
+			yearStart := time.Date(yearNumber, 1, 1, 0, 0, 0, 0, viewLocation(loc, &shiftSchedules[i]))
+			yearEnd := yearStart.AddDate(1, 0, 0)
+			shifts, err := recurrence.WithOccurrences(&shiftSchedules[i], yearStart, yearEnd)
+			if err != nil {
+				return http.StatusInternalServerError, nil, err
+			}
+			shiftSchedules[i].Shifts = shifts
+		}
+	}
+
+	// Step 4: Return shift schedules by year
 	return http.StatusOK, shiftSchedules, nil
 }
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"shyft/internal/models"
+	"shyft/internal/override"
+	"shyft/internal/recurrence"
+)
+
+const (
+	// timelinePeriod is the window of the timeline when no end is given
+	timelinePeriod = 7 * 24 * time.Hour
+	// maxTimelinePeriod bounds the window of the timeline
+	maxTimelinePeriod = 366 * 24 * time.Hour
+)
+
+// HandleGetShiftScheduleTimeline godoc
+// HandleGetShiftScheduleTimeline handles the request to get the shift schedules of a date range
+// @Summary get the shift schedules of a date range
+// @Schemes
+// @Description get the shift schedules overlapping the window, each with only its shifts overlapping the window (stored shifts are filtered in the database, recurrence occurrences and overrides are added)
+// @Tags Shift
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "Start of the window (RFC 3339), defaults to now"
+// @Param to query string false "End of the window (RFC 3339), defaults to 7 days after from, at most 366 days after from"
+// @Param tz query string false "IANA time zone shifts are written in, defaults to the time zone of every schedule"
+// @Success 200 {object} RespondJson "get timeline successfully"
+// @Failure 400 {object} RespondJson "cannot get timeline due to invalid query parameters"
+// @Failure 403 {object} RespondJson "cannot get timeline due to missing permission"
+// @Failure 500 {object} RespondJson "cannot get timeline due to internal server error"
+// @Router /shift-schedules/timeline [get]
+func (ss *ShiftService) HandleGetShiftScheduleTimeline(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get the window and the time zone from query
+	loc, err := requestLocation(c)
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	from, err := optionalTimeQuery(c, "from")
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	to, err := optionalTimeQuery(c, "to")
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	if from == nil {
+		now := time.Now()
+		from = &now
+	}
+	if to == nil {
+		end := from.Add(timelinePeriod)
+		to = &end
+	}
+	if !to.After(*from) {
+		return http.StatusBadRequest, nil, errors.New("to must be after from")
+	}
+	if to.Sub(*from) > maxTimelinePeriod {
+		return http.StatusBadRequest, nil, errors.New("the timeline window cannot be longer than 366 days")
+	}
+
+	// Step 2: Get the shift schedules the caller may read with their shifts overlapping the window
+	repo, err := ss.readableSchedules(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	shiftSchedules, err := repo.ListTimeline(*from, *to)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	// Step 3: Add the recurrence occurrences and the overrides of the window, and mark the holidays
+	overrides, err := ss.overridesOf(shiftSchedules, *from, *to)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+	holidays, err := ss.holidayCalendar(shiftSchedules, *from, *to)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+	for i := range shiftSchedules {
+		shiftSchedule := &shiftSchedules[i]
+		scheduleLoc := shiftSchedule.Location()
+		shifts, err := recurrence.WithOccurrences(shiftSchedule, *from, *to)
+		if err != nil {
+			return http.StatusInternalServerError, nil, err
+		}
+		shifts = override.Apply(shifts, overrides[shiftSchedule.ID], scheduleLoc)
+		shifts = holidays.Mark(shiftSchedule.OrganizationID, shifts, scheduleLoc)
+
+		// Overrides cut shifts into pieces, only the pieces overlapping the window are kept
+		window := models.JSONB{}
+		for _, shift := range shifts {
+			values, ok := shift.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			start, _ := values["start"].(string)
+			end, _ := values["end"].(string)
+			startAt, err := models.ParseShiftTime(start, scheduleLoc)
+			if err != nil {
+				continue
+			}
+			endAt, err := models.ParseShiftTime(end, scheduleLoc)
+			if err != nil || !startAt.Before(*to) || !endAt.After(*from) {
+				continue
+			}
+			window = append(window, inLocation(values, startAt, endAt, viewLocation(loc, shiftSchedule)))
+		}
+		shiftSchedule.Shifts = window
+	}
+
+	// Step 4: Return timeline
+	return http.StatusOK, shiftSchedules, nil
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleGetShiftSwapByID godoc
+// HandleGetShiftSwapByID handles the request to get a shift swap request by id
+// @Summary get a shift swap request by id
+// @Schemes
+// @Description get a shift swap request by id together with its history
+// @Tags Shift Swap
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Shift Swap ID"
+// @Success 200 {object} RespondJson "get shift swap by id successfully"
+// @Failure 403 {object} RespondJson "cannot get shift swap due to missing permission"
+// @Failure 404 {object} RespondJson "cannot get shift swap due to not found"
+// @Failure 500 {object} RespondJson "cannot get shift swap due to internal server error"
+// @Router /shift-swaps/{id} [get]
+func (ss *ShiftService) HandleGetShiftSwapByID(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get swap request id from path and validate
+	id := c.Param("id")
+	if id == "" {
+		return http.StatusBadRequest, nil, nil
+	}
+
+	// Step 2: Get swap request and its shift schedule, the caller must take part in the swap or may read the schedule
+	request, shiftSchedule, code, err := ss.findShiftSwap(c, id)
+	if err != nil {
+		return code, nil, err
+	}
+	if !ss.takesPartInSwap(c, request) {
+		if err := ss.authorize(c, policy.ReadSchedules, shiftSchedule); err != nil {
+			return http.StatusForbidden, nil, err
+		}
+	}
+
+	// Step 3: Return swap request
+	return http.StatusOK, request, nil
+}
+
+// findShiftSwap gets a swap request and its shift schedule from the caller's organization
+func (ss *ShiftService) findShiftSwap(c *gin.Context, id string) (*models.ShiftSwap, *models.ShiftSchedule, int, error) {
+	swaps, err := ss.swapRepository(c)
+	if err != nil {
+		return nil, nil, http.StatusForbidden, err
+	}
+	request, err := swaps.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, http.StatusNotFound, errors.New("shift swap not found")
+		}
+		r, _ := httpErrors.ErrorResponse(err)
+		return nil, nil, r, errors.New("cannot get shift swap due to internal server error")
+	}
+
+	repo, err := ss.scheduleRepository(c)
+	if err != nil {
+		return nil, nil, http.StatusForbidden, err
+	}
+	shiftSchedule, err := repo.FindByID(strconv.FormatUint(uint64(request.ShiftScheduleID), 10))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, http.StatusNotFound, errors.New("shift schedule of the shift swap not found")
+		}
+		r, _ := httpErrors.ErrorResponse(err)
+		return nil, nil, r, errors.New("cannot get shift swap due to internal server error")
+	}
+	return request, shiftSchedule, http.StatusOK, nil
+}
+
+// takesPartInSwap reports whether the caller is the requester or the responder of the swap request
+func (ss *ShiftService) takesPartInSwap(c *gin.Context, request *models.ShiftSwap) bool {
+	claims := claimsFromContext(c)
+	return claims != nil && claims.UserID != 0 &&
+		(uint(claims.UserID) == request.RequesterID || uint(claims.UserID) == request.ResponderID)
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleGetUserByID godoc
+// HandleGetUserByID handles the request to get a user by id
+// @Summary get a user by id
+// @Schemes
+// @Description get a user by id
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} RespondJson "get user by id successfully"
+// @Failure 403 {object} RespondJson "cannot get user by id due to missing permission"
+// @Failure 404 {object} RespondJson "cannot get user by id due to not found"
+// @Failure 500 {object} RespondJson "cannot get user by id due to internal server error"
+// @Router /users/{id} [get]
+func (ss *ShiftService) HandleGetUserByID(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get user id from path and validate
+	id := c.Param("id")
+	if id == "" {
+		return http.StatusBadRequest, nil, nil
+	}
+
+	// Step 2: Get user by id from database
+	user, err := repository.NewUserRepository(ss.db).FindByID(id)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot get user by id due to not found")
+		}
+		return r, i, errors.New("cannot get user by id due to internal server error")
+	}
+
+	// Step 3: Check that the caller may read the user's organization
+	if err := ss.authorizeOrganization(c, policy.ReadUsers, user.OrganizationID); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 4: Return user by id
+	return http.StatusOK, user, nil
+}
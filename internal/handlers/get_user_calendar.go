@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/ical"
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+// Calendar feeds publish the shifts from calendarPast ago until calendarFuture ahead
+const (
+	calendarPast   = 90 * 24 * time.Hour
+	calendarFuture = 365 * 24 * time.Hour
+)
+
+// HandleGetUserCalendar godoc
+// HandleGetUserCalendar handles the request to get the iCalendar feed of the shifts of a user
+// @Summary get the calendar feed of a user
+// @Schemes
+// @Description get the shifts of the user across shift schedules as an RFC 5545 iCalendar feed, authenticated by the calendar token in the URL
+// @Tags Calendar
+// @Produce text/calendar
+// @Param file path string true "User ID followed by .ics, e.g. 12.ics"
+// @Param token query string true "Calendar token"
+// @Param tz query string false "IANA time zone the events are written in, defaults to the time zone of every schedule"
+// @Success 200 {string} string "iCalendar feed"
+// @Failure 401 {object} RespondJson "cannot get calendar due to an invalid token"
+// @Failure 403 {object} RespondJson "cannot get calendar of another user"
+// @Failure 404 {object} RespondJson "cannot get calendar due to not found"
+// @Failure 500 {object} RespondJson "cannot get calendar due to internal server error"
+// @Router /calendar/users/{file} [get]
+func (ss *ShiftService) HandleGetUserCalendar(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get user id from path and the time zone from query
+	id, err := calendarFileID(c.Param("file"))
+	if err != nil {
+		return http.StatusNotFound, nil, err
+	}
+	loc, err := requestLocation(c)
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+
+	// Step 2: Check that the token holder may read the user's shifts, their own or any with the permission
+	claims := claimsFromContext(c)
+	if claims == nil || (claims.UserID != id && ss.policy.Scope(claims.Role, policy.ReadSchedules) != policy.ScopeAny) {
+		return http.StatusForbidden, nil, httpErrors.Forbidden
+	}
+	person, err := repository.NewUserRepository(ss.db).FindByID(strconv.Itoa(id))
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot get calendar due to not found")
+		}
+		return r, i, errors.New("cannot get calendar due to internal server error")
+	}
+
+	// Step 3: Get the shift schedules of the user in the token holder's organization
+	repo, err := ss.scheduleRepository(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	now := time.Now()
+	shiftSchedules, err := repo.VisibleTo(id, person.Mail).ListOverlapping(now.Add(-calendarPast), now.Add(calendarFuture), 0)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	// Step 4: Render the shifts of the user
+	data, err := ss.calendarOf(person.Name, shiftSchedules, loc, func(user models.Contact) bool {
+		return int(user.ID) == id || (person.Mail != "" && strings.EqualFold(user.Mail, person.Mail))
+	})
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	// Step 5: Return calendar
+	return http.StatusOK, data, nil
+}
+
+// calendarOf renders the effective shifts of the shift schedules, of the people keep accepts, as an iCalendar.
+// Events are written in loc, or else in the time zone of their schedule.
+func (ss *ShiftService) calendarOf(name string, shiftSchedules []models.ShiftSchedule, loc *time.Location, keep func(models.Contact) bool) ([]byte, error) {
+	now := time.Now()
+	from, to := now.Add(-calendarPast), now.Add(calendarFuture)
+
+	// Step 1: Get the effective shifts, with their holidays
+	schedules, err := ss.effectiveShifts(shiftSchedules, from, to)
+	if err != nil {
+		return nil, err
+	}
+	holidays, err := ss.holidayCalendar(shiftSchedules, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: Convert the shifts to events with stable uids
+	calendar := ical.Calendar{Name: name}
+	fingerprints := map[string]string{}
+	for _, s := range schedules {
+		scheduleLoc := s.Schedule.Location()
+		for _, entry := range holidays.Mark(s.Schedule.OrganizationID, s.Shifts, scheduleLoc) {
+			values, ok := entry.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			var user models.Contact
+			if value, ok := values["user"].(map[string]interface{}); ok {
+				user = models.ContactFromJSON(value)
+			}
+			if keep != nil && !keep(user) {
+				continue
+			}
+			start, _ := values["start"].(string)
+			end, _ := values["end"].(string)
+			startAt, err := models.ParseShiftTime(start, scheduleLoc)
+			if err != nil {
+				continue
+			}
+			endAt, err := models.ParseShiftTime(end, scheduleLoc)
+			if err != nil || !endAt.After(startAt) || !startAt.Before(to) || !endAt.After(from) {
+				continue
+			}
+
+			event := ical.Event{
+				UID:          eventUID(s.Schedule, values, startAt, fingerprints),
+				Start:        startAt.In(viewLocation(loc, s.Schedule)),
+				End:          endAt.In(viewLocation(loc, s.Schedule)),
+				Summary:      s.Schedule.Alias,
+				LastModified: s.Schedule.UpdatedAt,
+			}
+			if user.Name != "" {
+				event.Summary = fmt.Sprintf("%s: %s", s.Schedule.Alias, user.Name)
+			}
+			if holiday, ok := values["holiday"].(string); ok {
+				event.Description = "Holiday: " + holiday
+			}
+			sum := sha256.Sum256([]byte(fmt.Sprintf("%d|%d|%d|%s|%s|%s", event.Start.Unix(), event.End.Unix(), user.ID, user.Mail, event.Summary, event.Description)))
+			fingerprints[event.UID] = hex.EncodeToString(sum[:])
+			calendar.Events = append(calendar.Events, event)
+		}
+	}
+
+	// Step 3: Bump the sequence of the changed events
+	sequences, err := repository.NewCalendarRepository(ss.db).Sequences(fingerprints)
+	if err != nil {
+		return nil, err
+	}
+	for i := range calendar.Events {
+		calendar.Events[i].Sequence = sequences[calendar.Events[i].UID]
+	}
+	return calendar.Marshal(now), nil
+}
+
+// eventUID returns the uid of a shift entry: overrides and recurrence occurrences have their own, and the
+// pieces of a shift cut by an override are told apart by their start
+func eventUID(schedule *models.ShiftSchedule, values map[string]interface{}, start time.Time, used map[string]string) string {
+	ref := models.ShiftRefOf(values)
+	var uid string
+	switch {
+	case ref.Override != nil:
+		uid = fmt.Sprintf("override-%d@shyft", *ref.Override)
+	case ref.Rule != nil:
+		uid = fmt.Sprintf("occurrence-%d-%d-%d@shyft", schedule.ID, *ref.Rule, start.Unix())
+	case ref.ShiftID != nil:
+		uid = fmt.Sprintf("shift-%d-%d@shyft", schedule.ID, *ref.ShiftID)
+	default:
+		uid = fmt.Sprintf("shift-%d-0@shyft", schedule.ID)
+	}
+	if _, taken := used[uid]; taken {
+		uid = fmt.Sprintf("%s-%d@shyft", strings.TrimSuffix(uid, "@shyft"), start.Unix())
+	}
+	return uid
+}
+
+// calendarFileID reads the id of a feed file name, e.g. 12.ics
+func calendarFileID(file string) (int, error) {
+	id, err := strconv.Atoi(strings.TrimSuffix(file, ".ics"))
+	if !strings.HasSuffix(file, ".ics") || err != nil || id < 1 {
+		return 0, httpErrors.NotFound
+	}
+	return id, nil
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"shyft/internal/policy"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleGetUserSwaps godoc
+// HandleGetUserSwaps handles the request to get the swap requests of a user
+// @Summary get the swap requests of a user
+// @Schemes
+// @Description get the swap requests a user proposed or was asked for, optionally only those in a status
+// @Tags Shift Swap
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param status query string false "Status" Enums(pending, accepted, declined, cancelled, rejected, completed)
+// @Success 200 {object} RespondJson "get shift swaps successfully"
+// @Failure 400 {object} RespondJson "cannot get shift swaps due to invalid user id"
+// @Failure 403 {object} RespondJson "cannot get shift swaps due to missing permission"
+// @Failure 500 {object} RespondJson "cannot get shift swaps due to internal server error"
+// @Router /users/{id}/swaps [get]
+func (ss *ShiftService) HandleGetUserSwaps(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get user id from path and validate
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return http.StatusBadRequest, nil, errors.New("invalid user id")
+	}
+
+	// Step 2: Callers get their own swap requests, others need to read every schedule
+	claims := claimsFromContext(c)
+	if claims == nil || uint64(claims.UserID) != userID {
+		if claims == nil || ss.policy.Scope(claims.Role, policy.ReadSchedules) != policy.ScopeAny {
+			return http.StatusForbidden, nil, httpErrors.Forbidden
+		}
+	}
+
+	// Step 3: Get swap requests from database
+	swaps, err := ss.swapRepository(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	requests, err := swaps.ListByUser(uint(userID), c.Query("status"))
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot get shift swaps due to internal server error")
+	}
+
+	// Step 4: Return swap requests
+	return http.StatusOK, requests, nil
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+const (
+	defaultDeliveryLimit = 50
+	maxDeliveryLimit     = 200
+)
+
+// HandleGetWebhookDeliveries godoc
+// HandleGetWebhookDeliveries handles the request to get the delivery log of a webhook subscription
+// @Summary get deliveries of a webhook
+// @Schemes
+// @Description get the latest deliveries of a webhook subscription, newest first, with their attempts and last response
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Organization ID"
+// @Param webhook_id path string true "Webhook ID"
+// @Param status query string false "pending, delivered or failed"
+// @Param limit query int false "number of deliveries, 50 by default and 200 at most"
+// @Success 200 {object} RespondJson "successfully got webhook deliveries"
+// @Failure 400 {object} RespondJson "cannot get webhook deliveries due to invalid query params"
+// @Failure 403 {object} RespondJson "cannot get webhook deliveries due to missing permission"
+// @Failure 404 {object} RespondJson "cannot get webhook deliveries due to not found"
+// @Failure 500 {object} RespondJson "cannot get webhook deliveries due to internal server error"
+// @Router /organizations/{id}/webhooks/{webhook_id}/deliveries [get]
+func (ss *ShiftService) HandleGetWebhookDeliveries(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get organization and webhook id from path and filters from query
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 1 {
+		return http.StatusBadRequest, nil, httpErrors.BadQueryParams
+	}
+	organizationID := uint(id)
+	webhookID := c.Param("webhook_id")
+	status := c.Query("status")
+	if status != "" && status != models.DeliveryPending && status != models.DeliveryDelivered && status != models.DeliveryFailed {
+		return http.StatusBadRequest, nil, httpErrors.BadQueryParams
+	}
+	limit := defaultDeliveryLimit
+	if c.Query("limit") != "" {
+		limit, err = strconv.Atoi(c.Query("limit"))
+		if err != nil || limit < 1 {
+			return http.StatusBadRequest, nil, httpErrors.BadQueryParams
+		}
+		if limit > maxDeliveryLimit {
+			limit = maxDeliveryLimit
+		}
+	}
+
+	// Step 2: Check that the caller may read the organization
+	if err := ss.authorizeOrganization(c, policy.ReadOrganizations, &organizationID); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 3: Get webhook subscription and its deliveries from database
+	webhooks := repository.NewWebhookRepository(ss.db)
+	subscription, err := webhooks.FindByID(organizationID, webhookID)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot get webhook deliveries due to not found")
+		}
+		return r, i, errors.New("cannot get webhook deliveries due to internal server error")
+	}
+	deliveries, err := webhooks.ListDeliveries(subscription.ID, status, limit)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot get webhook deliveries due to internal server error")
+	}
+
+	// Step 4: Return deliveries
+	return http.StatusOK, deliveries, nil
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/internal/workload"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleGetWorkloadStatistics godoc
+// HandleGetWorkloadStatistics handles the request to get the workload statistics per person
+// @Summary get the workload statistics per person
+// @Schemes
+// @Description get, per person across the shift schedules, the shift hours, the number of shifts, the weekend, holiday and night hours and the deviation from the team average
+// @Tags Shift
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param shift_schedule_id query int false "Shift Schedule ID, defaults to every shift schedule of the organization"
+// @Param organization_id query int false "Organization ID, defaults to the caller's organization"
+// @Param user_id query int false "Only return the statistics of this user"
+// @Param tz query string false "IANA time zone the period is taken in, defaults to Europe/Istanbul"
+// @Param from query string false "Start of the period (RFC 3339), defaults to the start of the current year"
+// @Param to query string false "End of the period (RFC 3339), defaults to one year after from"
+// @Success 200 {object} RespondJson "get workload statistics successfully"
+// @Failure 400 {object} RespondJson "cannot get workload statistics due to invalid query parameters"
+// @Failure 403 {object} RespondJson "cannot get workload statistics due to missing permission"
+// @Failure 404 {object} RespondJson "cannot get workload statistics due to not found"
+// @Failure 500 {object} RespondJson "cannot get workload statistics due to internal server error"
+// @Router /statistics/workload [get]
+func (ss *ShiftService) HandleGetWorkloadStatistics(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get the time zone, the period and the user from query
+	loc, err := requestLocation(c)
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	from, err := optionalTimeQuery(c, "from")
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	to, err := optionalTimeQuery(c, "to")
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	if from == nil {
+		now := time.Now().In(viewLocation(loc, nil))
+		start := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location())
+		from = &start
+	}
+	if to == nil {
+		end := from.AddDate(1, 0, 0)
+		to = &end
+	}
+	if !to.After(*from) {
+		return http.StatusBadRequest, nil, errors.New("to must be after from")
+	}
+	var userID uint
+	if value := c.Query("user_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return http.StatusBadRequest, nil, errors.New("invalid user_id")
+		}
+		userID = uint(id)
+	}
+
+	// Step 2: Get the shift schedules the caller may read, only the caller's organization can be asked for
+	if value := c.Query("organization_id"); value != "" {
+		organizationID, err := strconv.Atoi(value)
+		if err != nil {
+			return http.StatusBadRequest, nil, errors.New("invalid organization_id")
+		}
+		if claims := claimsFromContext(c); claims == nil || claims.OrganizationID != organizationID {
+			return http.StatusForbidden, nil, httpErrors.Forbidden
+		}
+	}
+	repo, err := ss.readableSchedules(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	var shiftSchedules []models.ShiftSchedule
+	if id := c.Query("shift_schedule_id"); id != "" {
+		shiftSchedule, err := repo.FindByID(id)
+		if err != nil {
+			r, i := httpErrors.ErrorResponse(err)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return http.StatusNotFound, i, errors.New("cannot get workload statistics due to not found")
+			}
+			return r, i, errors.New("cannot get workload statistics due to internal server error")
+		}
+		if err := ss.authorize(c, policy.ReadSchedules, shiftSchedule); err != nil {
+			return http.StatusForbidden, nil, err
+		}
+		shiftSchedules = append(shiftSchedules, *shiftSchedule)
+	} else {
+		shiftSchedules, err = repo.ListOverlapping(*from, *to, 0)
+		if err != nil {
+			return http.StatusInternalServerError, nil, err
+		}
+	}
+
+	// Step 3: Sum up the effective shifts per person
+	report, err := ss.workloadReport(shiftSchedules, *from, *to, viewLocation(loc, nil))
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+	if userID != 0 {
+		people := []models.Workload{}
+		for _, w := range report.People {
+			if w.User.ID == userID {
+				people = append(people, w)
+			}
+		}
+		report.People = people
+	}
+
+	// Step 4: Return workload statistics
+	return http.StatusOK, report, nil
+}
+
+// workloadReport sums up the effective shifts of the shift schedules in [from, to) per person, the period is reported in loc
+func (ss *ShiftService) workloadReport(shiftSchedules []models.ShiftSchedule, from, to time.Time, loc *time.Location) (models.WorkloadReport, error) {
+	schedules, err := ss.effectiveShifts(shiftSchedules, from, to)
+	if err != nil {
+		return models.WorkloadReport{}, err
+	}
+	holidays, err := ss.holidayCalendar(shiftSchedules, from, to)
+	if err != nil {
+		return models.WorkloadReport{}, err
+	}
+	return workload.Compute(schedules, from, to, loc, workload.Options{IsHoliday: holidays.IsHoliday}), nil
+}
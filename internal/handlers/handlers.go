@@ -2,8 +2,13 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"shyft/config"
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/internal/swap"
+	"shyft/internal/workflow"
 	"shyft/pkg/logger"
 	"shyft/pkg/metric"
 
@@ -24,6 +29,8 @@ type ShiftService struct {
 	cache        *redis.Client
 	cacheContext context.Context
 	db           *gorm.DB
+	policy       *policy.Policy
+	metrics      metric.Metrics
 	// s3sess       *session.Session
 }
 
@@ -32,6 +39,7 @@ func NewShiftService(
 	cache *redis.Client,
 	cacheContext context.Context,
 	db *gorm.DB,
+	policy *policy.Policy,
 	// s3sess *session.Session,
 ) *ShiftService {
 	return &ShiftService{
@@ -39,6 +47,7 @@ func NewShiftService(
 		cache:        cache,
 		cacheContext: cacheContext,
 		db:           db,
+		policy:       policy,
 		// s3sess:       s3sess,
 	}
 }
@@ -47,6 +56,12 @@ type RespondJson struct {
 	Status  bool        `json:"status"`
 	Intent  string      `json:"intent"`
 	Message interface{} `json:"message"`
+	Details interface{} `json:"details,omitempty"` // structured details of an error, e.g. conflicts
+}
+
+// detailedError is an error carrying structured details for the response
+type detailedError interface {
+	Details() interface{}
 }
 
 func respondJson(ctx *gin.Context, code int, intent string, message interface{}, err error) {
@@ -57,14 +72,35 @@ func respondJson(ctx *gin.Context, code int, intent string, message interface{},
 			Message: message,
 		})
 	} else {
+		var details interface{}
+		var detailed detailedError
+		if errors.As(err, &detailed) {
+			details = detailed.Details()
+		}
 		ctx.JSON(code, RespondJson{
 			Status:  false,
 			Intent:  intent,
 			Message: err.Error(),
+			Details: details,
 		})
 	}
 }
 
+// respondCalendar writes an iCalendar feed, errors are written as JSON
+func respondCalendar(ctx *gin.Context, code int, intent string, data interface{}, err error) {
+	body, ok := data.([]byte)
+	if err != nil || !ok {
+		respondJson(ctx, code, intent, data, err)
+		return
+	}
+	ctx.Data(code, "text/calendar; charset=utf-8", body)
+}
+
+// Metrics returns the metrics created by InitRouter, nil before or when they cannot be created
+func (bs *ShiftService) Metrics() metric.Metrics {
+	return bs.metrics
+}
+
 func (bs *ShiftService) InitRouter(r *gin.Engine) {
 	// Prometheus metrics
 	metrics, err := metric.CreateMetrics(config.C.Metric.Url, config.C.Metric.Service)
@@ -72,11 +108,43 @@ func (bs *ShiftService) InitRouter(r *gin.Engine) {
 		logger.CLogger.Warn("Cannot create metrics: ", err)
 	} else {
 		logger.CLogger.Infof("Metrics server running. Metrics: %+v", metrics)
+		bs.metrics = metrics
+		go bs.reportUncoveredHours()
 	}
 
 	// -- my service routes (group)
-	v1 := r.Group(API_PREFIX)
-	health := v1.Group("/health")
+	root := r.Group(API_PREFIX)
+	health := root.Group("/health")
+
+	// -- authenticated routes
+	v1 := root.Group("", bs.AuthMiddleware())
+
+	// -- calendar feeds, authenticated by the calendar token in the URL
+	feeds := root.Group("/calendar", bs.CalendarTokenMiddleware())
+
+	// Get calendar feed of a user
+	feeds.GET("/users/:file", func(ctx *gin.Context) {
+		code, data, err := bs.HandleGetUserCalendar(ctx)
+		respondCalendar(ctx, code, RN_PREFIX+"/calendar/users/:file", data, err)
+	})
+
+	// Get calendar feed of a shift schedule
+	feeds.GET("/schedules/:file", func(ctx *gin.Context) {
+		code, data, err := bs.HandleGetShiftScheduleCalendar(ctx)
+		respondCalendar(ctx, code, RN_PREFIX+"/calendar/schedules/:file", data, err)
+	})
+
+	// Create calendar feed token of the caller
+	v1.POST("/calendar/token", func(ctx *gin.Context) {
+		code, data, err := bs.HandleCreateCalendarToken(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/calendar/token", data, err)
+	})
+
+	// Revoke calendar feed token of the caller
+	v1.DELETE("/calendar/token", func(ctx *gin.Context) {
+		code, data, err := bs.HandleDeleteCalendarToken(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/calendar/token", data, err)
+	})
 
 	// Get all shift schedules
 	v1.GET("/shift-schedules", func(ctx *gin.Context) {
@@ -102,6 +170,12 @@ func (bs *ShiftService) InitRouter(r *gin.Engine) {
 		respondJson(ctx, code, RN_PREFIX+"/shift-schedules/week", data, err)
 	})
 
+	// Get shift schedules timeline
+	v1.GET("/shift-schedules/timeline", func(ctx *gin.Context) {
+		code, data, err := bs.HandleGetShiftScheduleTimeline(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/shift-schedules/timeline", data, err)
+	})
+
 	// Get Get shift schedule by current week with pagination
 	v1.GET("/shift-schedules/week/paginated", func(ctx *gin.Context) {
 		code, data, err := bs.HandleGetShiftScheduleByWeekWithPagination(ctx)
@@ -132,12 +206,366 @@ func (bs *ShiftService) InitRouter(r *gin.Engine) {
 		respondJson(ctx, code, RN_PREFIX+"/shift-schedules/:id", data, err)
 	})
 
+	// Generate shift schedule rotation
+	v1.POST("/shift-schedules/:id/generate", func(ctx *gin.Context) {
+		code, data, err := bs.HandleGenerateShiftSchedule(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/shift-schedules/:id/generate", data, err)
+	})
+
+	// Get shift schedule overrides
+	v1.GET("/shift-schedules/:id/overrides", func(ctx *gin.Context) {
+		code, data, err := bs.HandleGetShiftOverrides(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/shift-schedules/:id/overrides", data, err)
+	})
+
+	// Create shift schedule override
+	v1.POST("/shift-schedules/:id/overrides", func(ctx *gin.Context) {
+		code, data, err := bs.HandleCreateShiftOverride(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/shift-schedules/:id/overrides", data, err)
+	})
+
+	// Delete shift schedule override (Soft delete)
+	v1.DELETE("/shift-schedules/:id/overrides/:override_id", func(ctx *gin.Context) {
+		code, data, err := bs.HandleDeleteShiftOverride(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/shift-schedules/:id/overrides/:override_id", data, err)
+	})
+
+	// Get shift schedule swap requests
+	v1.GET("/shift-schedules/:id/swaps", func(ctx *gin.Context) {
+		code, data, err := bs.HandleGetShiftScheduleSwaps(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/shift-schedules/:id/swaps", data, err)
+	})
+
+	// Propose shift swap
+	v1.POST("/shift-schedules/:id/swaps", func(ctx *gin.Context) {
+		code, data, err := bs.HandleCreateShiftSwap(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/shift-schedules/:id/swaps", data, err)
+	})
+
+	// Submit shift schedule for approval
+	v1.POST("/shift-schedules/:id/submit", func(ctx *gin.Context) {
+		code, data, err := bs.HandleTransitionShiftSchedule(ctx, workflow.ActionSubmit)
+		respondJson(ctx, code, RN_PREFIX+"/shift-schedules/:id/submit", data, err)
+	})
+
+	// Approve shift schedule
+	v1.POST("/shift-schedules/:id/approve", func(ctx *gin.Context) {
+		code, data, err := bs.HandleTransitionShiftSchedule(ctx, workflow.ActionApprove)
+		respondJson(ctx, code, RN_PREFIX+"/shift-schedules/:id/approve", data, err)
+	})
+
+	// Reject shift schedule (with a reason)
+	v1.POST("/shift-schedules/:id/reject", func(ctx *gin.Context) {
+		code, data, err := bs.HandleTransitionShiftSchedule(ctx, workflow.ActionReject)
+		respondJson(ctx, code, RN_PREFIX+"/shift-schedules/:id/reject", data, err)
+	})
+
+	// Reopen approved or rejected shift schedule
+	v1.POST("/shift-schedules/:id/reopen", func(ctx *gin.Context) {
+		code, data, err := bs.HandleTransitionShiftSchedule(ctx, workflow.ActionReopen)
+		respondJson(ctx, code, RN_PREFIX+"/shift-schedules/:id/reopen", data, err)
+	})
+
+	// Get shifts of a shift schedule assigned to people on leave
+	v1.GET("/shift-schedules/:id/leave-conflicts", func(ctx *gin.Context) {
+		code, data, err := bs.HandleGetShiftScheduleLeaveConflicts(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/shift-schedules/:id/leave-conflicts", data, err)
+	})
+
 	// Restore shift schedule
 	v1.PATCH("/shift-schedules/:id/restore", func(ctx *gin.Context) {
 		code, data, err := bs.HandleRestoreShiftSchedule(ctx)
 		respondJson(ctx, code, RN_PREFIX+"/shift-schedules/:id/restore", data, err)
 	})
 
+	// Get the person on call per shift schedule
+	v1.GET("/on-call", func(ctx *gin.Context) {
+		code, data, err := bs.HandleGetOnCall(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/on-call", data, err)
+	})
+
+	// Get shift swap request by id
+	v1.GET("/shift-swaps/:id", func(ctx *gin.Context) {
+		code, data, err := bs.HandleGetShiftSwapByID(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/shift-swaps/:id", data, err)
+	})
+
+	// Accept shift swap (responder)
+	v1.POST("/shift-swaps/:id/accept", func(ctx *gin.Context) {
+		code, data, err := bs.HandleTransitionShiftSwap(ctx, swap.ActionAccept)
+		respondJson(ctx, code, RN_PREFIX+"/shift-swaps/:id/accept", data, err)
+	})
+
+	// Decline shift swap (responder)
+	v1.POST("/shift-swaps/:id/decline", func(ctx *gin.Context) {
+		code, data, err := bs.HandleTransitionShiftSwap(ctx, swap.ActionDecline)
+		respondJson(ctx, code, RN_PREFIX+"/shift-swaps/:id/decline", data, err)
+	})
+
+	// Cancel shift swap (requester)
+	v1.POST("/shift-swaps/:id/cancel", func(ctx *gin.Context) {
+		code, data, err := bs.HandleTransitionShiftSwap(ctx, swap.ActionCancel)
+		respondJson(ctx, code, RN_PREFIX+"/shift-swaps/:id/cancel", data, err)
+	})
+
+	// Approve shift swap (manager)
+	v1.POST("/shift-swaps/:id/approve", func(ctx *gin.Context) {
+		code, data, err := bs.HandleTransitionShiftSwap(ctx, swap.ActionApprove)
+		respondJson(ctx, code, RN_PREFIX+"/shift-swaps/:id/approve", data, err)
+	})
+
+	// Reject shift swap (manager)
+	v1.POST("/shift-swaps/:id/reject", func(ctx *gin.Context) {
+		code, data, err := bs.HandleTransitionShiftSwap(ctx, swap.ActionReject)
+		respondJson(ctx, code, RN_PREFIX+"/shift-swaps/:id/reject", data, err)
+	})
+
+	// Get conflicts of the shift schedules
+	v1.GET("/conflicts", func(ctx *gin.Context) {
+		code, data, err := bs.HandleGetConflicts(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/conflicts", data, err)
+	})
+
+	// Get coverage gaps of the shift schedules
+	v1.GET("/coverage-gaps", func(ctx *gin.Context) {
+		code, data, err := bs.HandleGetCoverageGaps(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/coverage-gaps", data, err)
+	})
+
+	// Get workload statistics per person
+	v1.GET("/statistics/workload", func(ctx *gin.Context) {
+		code, data, err := bs.HandleGetWorkloadStatistics(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/statistics/workload", data, err)
+	})
+
+	// Get all leaves
+	v1.GET("/leaves", func(ctx *gin.Context) {
+		code, data, err := bs.HandleGetAllLeaves(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/leaves", data, err)
+	})
+
+	// Get leave by id
+	v1.GET("/leaves/:id", func(ctx *gin.Context) {
+		code, data, err := bs.HandleGetLeaveByID(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/leaves/:id", data, err)
+	})
+
+	// Request leave
+	v1.POST("/leaves", func(ctx *gin.Context) {
+		code, data, err := bs.HandleCreateLeave(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/leaves", data, err)
+	})
+
+	// Approve leave
+	v1.POST("/leaves/:id/approve", func(ctx *gin.Context) {
+		code, data, err := bs.HandleDecideLeave(ctx, models.LeaveApproved)
+		respondJson(ctx, code, RN_PREFIX+"/leaves/:id/approve", data, err)
+	})
+
+	// Reject leave
+	v1.POST("/leaves/:id/reject", func(ctx *gin.Context) {
+		code, data, err := bs.HandleDecideLeave(ctx, models.LeaveRejected)
+		respondJson(ctx, code, RN_PREFIX+"/leaves/:id/reject", data, err)
+	})
+
+	// Delete leave (Soft delete)
+	v1.DELETE("/leaves/:id", func(ctx *gin.Context) {
+		code, data, err := bs.HandleDeleteLeave(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/leaves/:id", data, err)
+	})
+
+	// Get all users
+	v1.GET("/users", func(ctx *gin.Context) {
+		code, data, err := bs.HandleGetAllUsers(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/users", data, err)
+	})
+
+	// Get user by id
+	v1.GET("/users/:id", func(ctx *gin.Context) {
+		code, data, err := bs.HandleGetUserByID(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/users/:id", data, err)
+	})
+
+	// Create user
+	v1.POST("/users", func(ctx *gin.Context) {
+		code, data, err := bs.HandleCreateUser(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/users", data, err)
+	})
+
+	// Update user
+	v1.PUT("/users/:id", func(ctx *gin.Context) {
+		code, data, err := bs.HandleUpdateUser(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/users/:id", data, err)
+	})
+
+	// Delete user (Soft delete)
+	v1.DELETE("/users/:id", func(ctx *gin.Context) {
+		code, data, err := bs.HandleDeleteUser(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/users/:id", data, err)
+	})
+
+	// Restore user
+	v1.PATCH("/users/:id/restore", func(ctx *gin.Context) {
+		code, data, err := bs.HandleRestoreUser(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/users/:id/restore", data, err)
+	})
+
+	// Get swap requests of a user
+	v1.GET("/users/:id/swaps", func(ctx *gin.Context) {
+		code, data, err := bs.HandleGetUserSwaps(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/users/:id/swaps", data, err)
+	})
+
+	// Get all organizations
+	v1.GET("/organizations", func(ctx *gin.Context) {
+		code, data, err := bs.HandleGetAllOrganizations(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/organizations", data, err)
+	})
+
+	// Get organization by id
+	v1.GET("/organizations/:id", func(ctx *gin.Context) {
+		code, data, err := bs.HandleGetOrganizationByID(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/organizations/:id", data, err)
+	})
+
+	// Get shift schedules owned by an organization
+	v1.GET("/organizations/:id/shift-schedules", func(ctx *gin.Context) {
+		code, data, err := bs.HandleGetOrganizationShiftSchedules(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/organizations/:id/shift-schedules", data, err)
+	})
+
+	// Create organization
+	v1.POST("/organizations", func(ctx *gin.Context) {
+		code, data, err := bs.HandleCreateOrganization(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/organizations", data, err)
+	})
+
+	// Update organization
+	v1.PUT("/organizations/:id", func(ctx *gin.Context) {
+		code, data, err := bs.HandleUpdateOrganization(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/organizations/:id", data, err)
+	})
+
+	// Delete organization (Soft delete)
+	v1.DELETE("/organizations/:id", func(ctx *gin.Context) {
+		code, data, err := bs.HandleDeleteOrganization(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/organizations/:id", data, err)
+	})
+
+	// Restore organization
+	v1.PATCH("/organizations/:id/restore", func(ctx *gin.Context) {
+		code, data, err := bs.HandleRestoreOrganization(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/organizations/:id/restore", data, err)
+	})
+
+	// Get holidays of an organization
+	v1.GET("/organizations/:id/holidays", func(ctx *gin.Context) {
+		code, data, err := bs.HandleGetOrganizationHolidays(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/organizations/:id/holidays", data, err)
+	})
+
+	// Add a holiday to an organization
+	v1.POST("/organizations/:id/holidays", func(ctx *gin.Context) {
+		code, data, err := bs.HandleCreateOrganizationHoliday(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/organizations/:id/holidays", data, err)
+	})
+
+	// Import holidays of an organization from an iCalendar or CSV file
+	v1.POST("/organizations/:id/holidays/import", func(ctx *gin.Context) {
+		code, data, err := bs.HandleImportOrganizationHolidays(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/organizations/:id/holidays/import", data, err)
+	})
+
+	// Delete a holiday of an organization
+	v1.DELETE("/organizations/:id/holidays/:holiday_id", func(ctx *gin.Context) {
+		code, data, err := bs.HandleDeleteOrganizationHoliday(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/organizations/:id/holidays/:holiday_id", data, err)
+	})
+
+	// Get webhook subscriptions of an organization
+	v1.GET("/organizations/:id/webhooks", func(ctx *gin.Context) {
+		code, data, err := bs.HandleGetOrganizationWebhooks(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/organizations/:id/webhooks", data, err)
+	})
+
+	// Subscribe a webhook to the events of an organization
+	v1.POST("/organizations/:id/webhooks", func(ctx *gin.Context) {
+		code, data, err := bs.HandleCreateOrganizationWebhook(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/organizations/:id/webhooks", data, err)
+	})
+
+	// Update a webhook subscription of an organization
+	v1.PUT("/organizations/:id/webhooks/:webhook_id", func(ctx *gin.Context) {
+		code, data, err := bs.HandleUpdateOrganizationWebhook(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/organizations/:id/webhooks/:webhook_id", data, err)
+	})
+
+	// Delete a webhook subscription of an organization
+	v1.DELETE("/organizations/:id/webhooks/:webhook_id", func(ctx *gin.Context) {
+		code, data, err := bs.HandleDeleteOrganizationWebhook(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/organizations/:id/webhooks/:webhook_id", data, err)
+	})
+
+	// Send a test event to a webhook subscription
+	v1.POST("/organizations/:id/webhooks/:webhook_id/ping", func(ctx *gin.Context) {
+		code, data, err := bs.HandlePingOrganizationWebhook(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/organizations/:id/webhooks/:webhook_id/ping", data, err)
+	})
+
+	// Get the delivery log of a webhook subscription
+	v1.GET("/organizations/:id/webhooks/:webhook_id/deliveries", func(ctx *gin.Context) {
+		code, data, err := bs.HandleGetWebhookDeliveries(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/organizations/:id/webhooks/:webhook_id/deliveries", data, err)
+	})
+
+	// Deliver the event of a webhook delivery again
+	v1.POST("/organizations/:id/webhooks/:webhook_id/deliveries/:delivery_id/redeliver", func(ctx *gin.Context) {
+		code, data, err := bs.HandleRedeliverWebhookDelivery(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/organizations/:id/webhooks/:webhook_id/deliveries/:delivery_id/redeliver", data, err)
+	})
+
+	// Get all managers
+	v1.GET("/managers", func(ctx *gin.Context) {
+		code, data, err := bs.HandleGetAllManagers(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/managers", data, err)
+	})
+
+	// Get manager by id
+	v1.GET("/managers/:id", func(ctx *gin.Context) {
+		code, data, err := bs.HandleGetManagerByID(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/managers/:id", data, err)
+	})
+
+	// Get shift schedules owned by a manager
+	v1.GET("/managers/:id/shift-schedules", func(ctx *gin.Context) {
+		code, data, err := bs.HandleGetManagerShiftSchedules(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/managers/:id/shift-schedules", data, err)
+	})
+
+	// Create manager
+	v1.POST("/managers", func(ctx *gin.Context) {
+		code, data, err := bs.HandleCreateManager(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/managers", data, err)
+	})
+
+	// Update manager
+	v1.PUT("/managers/:id", func(ctx *gin.Context) {
+		code, data, err := bs.HandleUpdateManager(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/managers/:id", data, err)
+	})
+
+	// Delete manager (Soft delete)
+	v1.DELETE("/managers/:id", func(ctx *gin.Context) {
+		code, data, err := bs.HandleDeleteManager(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/managers/:id", data, err)
+	})
+
+	// Restore manager
+	v1.PATCH("/managers/:id/restore", func(ctx *gin.Context) {
+		code, data, err := bs.HandleRestoreManager(ctx)
+		respondJson(ctx, code, RN_PREFIX+"/managers/:id/restore", data, err)
+	})
+
 	// Health check
 	health.GET("", func(ctx *gin.Context) {
 		ctx.JSON(http.StatusOK, gin.H{
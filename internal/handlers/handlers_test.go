@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/internal/testutil"
+)
+
+// newTestService returns a shift service of the default roles on a database whose statements are checked by
+// the returned mock
+func newTestService(t *testing.T) (*ShiftService, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock := testutil.MockDB(t)
+	return NewShiftService(nil, nil, nil, db, policy.New(nil)), mock
+}
+
+// newTestContext returns the context of a request of the caller with the JSON body and the path parameters
+func newTestContext(claims *models.Claims, body string, params ...gin.Param) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = params
+	if claims != nil {
+		c.Set(ClaimsContextKey, claims)
+	}
+	return c
+}
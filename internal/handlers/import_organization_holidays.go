@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"shyft/internal/holiday"
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleImportOrganizationHolidays godoc
+// HandleImportOrganizationHolidays handles the request to load the holiday calendar of an organization from a file
+// @Summary import the holidays of an organization
+// @Schemes
+// @Description load public holidays from an iCalendar (.ics) or CSV (`date,name` rows) file, sent as the `file` form field or as the request body. Holidays of days already in the calendar are renamed.
+// @Tags Organization
+// @Accept multipart/form-data,text/calendar,text/csv
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Organization ID"
+// @Param format query string false "File format, defaults to the file extension or content type" Enums(ics, csv)
+// @Param file formData file false "holiday file"
+// @Success 200 {object} RespondJson "successfully imported holidays"
+// @Failure 400 {object} RespondJson "cannot import holidays due to invalid file"
+// @Failure 403 {object} RespondJson "cannot import holidays due to missing permission"
+// @Failure 500 {object} RespondJson "cannot import holidays due to internal server error"
+// @Router /organizations/{id}/holidays/import [post]
+func (ss *ShiftService) HandleImportOrganizationHolidays(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get organization id from path and check that the caller may update the organization
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 1 {
+		return http.StatusBadRequest, nil, httpErrors.BadQueryParams
+	}
+	organizationID := uint(id)
+	if err := ss.authorizeOrganization(c, policy.UpdateOrganizations, &organizationID); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 2: Get the file from the form or the request body, and its format
+	format := strings.ToLower(c.Query("format"))
+	var body io.Reader = c.Request.Body
+	if file, header, err := c.Request.FormFile("file"); err == nil {
+		defer file.Close()
+		body = file
+		if format == "" {
+			format = strings.TrimPrefix(strings.ToLower(filepath.Ext(header.Filename)), ".")
+		}
+	}
+	if format == "" {
+		switch contentType := c.ContentType(); {
+		case strings.Contains(contentType, "calendar"):
+			format = "ics"
+		case strings.Contains(contentType, "csv"):
+			format = "csv"
+		}
+	}
+
+	// Step 3: Parse the holidays
+	var holidays []models.Holiday
+	switch format {
+	case "ics", "ical", "ifb", "icalendar":
+		holidays, err = holiday.ParseICS(body)
+	case "csv":
+		holidays, err = holiday.ParseCSV(body)
+	default:
+		err = holiday.ErrInvalidFile
+	}
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	for i := range holidays {
+		holidays[i].OrganizationID = organizationID
+	}
+
+	// Step 4: Save holidays to database
+	if err := repository.NewHolidayRepository(ss.db).Save(holidays); err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot import holidays due to internal server error")
+	}
+
+	// Step 5: Return imported holidays
+	return http.StatusOK, holidays, nil
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/internal/webhook"
+	"shyft/pkg/httpErrors"
+)
+
+// HandlePingOrganizationWebhook godoc
+// HandlePingOrganizationWebhook handles the request to send a test event to a webhook subscription
+// @Summary ping a webhook of an organization
+// @Schemes
+// @Description queue a signed "ping" event to the webhook subscription, to check the receiver and its signature verification
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Organization ID"
+// @Param webhook_id path string true "Webhook ID"
+// @Success 200 {object} RespondJson "successfully queued webhook ping"
+// @Failure 403 {object} RespondJson "cannot ping webhook due to missing permission"
+// @Failure 404 {object} RespondJson "cannot ping webhook due to not found"
+// @Failure 500 {object} RespondJson "cannot ping webhook due to internal server error"
+// @Router /organizations/{id}/webhooks/{webhook_id}/ping [post]
+func (ss *ShiftService) HandlePingOrganizationWebhook(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get organization and webhook id from path
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 1 {
+		return http.StatusBadRequest, nil, httpErrors.BadQueryParams
+	}
+	organizationID := uint(id)
+	webhookID := c.Param("webhook_id")
+
+	// Step 2: Check that the caller may update the organization
+	if err := ss.authorizeOrganization(c, policy.UpdateOrganizations, &organizationID); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 3: Get webhook subscription from database
+	webhooks := repository.NewWebhookRepository(ss.db)
+	subscription, err := webhooks.FindByID(organizationID, webhookID)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot ping webhook due to not found")
+		}
+		return r, i, errors.New("cannot ping webhook due to internal server error")
+	}
+
+	// Step 4: Queue the ping delivery
+	delivery, err := webhook.Ping(subscription)
+	if err != nil {
+		return http.StatusInternalServerError, nil, errors.New("cannot ping webhook due to internal server error")
+	}
+	deliveries := []models.WebhookDelivery{*delivery}
+	if err := webhooks.Enqueue(deliveries); err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot ping webhook due to internal server error")
+	}
+
+	// Step 5: Return the delivery
+	return http.StatusOK, deliveries[0], nil
+}
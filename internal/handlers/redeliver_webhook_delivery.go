@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleRedeliverWebhookDelivery godoc
+// HandleRedeliverWebhookDelivery handles the request to deliver the payload of a past delivery again
+// @Summary redeliver a webhook delivery
+// @Schemes
+// @Description queue a new delivery of the same event to the webhook subscription, attempted right away with a new delivery id and the same event id
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Organization ID"
+// @Param webhook_id path string true "Webhook ID"
+// @Param delivery_id path string true "Delivery ID"
+// @Success 200 {object} RespondJson "successfully queued webhook redelivery"
+// @Failure 403 {object} RespondJson "cannot redeliver webhook delivery due to missing permission"
+// @Failure 404 {object} RespondJson "cannot redeliver webhook delivery due to not found"
+// @Failure 500 {object} RespondJson "cannot redeliver webhook delivery due to internal server error"
+// @Router /organizations/{id}/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver [post]
+func (ss *ShiftService) HandleRedeliverWebhookDelivery(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get organization, webhook and delivery id from path
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 1 {
+		return http.StatusBadRequest, nil, httpErrors.BadQueryParams
+	}
+	organizationID := uint(id)
+	webhookID := c.Param("webhook_id")
+	deliveryID := c.Param("delivery_id")
+
+	// Step 2: Check that the caller may update the organization
+	if err := ss.authorizeOrganization(c, policy.UpdateOrganizations, &organizationID); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 3: Get webhook subscription and delivery from database
+	webhooks := repository.NewWebhookRepository(ss.db)
+	subscription, err := webhooks.FindByID(organizationID, webhookID)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot redeliver webhook delivery due to not found")
+		}
+		return r, i, errors.New("cannot redeliver webhook delivery due to internal server error")
+	}
+	delivery, err := webhooks.FindDelivery(subscription.ID, deliveryID)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot redeliver webhook delivery due to not found")
+		}
+		return r, i, errors.New("cannot redeliver webhook delivery due to internal server error")
+	}
+
+	// Step 4: Queue the redelivery
+	redelivery, err := webhooks.Redeliver(delivery)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot redeliver webhook delivery due to internal server error")
+	}
+
+	// Step 5: Return the new delivery
+	return http.StatusOK, redelivery, nil
+}
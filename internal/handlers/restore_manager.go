@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleRestoreManager godoc
+// HandleRestoreManager handles the request to restore a manager
+// @Summary restore a manager
+// @Schemes
+// @Description restore a deleted manager
+// @Tags Manager
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Manager ID"
+// @Success 200 {object} RespondJson "successfully restored manager"
+// @Failure 403 {object} RespondJson "cannot restore manager due to missing permission"
+// @Failure 404 {object} RespondJson "cannot restore manager due to not found"
+// @Failure 500 {object} RespondJson "cannot restore manager due to internal server error"
+// @Router /managers/{id}/restore [patch]
+func (ss *ShiftService) HandleRestoreManager(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get manager id from path and validate
+	id := c.Param("id")
+	if id == "" {
+		return http.StatusBadRequest, nil, nil
+	}
+
+	// Step 2: Get manager and check that the caller may restore it
+	repo := repository.NewManagerRepository(ss.db)
+	manager, err := repo.FindByIDWithDeleted(id)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot restore manager due to not found")
+		}
+		return r, i, errors.New("cannot restore manager due to internal server error")
+	}
+	if err := ss.authorizeOrganization(c, policy.RestoreManagers, manager.OrganizationID); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 3: Restore manager in database
+	if err := repo.Restore(manager); err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot restore manager due to internal server error")
+	}
+
+	// Step 4: Return result
+	return http.StatusOK, "Manager Successfully Restored", nil
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleRestoreOrganization godoc
+// HandleRestoreOrganization handles the request to restore an organization
+// @Summary restore an organization
+// @Schemes
+// @Description restore a deleted organization
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Organization ID"
+// @Success 200 {object} RespondJson "successfully restored organization"
+// @Failure 403 {object} RespondJson "cannot restore organization due to missing permission"
+// @Failure 404 {object} RespondJson "cannot restore organization due to not found"
+// @Failure 500 {object} RespondJson "cannot restore organization due to internal server error"
+// @Router /organizations/{id}/restore [patch]
+func (ss *ShiftService) HandleRestoreOrganization(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get organization id from path and validate
+	id := c.Param("id")
+	if id == "" {
+		return http.StatusBadRequest, nil, nil
+	}
+
+	// Step 2: Get organization and check that the caller may restore it
+	repo := repository.NewOrganizationRepository(ss.db)
+	organization, err := repo.FindByIDWithDeleted(id)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot restore organization due to not found")
+		}
+		return r, i, errors.New("cannot restore organization due to internal server error")
+	}
+	if err := ss.authorizeOrganization(c, policy.RestoreOrganizations, &organization.ID); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 3: Restore organization in database
+	if err := repo.Restore(organization); err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot restore organization due to internal server error")
+	}
+
+	// Step 4: Return result
+	return http.StatusOK, "Organization Successfully Restored", nil
+}
@@ -7,7 +7,8 @@ import (
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 
-	"shyft/internal/models"
+	"shyft/internal/events"
+	"shyft/internal/policy"
 	"shyft/pkg/httpErrors"
 )
 
@@ -33,9 +34,26 @@ func (ss *ShiftService) HandleRestoreShiftSchedule(c *gin.Context) (int, interfa
 		return http.StatusBadRequest, nil, nil
 	}
 
-	// Step 2: Restore shift schedule by id from database
-	var shiftSchedule models.ShiftSchedule
-	if err := ss.db.Unscoped().Model(shiftSchedule).Where("id = ?", id).Update("deleted_at", nil).Error; err != nil {
+	// Step 2: Get shift schedule and check that the caller may restore it
+	repo, err := ss.scheduleRepository(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	shiftSchedule, err := repo.FindByIDWithDeleted(id)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot restore shift schedule due to not found")
+		}
+		return r, i, errors.New("cannot restore shift schedule due to internal server error")
+	}
+	if err := ss.authorize(c, policy.RestoreSchedules, shiftSchedule); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 3: Restore shift schedule by id from database
+	before := shiftSchedule.Clone()
+	if err := repo.Restore(shiftSchedule, ss.event(c, events.ShiftScheduleRestored, before)); err != nil {
 		r, i := httpErrors.ErrorResponse(err)
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return r, i, errors.New("cannot restore shift schedule due to not found")
@@ -43,6 +61,6 @@ func (ss *ShiftService) HandleRestoreShiftSchedule(c *gin.Context) (int, interfa
 		return r, i, errors.New("cannot restore shift schedule due to internal server error")
 	}
 
-	// Step 3: Return shift schedule by id
+	// Step 4: Return shift schedule by id
 	return http.StatusOK, "Shift Schedule Successfully Restored", nil
 }
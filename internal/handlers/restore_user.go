@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+// HandleRestoreUser godoc
+// HandleRestoreUser handles the request to restore a user
+// @Summary restore a user
+// @Schemes
+// @Description restore a deleted user
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} RespondJson "successfully restored user"
+// @Failure 403 {object} RespondJson "cannot restore user due to missing permission"
+// @Failure 404 {object} RespondJson "cannot restore user due to not found"
+// @Failure 500 {object} RespondJson "cannot restore user due to internal server error"
+// @Router /users/{id}/restore [patch]
+func (ss *ShiftService) HandleRestoreUser(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get user id from path and validate
+	id := c.Param("id")
+	if id == "" {
+		return http.StatusBadRequest, nil, nil
+	}
+
+	// Step 2: Restore user by id from database
+	repo := repository.NewUserRepository(ss.db)
+	user, err := repo.FindByIDWithDeleted(id)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot restore user due to not found")
+		}
+		return r, i, errors.New("cannot restore user due to internal server error")
+	}
+	if err := ss.authorizeOrganization(c, policy.RestoreUsers, user.OrganizationID); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	if err := repo.Restore(user); err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot restore user due to internal server error")
+	}
+
+	// Step 3: Return result
+	return http.StatusOK, "User Successfully Restored", nil
+}
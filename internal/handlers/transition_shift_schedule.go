@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/internal/workflow"
+	"shyft/pkg/httpErrors"
+)
+
+type transitionShiftScheduleDTO struct {
+	Reason string `json:"reason"` // required to reject
+}
+
+// HandleTransitionShiftSchedule godoc
+// HandleTransitionShiftSchedule handles the requests to submit, approve, reject or reopen a shift schedule
+// @Summary submit, approve, reject or reopen a shift schedule
+// @Schemes
+// @Description pending or rejected schedules are submitted, submitted schedules are approved or rejected (with a reason), approved or rejected schedules are reopened
+// @Tags Shift
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Shift Schedule ID"
+// @Param action path string true "Action" Enums(submit, approve, reject, reopen)
+// @Param body body transitionShiftScheduleDTO false "reason"
+// @Success 200 {object} RespondJson "successfully changed shift schedule status"
+// @Failure 400 {object} RespondJson "cannot change shift schedule status due to invalid request body"
+// @Failure 403 {object} RespondJson "cannot change shift schedule status due to missing permission"
+// @Failure 404 {object} RespondJson "cannot change shift schedule status due to not found"
+// @Failure 409 {object} RespondJson "cannot change shift schedule status from its current status"
+// @Failure 500 {object} RespondJson "cannot change shift schedule status due to internal server error"
+// @Router /shift-schedules/{id}/{action} [post]
+func (ss *ShiftService) HandleTransitionShiftSchedule(c *gin.Context, action workflow.Action) (int, interface{}, error) {
+	// Step 1: Get shift schedule id from path and reason from request body
+	id := c.Param("id")
+	if id == "" {
+		return http.StatusBadRequest, nil, nil
+	}
+	var params transitionShiftScheduleDTO
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&params); err != nil {
+			return http.StatusBadRequest, nil, err
+		}
+	}
+
+	// Step 2: Get shift schedule and check that the caller may submit (update) or approve it
+	repo, err := ss.scheduleRepository(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	shiftSchedule, err := repo.FindByID(id)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot change shift schedule status due to not found")
+		}
+		return r, i, errors.New("cannot change shift schedule status due to internal server error")
+	}
+	permission := policy.ApproveSchedules
+	if action == workflow.ActionSubmit {
+		permission = policy.UpdateSchedules
+	}
+	if err := ss.authorize(c, permission, shiftSchedule); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 3: Check the transition and save it
+	status, err := workflow.Transition(shiftSchedule.Status, action, params.Reason)
+	if err != nil {
+		if errors.Is(err, workflow.ErrReasonRequired) {
+			return http.StatusBadRequest, nil, err
+		}
+		return http.StatusConflict, nil, err
+	}
+	transition := models.ShiftScheduleTransition{
+		Action:     string(action),
+		FromStatus: shiftSchedule.Status,
+		ToStatus:   status,
+		ActorID:    actorID(c),
+		Reason:     params.Reason,
+	}
+	before := shiftSchedule.Clone()
+	if err := repo.Transition(shiftSchedule, &transition, ss.event(c, transitionEvents[action], before)); err != nil {
+		if errors.Is(err, repository.ErrStaleShiftSchedule) {
+			return http.StatusConflict, nil, err
+		}
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot change shift schedule status due to internal server error")
+	}
+
+	// Step 4: Return the status change
+	return http.StatusOK, transition, nil
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"shyft/internal/conflict"
+	"shyft/internal/events"
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/internal/swap"
+	"shyft/internal/workflow"
+	"shyft/pkg/httpErrors"
+)
+
+type transitionShiftSwapDTO struct {
+	Comment string `json:"comment"`
+}
+
+// HandleTransitionShiftSwap godoc
+// HandleTransitionShiftSwap handles the requests to accept, decline, cancel, approve or reject a shift swap
+// @Summary accept, decline, cancel, approve or reject a shift swap
+// @Schemes
+// @Description the responder accepts or declines, the requester cancels, the manager approves or rejects. The shifts are swapped in the schedule once the swap is accepted (and approved when the schedule requires it), unless the schedule is approved or the swapped shifts conflict.
+// @Tags Shift Swap
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Shift Swap ID"
+// @Param action path string true "Action" Enums(accept, decline, cancel, approve, reject)
+// @Param body body transitionShiftSwapDTO false "comment"
+// @Success 200 {object} RespondJson "successfully changed shift swap"
+// @Failure 400 {object} RespondJson "cannot change shift swap due to invalid request body"
+// @Failure 403 {object} RespondJson "cannot change shift swap due to missing permission"
+// @Failure 404 {object} RespondJson "cannot change shift swap due to not found"
+// @Failure 409 {object} RespondJson "cannot change shift swap in its current status, of an approved schedule or with conflicting shifts"
+// @Failure 500 {object} RespondJson "cannot change shift swap due to internal server error"
+// @Router /shift-swaps/{id}/{action} [post]
+func (ss *ShiftService) HandleTransitionShiftSwap(c *gin.Context, action swap.Action) (int, interface{}, error) {
+	// Step 1: Get swap request id from path and comment from request body
+	id := c.Param("id")
+	if id == "" {
+		return http.StatusBadRequest, nil, nil
+	}
+	var params transitionShiftSwapDTO
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&params); err != nil {
+			return http.StatusBadRequest, nil, err
+		}
+	}
+
+	// Step 2: Get swap request and its shift schedule
+	request, shiftSchedule, code, err := ss.findShiftSwap(c, id)
+	if err != nil {
+		return code, nil, err
+	}
+
+	// Step 3: Check that the caller may take the action
+	if err := ss.authorizeSwapAction(c, action, request, shiftSchedule); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 4: Move the swap request to its next status, swapping the shifts once it is completed. The locked
+	// schedule must still be editable and the swapped shifts must not conflict, or the swap stays as it was.
+	status, err := swap.Transition(request.Status, action, request.RequiresApproval)
+	if err != nil {
+		return http.StatusConflict, nil, err
+	}
+	repo, err := ss.scheduleRepository(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	var apply func(*models.ShiftSchedule) error
+	var event repository.EventFunc
+	if status == models.SwapCompleted {
+		var before *models.ShiftSchedule
+		apply = func(schedule *models.ShiftSchedule) error {
+			if err := workflow.CheckEditable(schedule); err != nil {
+				return err
+			}
+			before = schedule.Clone()
+			if err := swap.Apply(schedule, request); err != nil {
+				return err
+			}
+			return ss.checkConflicts(repo, schedule)
+		}
+		event = func(schedule *models.ShiftSchedule) (*models.OutboxEvent, error) {
+			return ss.event(c, events.ShiftScheduleUpdated, before)(schedule)
+		}
+	}
+	swaps, err := ss.swapRepository(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	if err := swaps.Transition(request, status, actorID(c), params.Comment, apply, event); err != nil {
+		var conflicts *conflict.Error
+		switch {
+		case errors.Is(err, repository.ErrStaleShiftSwap), errors.Is(err, swap.ErrShiftReassigned),
+			errors.Is(err, swap.ErrShiftNotFound), errors.Is(err, swap.ErrShiftNotAssigned),
+			errors.Is(err, workflow.ErrLocked), errors.As(err, &conflicts):
+			return http.StatusConflict, nil, err
+		}
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot change shift swap due to internal server error")
+	}
+
+	// Step 5: Return swap request
+	return http.StatusOK, request, nil
+}
+
+// authorizeSwapAction checks that the caller may take action on the swap request: the responder accepts
+// or declines, the requester cancels, and the manager approves or rejects. Whoever may update the
+// schedule may also act on behalf of the requester and the responder.
+func (ss *ShiftService) authorizeSwapAction(c *gin.Context, action swap.Action, request *models.ShiftSwap, shiftSchedule *models.ShiftSchedule) error {
+	claims := claimsFromContext(c)
+	if claims == nil {
+		return httpErrors.Forbidden
+	}
+	switch action {
+	case swap.ActionAccept, swap.ActionDecline:
+		if claims.UserID != 0 && uint(claims.UserID) == request.ResponderID {
+			return nil
+		}
+	case swap.ActionCancel:
+		if claims.UserID != 0 && uint(claims.UserID) == request.RequesterID {
+			return nil
+		}
+	case swap.ActionApprove, swap.ActionReject:
+		return ss.authorize(c, policy.ApproveSchedules, shiftSchedule)
+	}
+	return ss.authorize(c, policy.UpdateSchedules, shiftSchedule)
+}
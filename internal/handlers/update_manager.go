@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+type updateManagerDTO struct {
+	Name           string `json:"name" binding:"required"`
+	Mail           string `json:"mail" binding:"omitempty,email"`
+	Phone          string `json:"phone"`
+	Description    string `json:"description"`
+	OrganizationID *uint  `json:"organization_id"`
+
+	// PersonID is the user the manager is, it defaults to the user of the organization with the same mail
+	PersonID *uint `json:"person_id"`
+}
+
+// HandleUpdateManager godoc
+// HandleUpdateManager handles the request to update a manager
+// @Summary update a manager
+// @Schemes
+// @Description update a manager, the change is reflected in every shift schedule they manage
+// @Tags Manager
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Manager ID"
+// @Param body body updateManagerDTO true "update manager"
+// @Success 200 {object} RespondJson "successfully updated manager"
+// @Failure 400 {object} RespondJson "cannot update manager due to invalid request body or unknown user"
+// @Failure 403 {object} RespondJson "cannot update manager due to missing permission"
+// @Failure 404 {object} RespondJson "cannot update manager due to not found"
+// @Failure 500 {object} RespondJson "cannot update manager due to internal server error"
+// @Router /managers/{id} [put]
+func (ss *ShiftService) HandleUpdateManager(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get manager id from path and validate it
+	id := c.Param("id")
+	if id == "" {
+		return http.StatusBadRequest, nil, nil
+	}
+
+	// Step 2: Get DTO from request body and validate it
+	var params updateManagerDTO
+	if err := c.ShouldBindJSON(&params); err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+
+	repo := repository.NewManagerRepository(ss.db)
+	manager, err := repo.FindByID(id)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot update manager due to not found")
+		}
+		return r, i, errors.New("cannot update manager due to internal server error")
+	}
+
+	// Step 3: Check that the caller may update the manager in both its current and its new organization
+	if err := ss.authorizeOrganization(c, policy.UpdateManagers, manager.OrganizationID); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	if err := ss.authorizeOrganization(c, policy.UpdateManagers, params.OrganizationID); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 4: Map DTO to manager
+	manager.Name = params.Name
+	manager.Mail = params.Mail
+	manager.Phone = params.Phone
+	manager.Description = params.Description
+	manager.OrganizationID = params.OrganizationID
+	manager.PersonID = params.PersonID
+
+	// Step 5: Update manager (and the shift schedules embedding it) in database
+	if err := repo.Save(manager); err != nil {
+		if errors.Is(err, repository.ErrUnknownContact) {
+			return http.StatusBadRequest, nil, err
+		}
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot update manager due to internal server error")
+	}
+
+	// Step 6: Return manager
+	return http.StatusOK, manager, nil
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+type updateOrganizationDTO struct {
+	Name        string `json:"name" binding:"required"`
+	Mail        string `json:"mail" binding:"omitempty,email"`
+	Phone       string `json:"phone"`
+	Description string `json:"description"`
+}
+
+// HandleUpdateOrganization godoc
+// HandleUpdateOrganization handles the request to update an organization
+// @Summary update an organization
+// @Schemes
+// @Description update an organization, the change is reflected in every shift schedule it owns
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Organization ID"
+// @Param body body updateOrganizationDTO true "update organization"
+// @Success 200 {object} RespondJson "successfully updated organization"
+// @Failure 400 {object} RespondJson "cannot update organization due to invalid request body"
+// @Failure 403 {object} RespondJson "cannot update organization due to missing permission"
+// @Failure 404 {object} RespondJson "cannot update organization due to not found"
+// @Failure 500 {object} RespondJson "cannot update organization due to internal server error"
+// @Router /organizations/{id} [put]
+func (ss *ShiftService) HandleUpdateOrganization(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get organization id from path and validate it
+	id := c.Param("id")
+	if id == "" {
+		return http.StatusBadRequest, nil, nil
+	}
+
+	// Step 2: Get DTO from request body and validate it
+	var params updateOrganizationDTO
+	if err := c.ShouldBindJSON(&params); err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+
+	repo := repository.NewOrganizationRepository(ss.db)
+	organization, err := repo.FindByID(id)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot update organization due to not found")
+		}
+		return r, i, errors.New("cannot update organization due to internal server error")
+	}
+	if err := ss.authorizeOrganization(c, policy.UpdateOrganizations, &organization.ID); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 3: Map DTO to organization
+	organization.Name = params.Name
+	organization.Mail = params.Mail
+	organization.Phone = params.Phone
+	organization.Description = params.Description
+
+	// Step 4: Update organization (and the shift schedules embedding it) in database
+	if err := repo.Save(organization); err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot update organization due to internal server error")
+	}
+
+	// Step 5: Return organization
+	return http.StatusOK, organization, nil
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+type updateWebhookDTO struct {
+	Url         *string   `json:"url"`
+	EventTypes  *[]string `json:"event_types"` // empty subscribes to every event
+	Secret      *string   `json:"secret"`      // rotates the secret
+	Description *string   `json:"description"`
+	Active      *bool     `json:"active"`
+}
+
+// HandleUpdateOrganizationWebhook godoc
+// HandleUpdateOrganizationWebhook handles the request to update a webhook subscription of an organization
+// @Summary update a webhook of an organization
+// @Schemes
+// @Description update the url, event types, secret, description or activity of a webhook subscription, fields left out are kept
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Organization ID"
+// @Param webhook_id path string true "Webhook ID"
+// @Param body body updateWebhookDTO true "webhook"
+// @Success 200 {object} RespondJson "successfully updated webhook"
+// @Failure 400 {object} RespondJson "cannot update webhook due to invalid request body"
+// @Failure 403 {object} RespondJson "cannot update webhook due to missing permission"
+// @Failure 404 {object} RespondJson "cannot update webhook due to not found"
+// @Failure 500 {object} RespondJson "cannot update webhook due to internal server error"
+// @Router /organizations/{id}/webhooks/{webhook_id} [put]
+func (ss *ShiftService) HandleUpdateOrganizationWebhook(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get organization and webhook id from path and changes from request body
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 1 {
+		return http.StatusBadRequest, nil, httpErrors.BadQueryParams
+	}
+	organizationID := uint(id)
+	webhookID := c.Param("webhook_id")
+	var params updateWebhookDTO
+	if err := c.ShouldBindJSON(&params); err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	if params.Url != nil {
+		if err := validateWebhookUrl(c.Request.Context(), *params.Url); err != nil {
+			return http.StatusBadRequest, nil, err
+		}
+	}
+	if params.EventTypes != nil {
+		if err := validateWebhookEventTypes(*params.EventTypes); err != nil {
+			return http.StatusBadRequest, nil, err
+		}
+	}
+	if params.Secret != nil && *params.Secret == "" {
+		return http.StatusBadRequest, nil, errors.New("webhook secret cannot be empty")
+	}
+
+	// Step 2: Check that the caller may update the organization
+	if err := ss.authorizeOrganization(c, policy.UpdateOrganizations, &organizationID); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 3: Get webhook subscription from database
+	webhooks := repository.NewWebhookRepository(ss.db)
+	subscription, err := webhooks.FindByID(organizationID, webhookID)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot update webhook due to not found")
+		}
+		return r, i, errors.New("cannot update webhook due to internal server error")
+	}
+
+	// Step 4: Apply changes and save webhook subscription to database
+	if params.Url != nil {
+		subscription.Url = strings.TrimSpace(*params.Url)
+	}
+	if params.EventTypes != nil {
+		subscription.EventTypes = models.StringList(*params.EventTypes)
+	}
+	if params.Secret != nil {
+		subscription.Secret = *params.Secret
+	}
+	if params.Description != nil {
+		subscription.Description = strings.TrimSpace(*params.Description)
+	}
+	if params.Active != nil {
+		subscription.Active = *params.Active
+	}
+	if err := webhooks.Save(subscription); err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot update webhook due to internal server error")
+	}
+
+	// Step 5: Return webhook subscription
+	return http.StatusOK, subscription, nil
+}
@@ -8,7 +8,13 @@ import (
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 
+	"shyft/internal/conflict"
+	"shyft/internal/events"
 	"shyft/internal/models"
+	"shyft/internal/policy"
+	"shyft/internal/recurrence"
+	"shyft/internal/repository"
+	"shyft/internal/workflow"
 	"shyft/pkg/httpErrors"
 )
 
@@ -19,11 +25,14 @@ type updateShiftScheduleDTO struct {
 	Start_Date   time.Time    `json:"start_date" binding:"required"`
 	End_Date     time.Time    `json:"end_date" binding:"required"`
 	Year         int          `json:"year" binding:"required"`
-	Status       int          `json:"status"` // 0: pending, 1: approved, 2: rejected
 	Organization models.JSONB `json:"organization" binding:"required"`
 	Manager      models.JSONB `json:"manager" binding:"required"`
 	Users        models.JSONB `json:"users" binding:"required"`
 	Shifts       models.JSONB `json:"shifts" binding:"required"`
+
+	Recurrence           models.RecurrenceRules `json:"recurrence"`             // recurring shift patterns (RRULE)
+	SwapRequiresApproval bool                   `json:"swap_requires_approval"` // shift swaps also need the manager approval
+	TimeZone             string                 `json:"time_zone"`              // IANA time zone, unchanged when empty
 }
 
 // HandleUpdateShiftSchedule godoc
@@ -39,6 +48,7 @@ type updateShiftScheduleDTO struct {
 // @Param body body updateShiftScheduleDTO true "update shift schedule"
 // @Success 200 {object} RespondJson "successfully updated shift schedule"
 // @Failure 400 {object} RespondJson "cannot update shift schedule due to invalid request body"
+// @Failure 409 {object} RespondJson "cannot update shift schedule while it is approved, or due to conflicts (double bookings, overlaps, shifts out of range) listed in details"
 // @Failure 422 {object} RespondJson "cannot update shift schedule due to invalid request body"
 // @Failure 500 {object} RespondJson "cannot update shift schedule due to internal server error"
 // @Router /shift-schedule/{id} [put]
@@ -54,26 +64,60 @@ func (ss *ShiftService) HandleUpdateShiftSchedule(c *gin.Context) (int, interfac
 	if err := c.ShouldBindJSON(&params); err != nil {
 		return http.StatusBadRequest, nil, err
 	}
+	if err := recurrence.Validate(params.Recurrence); err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+	if params.TimeZone != "" {
+		if _, err := models.LoadLocation(params.TimeZone); err != nil {
+			return http.StatusBadRequest, nil, err
+		}
+	}
 
-	var shift models.ShiftSchedule
-	if err := ss.db.Where("id = ?", id).First(&shift).Error; err != nil {
+	repo, err := ss.scheduleRepository(c)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	shift, err := repo.FindByID(id)
+	if err != nil {
 		r, i := httpErrors.ErrorResponse(err)
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return r, i, errors.New("cannot update shift due to not found")
+			return http.StatusNotFound, i, errors.New("cannot update shift due to not found")
 		}
 		return r, i, errors.New("cannot update shift due to internal server error")
 	}
 
-	// Step 3: Map DTO to shift and validate it
-	updateParamsToShiftSchedule(&params, &shift)
+	// Step 3: Check that the caller may update the shift and that it is not approved
+	if err := ss.authorize(c, policy.UpdateSchedules, shift); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	if err := workflow.CheckEditable(shift); err != nil {
+		return http.StatusConflict, nil, err
+	}
+
+	// Step 4: Map DTO to shift and validate it
+	before := shift.Clone()
+	updateParamsToShiftSchedule(&params, shift)
+	if !shift.OwnedBy(claimsFromContext(c).OrganizationID) {
+		return http.StatusForbidden, nil, errors.New("cannot update shift schedule unless the caller's organization is its only organization")
+	}
+	if err := ss.checkConflicts(repo, shift); err != nil {
+		var conflicts *conflict.Error
+		if errors.As(err, &conflicts) {
+			return http.StatusConflict, nil, err
+		}
+		return http.StatusInternalServerError, nil, err
+	}
 
-	// Step 4: Update shift to database
-	if err := ss.db.Save(&shift).Error; err != nil {
+	// Step 5: Update shift to database
+	if err := repo.Save(shift, ss.event(c, events.ShiftScheduleUpdated, before)); err != nil {
+		if errors.Is(err, repository.ErrUnknownContact) {
+			return http.StatusBadRequest, nil, err
+		}
 		r, i := httpErrors.ErrorResponse(err)
 		return r, i, errors.New("cannot update shift due to internal server error")
 	}
 
-	// Step 5: Get shift by id from database
+	// Step 6: Get shift by id from database
 	return http.StatusOK, "Shift Schedule Successfully Updated", nil
 }
 
@@ -84,9 +128,13 @@ func updateParamsToShiftSchedule(params *updateShiftScheduleDTO, shift *models.S
 	shift.Start_Date = params.Start_Date
 	shift.End_Date = params.End_Date
 	shift.Year = params.Year
-	shift.Status = params.Status
 	shift.Organization = params.Organization
 	shift.Manager = params.Manager
 	shift.Users = params.Users
 	shift.Shifts = params.Shifts
+	shift.Recurrence = params.Recurrence
+	shift.SwapRequiresApproval = params.SwapRequiresApproval
+	if params.TimeZone != "" {
+		shift.TimeZone = params.TimeZone
+	}
 }
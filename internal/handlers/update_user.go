@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"shyft/internal/policy"
+	"shyft/internal/repository"
+	"shyft/pkg/httpErrors"
+)
+
+type updateUserDTO struct {
+	Name        string `json:"name" binding:"required"`
+	Mail        string `json:"mail" binding:"omitempty,email"`
+	Phone       string `json:"phone"`
+	Description string `json:"description"`
+}
+
+// HandleUpdateUser godoc
+// HandleUpdateUser handles the request to update a user
+// @Summary update a user
+// @Schemes
+// @Description update a user, the change is reflected in every shift schedule the user belongs to
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param body body updateUserDTO true "update user"
+// @Success 200 {object} RespondJson "successfully updated user"
+// @Failure 400 {object} RespondJson "cannot update user due to invalid request body"
+// @Failure 403 {object} RespondJson "cannot update user due to missing permission"
+// @Failure 404 {object} RespondJson "cannot update user due to not found"
+// @Failure 500 {object} RespondJson "cannot update user due to internal server error"
+// @Router /users/{id} [put]
+func (ss *ShiftService) HandleUpdateUser(c *gin.Context) (int, interface{}, error) {
+	// Step 1: Get user id from path and validate it
+	id := c.Param("id")
+	if id == "" {
+		return http.StatusBadRequest, nil, nil
+	}
+
+	// Step 2: Get DTO from request body and validate it
+	var params updateUserDTO
+	if err := c.ShouldBindJSON(&params); err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+
+	repo := repository.NewUserRepository(ss.db)
+	user, err := repo.FindByID(id)
+	if err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusNotFound, i, errors.New("cannot update user due to not found")
+		}
+		return r, i, errors.New("cannot update user due to internal server error")
+	}
+	if err := ss.authorizeOrganization(c, policy.UpdateUsers, user.OrganizationID); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	// Step 3: Map DTO to user
+	user.Name = params.Name
+	user.Mail = params.Mail
+	user.Phone = params.Phone
+	user.Description = params.Description
+
+	// Step 4: Update user (and the shift schedules embedding it) in database
+	if err := repo.Save(user); err != nil {
+		r, i := httpErrors.ErrorResponse(err)
+		return r, i, errors.New("cannot update user due to internal server error")
+	}
+
+	// Step 5: Return user
+	return http.StatusOK, user, nil
+}
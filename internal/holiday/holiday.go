@@ -0,0 +1,219 @@
+package holiday
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"shyft/internal/models"
+)
+
+// Calendar tells which days are holidays, per organization
+type Calendar struct {
+	days map[uint]map[string]string
+}
+
+// NewCalendar creates a calendar of the holidays
+func NewCalendar(holidays []models.Holiday) *Calendar {
+	c := &Calendar{days: map[uint]map[string]string{}}
+	for _, h := range holidays {
+		if c.days[h.OrganizationID] == nil {
+			c.days[h.OrganizationID] = map[string]string{}
+		}
+		c.days[h.OrganizationID][h.Date.Format(models.HolidayDateLayout)] = h.Name
+	}
+	return c
+}
+
+// Name returns the name of the organization's holiday on the (local) day of day, empty when it is no holiday.
+// A nil calendar has no holidays.
+func (c *Calendar) Name(organizationID *uint, day time.Time) string {
+	if c == nil || organizationID == nil {
+		return ""
+	}
+	return c.days[*organizationID][day.Format(models.HolidayDateLayout)]
+}
+
+// IsHoliday reports whether the (local) day of day is a holiday of the organization
+func (c *Calendar) IsHoliday(organizationID *uint, day time.Time) bool {
+	return c.Name(organizationID, day) != ""
+}
+
+// Of returns the holidays of a single organization, e.g. for the rotation generator
+func (c *Calendar) Of(organizationID *uint) func(day time.Time) bool {
+	return func(day time.Time) bool {
+		return c.IsHoliday(organizationID, day)
+	}
+}
+
+// Mark sets `holiday` to the holiday name on the shift entries overlapping a holiday of the organization.
+// Entries are copied, not modified.
+func (c *Calendar) Mark(organizationID *uint, entries models.JSONB, loc *time.Location) models.JSONB {
+	if c == nil || organizationID == nil || len(c.days[*organizationID]) == 0 {
+		return entries
+	}
+	result := make(models.JSONB, 0, len(entries))
+	for _, entry := range entries {
+		values, ok := entry.(map[string]interface{})
+		if !ok {
+			result = append(result, entry)
+			continue
+		}
+		start, _ := values["start"].(string)
+		end, _ := values["end"].(string)
+		startAt, err := models.ParseShiftTime(start, loc)
+		if err != nil {
+			result = append(result, entry)
+			continue
+		}
+		endAt, err := models.ParseShiftTime(end, loc)
+		if err != nil {
+			result = append(result, entry)
+			continue
+		}
+
+		name := ""
+		startAt = startAt.In(loc)
+		for day := time.Date(startAt.Year(), startAt.Month(), startAt.Day(), 0, 0, 0, 0, loc); day.Before(endAt) && name == ""; day = day.AddDate(0, 0, 1) {
+			name = c.Name(organizationID, day)
+		}
+		if name == "" {
+			result = append(result, entry)
+			continue
+		}
+		marked := make(map[string]interface{}, len(values)+1)
+		for key, value := range values {
+			marked[key] = value
+		}
+		marked["holiday"] = name
+		result = append(result, marked)
+	}
+	return result
+}
+
+var ErrInvalidFile = errors.New("holiday file must be an iCalendar or a CSV file")
+
+// ParseCSV reads holidays from `date,name` rows, dates written as YYYY-MM-DD. A header row is skipped.
+func ParseCSV(r io.Reader) ([]models.Holiday, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var holidays []models.Holiday
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 2 {
+			return nil, fmt.Errorf("line %d: expected date and name", line)
+		}
+		date, err := time.Parse(models.HolidayDateLayout, strings.TrimSpace(record[0]))
+		if err != nil {
+			if line == 1 {
+				continue // header
+			}
+			return nil, fmt.Errorf("line %d: date must be formatted as YYYY-MM-DD", line)
+		}
+		holidays = append(holidays, models.Holiday{Date: date, Name: strings.TrimSpace(record[1])})
+	}
+	return holidays, nil
+}
+
+// ParseICS reads holidays from the VEVENTs of an iCalendar file, an event lasting several days
+// (DTEND is exclusive) becomes a holiday per day
+func ParseICS(r io.Reader) ([]models.Holiday, error) {
+	var holidays []models.Holiday
+	var inEvent bool
+	var summary string
+	var start, end time.Time
+
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range lines {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		property, _, _ := strings.Cut(name, ";")
+		switch strings.ToUpper(property) {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				inEvent, summary, start, end = true, "", time.Time{}, time.Time{}
+			}
+		case "END":
+			if !strings.EqualFold(value, "VEVENT") || !inEvent {
+				continue
+			}
+			inEvent = false
+			if start.IsZero() {
+				return nil, fmt.Errorf("event %q has no DTSTART", summary)
+			}
+			if !end.After(start) {
+				end = start.AddDate(0, 0, 1)
+			}
+			for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+				holidays = append(holidays, models.Holiday{Date: day, Name: summary})
+			}
+		case "SUMMARY":
+			summary = unescape(value)
+		case "DTSTART", "DTEND":
+			if !inEvent {
+				continue
+			}
+			day, err := parseDate(value)
+			if err != nil {
+				return nil, err
+			}
+			if strings.EqualFold(property, "DTSTART") {
+				start = day
+			} else {
+				end = day
+			}
+		}
+	}
+	if len(holidays) == 0 {
+		return nil, ErrInvalidFile
+	}
+	return holidays, nil
+}
+
+// unfold joins the continuation lines (starting with a space or a tab) of an iCalendar file
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// parseDate reads the day of a DATE (20260101) or DATE-TIME (20260101T000000Z) value
+func parseDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("invalid iCalendar date %q", value)
+	}
+	day, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid iCalendar date %q", value)
+	}
+	return day, nil
+}
+
+func unescape(value string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value)
+}
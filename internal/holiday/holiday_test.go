@@ -0,0 +1,211 @@
+package holiday
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"shyft/internal/models"
+)
+
+// day returns midnight UTC of the day, the way holidays are stored
+func day(value string) time.Time {
+	t, err := time.Parse(models.HolidayDateLayout, value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func holidayStrings(holidays []models.Holiday) []string {
+	result := make([]string, 0, len(holidays))
+	for _, h := range holidays {
+		result = append(result, h.Date.Format(models.HolidayDateLayout)+" "+h.Name)
+	}
+	return result
+}
+
+func TestParseICS(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "all day events",
+			file: "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20260101\r\nDTEND;VALUE=DATE:20260102\r\nSUMMARY:New Year\r\nEND:VEVENT\r\n" +
+				"BEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20260501\r\nSUMMARY:Labour Day\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+			want: []string{"2026-01-01 New Year", "2026-05-01 Labour Day"},
+		},
+		{
+			name: "an event lasting several days becomes a holiday per day, DTEND is exclusive",
+			file: "BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:Feast\nDTSTART;VALUE=DATE:20260320\nDTEND;VALUE=DATE:20260323\nEND:VEVENT\nEND:VCALENDAR\n",
+			want: []string{"2026-03-20 Feast", "2026-03-21 Feast", "2026-03-22 Feast"},
+		},
+		{
+			name: "date-time values, folded lines and escaped text",
+			file: "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART:20261029T000000Z\r\nSUMMARY:Republic Day\\, \r\n national holiday\\; observed\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+			want: []string{"2026-10-29 Republic Day, national holiday; observed"},
+		},
+		{
+			name:    "an event without DTSTART",
+			file:    "BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:Nowhere\nEND:VEVENT\nEND:VCALENDAR\n",
+			wantErr: true,
+		},
+		{
+			name:    "an invalid date",
+			file:    "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:2026\nEND:VEVENT\nEND:VCALENDAR\n",
+			wantErr: true,
+		},
+		{
+			name:    "no events",
+			file:    "BEGIN:VCALENDAR\nEND:VCALENDAR\n",
+			wantErr: true,
+		},
+		{
+			name:    "not an iCalendar file",
+			file:    "date,name\n2026-01-01,New Year\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			holidays, err := ParseICS(strings.NewReader(tt.file))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseICS() = %v, want an error", holidayStrings(holidays))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseICS() error = %v", err)
+			}
+			got := holidayStrings(holidays)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("ParseICS() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ParseICS(strings.NewReader("BEGIN:VCALENDAR\nEND:VCALENDAR\n")); !errors.Is(err, ErrInvalidFile) {
+		t.Errorf("ParseICS() of an empty calendar error = %v, want ErrInvalidFile", err)
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "with a header",
+			file: "date,name\n2026-01-01,New Year\n2026-04-23, National Sovereignty Day \n",
+			want: []string{"2026-01-01 New Year", "2026-04-23 National Sovereignty Day"},
+		},
+		{
+			name: "without a header",
+			file: "2026-05-19,Youth Day\n",
+			want: []string{"2026-05-19 Youth Day"},
+		},
+		{
+			name: "quoted names",
+			file: "2026-08-30,\"Victory Day, observed\"\n",
+			want: []string{"2026-08-30 Victory Day, observed"},
+		},
+		{
+			name:    "a row without name",
+			file:    "2026-01-01\n",
+			wantErr: true,
+		},
+		{
+			name:    "an invalid date after the header",
+			file:    "date,name\n01/01/2026,New Year\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			holidays, err := ParseCSV(strings.NewReader(tt.file))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseCSV() = %v, want an error", holidayStrings(holidays))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCSV() error = %v", err)
+			}
+			got := holidayStrings(holidays)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("ParseCSV() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalendar(t *testing.T) {
+	istanbul, err := time.LoadLocation("Europe/Istanbul")
+	if err != nil {
+		t.Fatal(err)
+	}
+	acme, other := uint(1), uint(2)
+	calendar := NewCalendar([]models.Holiday{
+		{OrganizationID: acme, Date: day("2026-01-01"), Name: "New Year"},
+		{OrganizationID: other, Date: day("2026-05-01"), Name: "Labour Day"},
+	})
+
+	names := []struct {
+		name         string
+		calendar     *Calendar
+		organization *uint
+		day          time.Time
+		want         string
+	}{
+		{"holiday of the organization", calendar, &acme, time.Date(2026, 1, 1, 0, 0, 0, 0, istanbul), "New Year"},
+		{"any time of the local day", calendar, &acme, time.Date(2026, 1, 1, 23, 59, 0, 0, istanbul), "New Year"},
+		{"the local day counts, not the UTC one", calendar, &acme, time.Date(2025, 12, 31, 22, 0, 0, 0, time.UTC).In(istanbul), "New Year"},
+		{"holiday of another organization", calendar, &acme, time.Date(2026, 5, 1, 12, 0, 0, 0, istanbul), ""},
+		{"no organization", calendar, nil, time.Date(2026, 1, 1, 12, 0, 0, 0, istanbul), ""},
+		{"nil calendar", nil, &acme, time.Date(2026, 1, 1, 12, 0, 0, 0, istanbul), ""},
+	}
+	for _, tt := range names {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.calendar.Name(tt.organization, tt.day); got != tt.want {
+				t.Errorf("Name() = %q, want %q", got, tt.want)
+			}
+			if got := tt.calendar.IsHoliday(tt.organization, tt.day); got != (tt.want != "") {
+				t.Errorf("IsHoliday() = %v, want %v", got, tt.want != "")
+			}
+		})
+	}
+
+	entries := models.JSONB{
+		map[string]interface{}{"id": 0.0, "start": "2025-12-31 09:00:00", "end": "2026-01-01 09:00:00"},
+		map[string]interface{}{"id": 1.0, "start": "2026-01-01 09:00:00", "end": "2026-01-02 09:00:00"},
+		map[string]interface{}{"id": 2.0, "start": "2026-01-02 09:00:00", "end": "2026-01-03 09:00:00"},
+		map[string]interface{}{"id": 3.0, "start": "not a time", "end": "2026-01-03 09:00:00"},
+		"not an object",
+	}
+	marked := calendar.Mark(&acme, entries, istanbul)
+	if len(marked) != len(entries) {
+		t.Fatalf("Mark() returned %d entries, want %d", len(marked), len(entries))
+	}
+	for i, want := range []string{"New Year", "New Year", "", ""} {
+		got, _ := marked[i].(map[string]interface{})["holiday"].(string)
+		if got != want {
+			t.Errorf("Mark() entry %d holiday = %q, want %q", i, got, want)
+		}
+	}
+	if _, ok := entries[0].(map[string]interface{})["holiday"]; ok {
+		t.Error("Mark() modified the given entries")
+	}
+	if unmarked := calendar.Mark(&other, entries[:1], istanbul); len(unmarked) != 1 || unmarked[0].(map[string]interface{})["holiday"] != nil {
+		t.Errorf("Mark() of another organization = %v, want the entry unmarked", unmarked)
+	}
+}
@@ -0,0 +1,92 @@
+package hr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Version of the HR event payload the worker understands
+const Version = 1
+
+// Type of an HR event
+type Type string
+
+const (
+	EmployeeJoined      Type = "employee.joined"      // a person joins an organization
+	EmployeeLeft        Type = "employee.left"        // a person leaves the company
+	EmployeeTransferred Type = "employee.transferred" // a person moves to another organization
+)
+
+// ErrInvalidEvent is returned for events that can never be applied, they are dead lettered
+var ErrInvalidEvent = errors.New("invalid HR event")
+
+// Employee is the person an HR event is about, matched by id or else by mail
+type Employee struct {
+	ID    uint   `json:"id"` // id of the person in the shift service, optional
+	Name  string `json:"name"`
+	Mail  string `json:"mail"`
+	Phone string `json:"phone"`
+}
+
+// Event is an employee event of the HR platform
+type Event struct {
+	ID          string     `json:"id"`
+	Version     int        `json:"version"`
+	Type        Type       `json:"type"`
+	OccurredAt  time.Time  `json:"occurred_at"`
+	EffectiveAt *time.Time `json:"effective_at"` // shifts from then on are unassigned, defaults to occurred_at
+	Employee    Employee   `json:"employee"`
+
+	OrganizationID     uint  `json:"organization_id"`      // joined: the organization, transferred: the new organization
+	FromOrganizationID *uint `json:"from_organization_id"` // transferred: the previous organization, optional
+}
+
+// Effective returns the time the event takes effect
+func (e Event) Effective() time.Time {
+	if e.EffectiveAt != nil {
+		return *e.EffectiveAt
+	}
+	if !e.OccurredAt.IsZero() {
+		return e.OccurredAt
+	}
+	return time.Now()
+}
+
+// Parse decodes and validates an HR event, errors wrap ErrInvalidEvent
+func Parse(value []byte) (Event, error) {
+	var event Event
+	if err := json.Unmarshal(value, &event); err != nil {
+		return event, fmt.Errorf("%w: %v", ErrInvalidEvent, err)
+	}
+	event.Employee.Mail = strings.TrimSpace(event.Employee.Mail)
+	event.Employee.Name = strings.TrimSpace(event.Employee.Name)
+
+	switch {
+	case event.ID == "":
+		return event, fmt.Errorf("%w: id is required", ErrInvalidEvent)
+	case event.Version != Version:
+		return event, fmt.Errorf("%w: unsupported version %d", ErrInvalidEvent, event.Version)
+	case event.Employee.ID == 0 && event.Employee.Mail == "":
+		return event, fmt.Errorf("%w: employee id or mail is required", ErrInvalidEvent)
+	}
+	switch event.Type {
+	case EmployeeJoined:
+		if event.Employee.Name == "" {
+			return event, fmt.Errorf("%w: employee name is required", ErrInvalidEvent)
+		}
+		if event.OrganizationID == 0 {
+			return event, fmt.Errorf("%w: organization_id is required", ErrInvalidEvent)
+		}
+	case EmployeeTransferred:
+		if event.OrganizationID == 0 {
+			return event, fmt.Errorf("%w: organization_id is required", ErrInvalidEvent)
+		}
+	case EmployeeLeft:
+	default:
+		return event, fmt.Errorf("%w: unknown type %q", ErrInvalidEvent, event.Type)
+	}
+	return event, nil
+}
@@ -0,0 +1,107 @@
+package hr
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"shyft/pkg/broker"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{"joined", `{"id":"1","version":1,"type":"employee.joined","employee":{"name":" Ada ","mail":" ada@example.com "},"organization_id":2}`, false},
+		{"left by id", `{"id":"1","version":1,"type":"employee.left","employee":{"id":4}}`, false},
+		{"transferred", `{"id":"1","version":1,"type":"employee.transferred","employee":{"mail":"ada@example.com"},"organization_id":3,"from_organization_id":2}`, false},
+		{"not json", `employee.left`, true},
+		{"missing id", `{"version":1,"type":"employee.left","employee":{"id":4}}`, true},
+		{"unsupported version", `{"id":"1","version":2,"type":"employee.left","employee":{"id":4}}`, true},
+		{"missing employee", `{"id":"1","version":1,"type":"employee.left","employee":{"mail":"  "}}`, true},
+		{"joined without name", `{"id":"1","version":1,"type":"employee.joined","employee":{"mail":"ada@example.com"},"organization_id":2}`, true},
+		{"joined without organization", `{"id":"1","version":1,"type":"employee.joined","employee":{"name":"Ada","mail":"ada@example.com"}}`, true},
+		{"transferred without organization", `{"id":"1","version":1,"type":"employee.transferred","employee":{"id":4}}`, true},
+		{"unknown type", `{"id":"1","version":1,"type":"employee.promoted","employee":{"id":4}}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := Parse([]byte(tt.value))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidEvent) {
+				t.Errorf("Parse() error = %v, want it to wrap ErrInvalidEvent", err)
+			}
+			if event.Employee.Mail != strings.TrimSpace(event.Employee.Mail) || event.Employee.Name != strings.TrimSpace(event.Employee.Name) {
+				t.Errorf("Parse() employee = %+v, want the name and mail trimmed", event.Employee)
+			}
+		})
+	}
+}
+
+func TestEffective(t *testing.T) {
+	occurred := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	effective := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		event Event
+		want  time.Time
+	}{
+		{"effective at", Event{OccurredAt: occurred, EffectiveAt: &effective}, effective},
+		{"occurred at", Event{OccurredAt: occurred}, occurred},
+	}
+	for _, tt := range tests {
+		if got := tt.event.Effective(); !got.Equal(tt.want) {
+			t.Errorf("%s: Effective() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if got := (Event{}).Effective(); time.Since(got) > time.Minute {
+		t.Errorf("Effective() without times = %v, want now", got)
+	}
+}
+
+func TestHandleDeadLettersInvalidEvents(t *testing.T) {
+	b := broker.NewMemoryBroker()
+	w := NewWorker(nil, b, "hr.dead-letter")
+	message := broker.Message{
+		Topic:     "hr.events",
+		Key:       []byte("4"),
+		Value:     []byte(`{"id":"1","version":9}`),
+		Headers:   map[string]string{"source": "hr"},
+		Partition: 2,
+		Offset:    17,
+	}
+	if err := w.Handle(context.Background(), message); err != nil {
+		t.Fatalf("Handle() error = %v, want the event dead lettered", err)
+	}
+
+	dead := b.Messages("hr.dead-letter")
+	if len(dead) != 1 {
+		t.Fatalf("dead lettered %d messages, want 1", len(dead))
+	}
+	want := map[string]string{
+		"source":                  "hr",
+		HeaderDeadLetterTopic:     "hr.events",
+		HeaderDeadLetterPartition: "2",
+		HeaderDeadLetterOffset:    "17",
+	}
+	for key, value := range want {
+		if dead[0].Headers[key] != value {
+			t.Errorf("dead letter header %s = %q, want %q", key, dead[0].Headers[key], value)
+		}
+	}
+	if dead[0].Headers[HeaderDeadLetterReason] == "" || string(dead[0].Value) != string(message.Value) || string(dead[0].Key) != "4" {
+		t.Errorf("dead letter = %+v, want the original message with a reason", dead[0])
+	}
+
+	b.Close()
+	if err := w.Handle(context.Background(), message); err == nil {
+		t.Error("Handle() with a closed broker error = nil, want the event handed again")
+	}
+}
@@ -0,0 +1,212 @@
+package hr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+
+	"shyft/internal/events"
+	"shyft/internal/models"
+	"shyft/internal/repository"
+	"shyft/pkg/broker"
+	"shyft/pkg/logger"
+)
+
+// Headers of the dead lettered messages, next to the headers of the original message
+const (
+	HeaderDeadLetterReason    = "dead-letter-reason"
+	HeaderDeadLetterTopic     = "dead-letter-topic"
+	HeaderDeadLetterPartition = "dead-letter-partition"
+	HeaderDeadLetterOffset    = "dead-letter-offset"
+)
+
+// actor of the shift schedule changes made by the HR events
+var actor = &events.Actor{Name: "HR platform", Role: "system"}
+
+// Worker applies the employee events of the HR platform: joiners are added to the people of their
+// organization, leavers are deactivated and removed from the users and upcoming shifts of every shift
+// schedule, transferees are moved to their new organization and removed from the shift schedules of the
+// previous one. Events are applied once, invalid ones are published to the dead letter topic.
+type Worker struct {
+	employees       *repository.EmployeeRepository
+	broker          broker.Broker
+	deadLetterTopic string
+}
+
+func NewWorker(db *gorm.DB, b broker.Broker, deadLetterTopic string) *Worker {
+	return &Worker{employees: repository.NewEmployeeRepository(db), broker: b, deadLetterTopic: deadLetterTopic}
+}
+
+// Run consumes the HR events until ctx is done, consuming again with backoff when the consumer fails
+func (w *Worker) Run(ctx context.Context, consumer broker.Consumer) {
+	defer consumer.Close()
+	broker.ConsumeWithRetry(ctx, consumer, w.Handle, func(err error, delay time.Duration) {
+		logger.CLogger.Errorf("HR event consumer failed, consuming again in %s: %v", delay, err)
+	})
+}
+
+// Handle applies an HR event, invalid events are dead lettered. Other errors are returned, the event is
+// handed again then.
+func (w *Worker) Handle(ctx context.Context, message broker.Message) error {
+	event, err := Parse(message.Value)
+	if err == nil {
+		err = w.apply(event)
+	}
+	if errors.Is(err, ErrInvalidEvent) {
+		return w.deadLetter(ctx, message, err)
+	}
+	if err != nil {
+		logger.CLogger.Errorf("Cannot apply HR event %s: %v", event.ID, err)
+	}
+	return err
+}
+
+func (w *Worker) apply(event Event) error {
+	applied, err := w.employees.Apply(event.ID, string(event.Type), func(tx *repository.EmployeeRepository) (*models.User, error) {
+		switch event.Type {
+		case EmployeeJoined:
+			return join(tx, event)
+		case EmployeeLeft:
+			return leave(tx, event)
+		case EmployeeTransferred:
+			return transfer(tx, event)
+		}
+		return nil, fmt.Errorf("%w: unknown type %q", ErrInvalidEvent, event.Type)
+	})
+	if err != nil {
+		return err
+	}
+	if applied {
+		logger.CLogger.Infof("HR event %s (%s) applied", event.ID, event.Type)
+	}
+	return nil
+}
+
+// join adds the person to the organization, creating or reactivating them
+func join(tx *repository.EmployeeRepository, event Event) (*models.User, error) {
+	if err := checkOrganization(tx, event.OrganizationID); err != nil {
+		return nil, err
+	}
+	person, err := tx.FindPerson(event.Employee.ID, event.Employee.Mail)
+	if err != nil {
+		return nil, err
+	}
+	if person == nil {
+		if event.Employee.Name == "" {
+			return nil, fmt.Errorf("%w: employee %q is unknown and has no name", ErrInvalidEvent, event.Employee.Mail)
+		}
+		person = &models.User{}
+	}
+	updateContact(&person.Contact, event.Employee)
+	organizationID := event.OrganizationID
+	person.OrganizationID = &organizationID
+	return person, tx.SavePerson(person)
+}
+
+// leave deactivates the person, revokes their calendar token and removes them from every shift schedule
+func leave(tx *repository.EmployeeRepository, event Event) (*models.User, error) {
+	person, err := tx.FindPerson(event.Employee.ID, event.Employee.Mail)
+	if err != nil || person == nil {
+		return nil, err // unknown people have nothing to leave
+	}
+	if _, err := tx.Unassign(person.ID, event.Effective(), nil, changed); err != nil {
+		return nil, err
+	}
+	if err := tx.RevokeCalendarToken(person.ID); err != nil {
+		return nil, err
+	}
+	if person.DeletedAt.Valid {
+		return person, nil
+	}
+	return person, tx.Deactivate(person)
+}
+
+// transfer moves the person to the new organization, revoking their calendar token, and removes them from
+// the shift schedules of the previous one, or of every other organization when it is not given
+func transfer(tx *repository.EmployeeRepository, event Event) (*models.User, error) {
+	person, err := tx.FindPerson(event.Employee.ID, event.Employee.Mail)
+	if err != nil {
+		return nil, err
+	}
+	if person == nil {
+		return join(tx, event) // transferred before the joiner event reached us
+	}
+	if err := checkOrganization(tx, event.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	scope := func(query *gorm.DB) *gorm.DB {
+		if event.FromOrganizationID != nil {
+			return query.Where("organization_id = ?", *event.FromOrganizationID)
+		}
+		return query.Where("organization_id IS DISTINCT FROM ?", event.OrganizationID)
+	}
+	if _, err := tx.Unassign(person.ID, event.Effective(), scope, changed); err != nil {
+		return nil, err
+	}
+	if person.OrganizationID == nil || *person.OrganizationID != event.OrganizationID {
+		if err := tx.RevokeCalendarToken(person.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	updateContact(&person.Contact, event.Employee)
+	organizationID := event.OrganizationID
+	person.OrganizationID = &organizationID
+	return person, tx.SavePerson(person)
+}
+
+// changed returns the outbox event of a shift schedule changed by an HR event
+func changed(before *models.ShiftSchedule) repository.EventFunc {
+	return func(schedule *models.ShiftSchedule) (*models.OutboxEvent, error) {
+		return events.New(events.ShiftScheduleUpdated, actor, before, schedule).Outbox()
+	}
+}
+
+func checkOrganization(tx *repository.EmployeeRepository, id uint) error {
+	exists, err := tx.HasOrganization(id)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%w: organization %d is unknown", ErrInvalidEvent, id)
+	}
+	return nil
+}
+
+// the details given by the HR platform win over the stored ones
+func updateContact(contact *models.Contact, employee Employee) {
+	if employee.Name != "" {
+		contact.Name = employee.Name
+	}
+	if employee.Mail != "" {
+		contact.Mail = employee.Mail
+	}
+	if employee.Phone != "" {
+		contact.Phone = employee.Phone
+	}
+}
+
+// deadLetter publishes the message to the dead letter topic with the reason it cannot be applied
+func (w *Worker) deadLetter(ctx context.Context, message broker.Message, reason error) error {
+	headers := map[string]string{}
+	for key, value := range message.Headers {
+		headers[key] = value
+	}
+	headers[HeaderDeadLetterReason] = reason.Error()
+	headers[HeaderDeadLetterTopic] = message.Topic
+	headers[HeaderDeadLetterPartition] = strconv.Itoa(message.Partition)
+	headers[HeaderDeadLetterOffset] = strconv.FormatInt(message.Offset, 10)
+
+	deadLetter := broker.Message{Topic: w.deadLetterTopic, Key: message.Key, Value: message.Value, Headers: headers}
+	if err := w.broker.Publish(ctx, deadLetter); err != nil {
+		logger.CLogger.Errorf("Cannot dead letter HR event at %s/%d/%d: %v", message.Topic, message.Partition, message.Offset, err)
+		return err
+	}
+	logger.CLogger.Warnf("HR event at %s/%d/%d dead lettered: %v", message.Topic, message.Partition, message.Offset, reason)
+	return nil
+}
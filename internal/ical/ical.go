@@ -0,0 +1,187 @@
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ProductID identifies the shift service as the producer of the calendars
+const ProductID = "-//shyft//shift service//EN"
+
+const (
+	dateTimeLayout = "20060102T150405"
+	utcLayout      = "20060102T150405Z"
+	lineLength     = 75 // octets, continuation lines start with a space
+)
+
+// Event is a VEVENT of a calendar, Start and End are written in the time zone of Start
+type Event struct {
+	UID          string
+	Sequence     int
+	Start        time.Time
+	End          time.Time
+	Summary      string
+	Description  string
+	LastModified time.Time
+}
+
+// Calendar is an RFC 5545 calendar of events
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+// Marshal renders the calendar with a VTIMEZONE for every time zone the events are written in
+func (c Calendar) Marshal(now time.Time) []byte {
+	var b bytes.Buffer
+	line := func(format string, args ...interface{}) {
+		writeLine(&b, fmt.Sprintf(format, args...))
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:%s", ProductID)
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	if c.Name != "" {
+		line("X-WR-CALNAME:%s", escape(c.Name))
+	}
+
+	// Step 1: Describe the time zones the events are written in, over the period of the events
+	zones := map[string]*time.Location{}
+	var from, to time.Time
+	for _, event := range c.Events {
+		loc := event.Start.Location()
+		if loc != time.UTC {
+			zones[loc.String()] = loc
+		}
+		if from.IsZero() || event.Start.Before(from) {
+			from = event.Start
+		}
+		if to.IsZero() || event.End.After(to) {
+			to = event.End
+		}
+	}
+	names := make([]string, 0, len(zones))
+	for name := range zones {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, l := range timezone(zones[name], from, to) {
+			line("%s", l)
+		}
+	}
+
+	// Step 2: Write the events
+	for _, event := range c.Events {
+		line("BEGIN:VEVENT")
+		line("UID:%s", escape(event.UID))
+		line("SEQUENCE:%d", event.Sequence)
+		line("DTSTAMP:%s", now.UTC().Format(utcLayout))
+		line("DTSTART%s", dateTime(event.Start))
+		line("DTEND%s", dateTime(event.End.In(event.Start.Location())))
+		line("SUMMARY:%s", escape(event.Summary))
+		if event.Description != "" {
+			line("DESCRIPTION:%s", escape(event.Description))
+		}
+		if !event.LastModified.IsZero() {
+			line("LAST-MODIFIED:%s", event.LastModified.UTC().Format(utcLayout))
+		}
+		line("TRANSP:OPAQUE")
+		line("END:VEVENT")
+	}
+
+	line("END:VCALENDAR")
+	return b.Bytes()
+}
+
+// dateTime writes the property value of t with its TZID, or in UTC
+func dateTime(t time.Time) string {
+	if t.Location() == time.UTC {
+		return ":" + t.Format(utcLayout)
+	}
+	return fmt.Sprintf(";TZID=%s:%s", t.Location().String(), t.Format(dateTimeLayout))
+}
+
+// timezone describes loc as a VTIMEZONE with the offset transitions between from and to, found by
+// scanning the period day by day and narrowing every change down to the second
+func timezone(loc *time.Location, from, to time.Time) []string {
+	lines := []string{"BEGIN:VTIMEZONE", "TZID:" + loc.String()}
+
+	// component describes the offset in effect from at on, starting at the local time dtstart
+	component := func(at time.Time, offsetFrom int, dtstart string) {
+		name, offset := at.In(loc).Zone()
+		kind := "STANDARD"
+		if at.In(loc).IsDST() {
+			kind = "DAYLIGHT"
+		}
+		lines = append(lines,
+			"BEGIN:"+kind,
+			"DTSTART:"+dtstart,
+			"TZOFFSETFROM:"+offsetString(offsetFrom),
+			"TZOFFSETTO:"+offsetString(offset),
+			"TZNAME:"+name,
+			"END:"+kind,
+		)
+	}
+
+	start := from.AddDate(0, 0, -1)
+	_, offset := start.In(loc).Zone()
+	component(start, offset, "19700101T000000")
+	for day := start; day.Before(to); day = day.AddDate(0, 0, 1) {
+		next := day.AddDate(0, 0, 1)
+		_, nextOffset := next.In(loc).Zone()
+		if nextOffset == offset {
+			continue
+		}
+		// binary search the first second in the new offset
+		low, high := day, next
+		for high.Sub(low) > time.Second {
+			middle := low.Add(high.Sub(low) / 2)
+			if _, o := middle.In(loc).Zone(); o == offset {
+				low = middle
+			} else {
+				high = middle
+			}
+		}
+		transition := high.Truncate(time.Second)
+		// DTSTART is the local time of the transition in the offset before it
+		component(transition, offset, transition.In(time.FixedZone("", offset)).Format(dateTimeLayout))
+		offset = nextOffset
+	}
+
+	return append(lines, "END:VTIMEZONE")
+}
+
+func offsetString(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds%3600/60)
+}
+
+// escape escapes a TEXT value
+func escape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(value)
+}
+
+// writeLine writes a content line folded at 75 octets without splitting UTF-8 characters, ended by CRLF
+func writeLine(b *bytes.Buffer, line string) {
+	for length := lineLength; len(line) > length; length = lineLength - 1 {
+		cut := length
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,183 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("cannot load time zone %s: %v", name, err)
+	}
+	return loc
+}
+
+// unfold joins the folded content lines of a calendar
+func unfold(data []byte) []string {
+	return strings.Split(strings.TrimSuffix(strings.ReplaceAll(string(data), "\r\n ", ""), "\r\n"), "\r\n")
+}
+
+func TestMarshal(t *testing.T) {
+	berlin := mustLoad(t, "Europe/Berlin")
+	now := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		calendar Calendar
+		contains []string
+		excludes []string
+	}{
+		{
+			name: "utc event",
+			calendar: Calendar{Name: "On call", Events: []Event{{
+				UID:     "shift-1-0@shyft",
+				Start:   time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC),
+				End:     time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC),
+				Summary: "On call",
+			}}},
+			contains: []string{
+				"BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:" + ProductID, "X-WR-CALNAME:On call",
+				"UID:shift-1-0@shyft", "SEQUENCE:0", "DTSTAMP:20240301T080000Z",
+				"DTSTART:20240304T090000Z", "DTEND:20240305T090000Z", "SUMMARY:On call", "END:VCALENDAR",
+			},
+			excludes: []string{"BEGIN:VTIMEZONE", "DESCRIPTION:", "LAST-MODIFIED:"},
+		},
+		{
+			name: "text values are escaped",
+			calendar: Calendar{Events: []Event{{
+				UID:         "a;b",
+				Start:       time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC),
+				End:         time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC),
+				Summary:     `Ops, backend; on call \ primary`,
+				Description: "first line\nsecond line",
+			}}},
+			contains: []string{
+				`UID:a\;b`,
+				`SUMMARY:Ops\, backend\; on call \\ primary`,
+				`DESCRIPTION:first line\nsecond line`,
+			},
+			excludes: []string{"X-WR-CALNAME:"},
+		},
+		{
+			name: "events across a daylight saving time change",
+			calendar: Calendar{Events: []Event{{
+				UID:          "shift-2-3@shyft",
+				Sequence:     2,
+				Start:        time.Date(2024, 3, 30, 9, 0, 0, 0, berlin),
+				End:          time.Date(2024, 4, 1, 9, 0, 0, 0, berlin),
+				Summary:      "Weekend",
+				LastModified: time.Date(2024, 2, 1, 12, 0, 0, 0, berlin),
+			}}},
+			contains: []string{
+				"BEGIN:VTIMEZONE", "TZID:Europe/Berlin",
+				"BEGIN:STANDARD", "TZOFFSETTO:+0100", "TZNAME:CET",
+				"BEGIN:DAYLIGHT", "DTSTART:20240331T020000", "TZOFFSETFROM:+0100", "TZOFFSETTO:+0200", "TZNAME:CEST",
+				"END:VTIMEZONE",
+				"DTSTART;TZID=Europe/Berlin:20240330T090000", "DTEND;TZID=Europe/Berlin:20240401T090000",
+				"SEQUENCE:2", "LAST-MODIFIED:20240201T110000Z",
+			},
+		},
+		{
+			name: "the end is written in the time zone of the start",
+			calendar: Calendar{Events: []Event{{
+				UID:     "override-7@shyft",
+				Start:   time.Date(2024, 6, 3, 9, 0, 0, 0, berlin),
+				End:     time.Date(2024, 6, 3, 17, 0, 0, 0, time.UTC),
+				Summary: "Override",
+			}}},
+			contains: []string{"DTSTART;TZID=Europe/Berlin:20240603T090000", "DTEND;TZID=Europe/Berlin:20240603T190000"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.calendar.Marshal(now)
+			if !bytes.HasSuffix(data, []byte("END:VCALENDAR\r\n")) {
+				t.Fatalf("calendar does not end with END:VCALENDAR and CRLF: %q", data)
+			}
+			lines := map[string]bool{}
+			for _, line := range unfold(data) {
+				lines[line] = true
+			}
+			for _, want := range tt.contains {
+				if !lines[want] {
+					t.Errorf("missing line %q in\n%s", want, data)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				for line := range lines {
+					if strings.HasPrefix(line, unwanted) {
+						t.Errorf("unexpected line %q in\n%s", line, data)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestWriteLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		line  string
+		lines int
+	}{
+		{"short", "SUMMARY:On call", 1},
+		{"exactly 75 octets", "SUMMARY:" + strings.Repeat("a", 67), 1},
+		{"76 octets", "SUMMARY:" + strings.Repeat("a", 68), 2},
+		{"long", "DESCRIPTION:" + strings.Repeat("abcdefghij", 20), 3},
+		{"multibyte characters", "SUMMARY:" + strings.Repeat("nöbet ğ ü ş ", 12), 3},
+		{"emoji", "SUMMARY:" + strings.Repeat("📟", 40), 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			writeLine(&b, tt.line)
+			out := b.String()
+			if !strings.HasSuffix(out, "\r\n") {
+				t.Fatalf("line does not end with CRLF: %q", out)
+			}
+			physical := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+			if len(physical) != tt.lines {
+				t.Errorf("got %d physical lines, want %d: %q", len(physical), tt.lines, physical)
+			}
+			for i, p := range physical {
+				if len(p) > lineLength {
+					t.Errorf("physical line %d has %d octets, more than %d", i, len(p), lineLength)
+				}
+				if i > 0 && !strings.HasPrefix(p, " ") {
+					t.Errorf("continuation line %d does not start with a space: %q", i, p)
+				}
+				if !utf8.ValidString(strings.TrimPrefix(p, " ")) {
+					t.Errorf("physical line %d splits a UTF-8 character: %q", i, p)
+				}
+			}
+			if got := unfold(b.Bytes())[0]; got != tt.line {
+				t.Errorf("unfolded line = %q, want %q", got, tt.line)
+			}
+		})
+	}
+}
+
+func TestOffsetString(t *testing.T) {
+	tests := []struct {
+		seconds int
+		want    string
+	}{
+		{0, "+0000"},
+		{3 * 3600, "+0300"},
+		{-5 * 3600, "-0500"},
+		{5*3600 + 30*60, "+0530"},
+		{-(9*3600 + 30*60), "-0930"},
+	}
+	for _, tt := range tests {
+		if got := offsetString(tt.seconds); got != tt.want {
+			t.Errorf("offsetString(%d) = %q, want %q", tt.seconds, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+)
+
+// CalendarToken authenticates the iCalendar feeds of a person until it expires, it carries the role the token
+// was issued with
+type CalendarToken struct {
+	ID             uint      `json:"id"`
+	CreatedAt      time.Time `json:"CreatedAt"`
+	UpdatedAt      time.Time `json:"UpdatedAt"`
+	PersonID       uint      `json:"person_id" gorm:"not null;"`
+	TokenHash      string    `json:"-" gorm:"not null;"` // SHA-256 of the token, the token itself is not stored
+	Role           string    `json:"role" gorm:"not null;"`
+	Mail           string    `json:"mail" gorm:"default:null"`
+	OrganizationID int       `json:"organization_id" gorm:"not null;"`
+	ExpiresAt      time.Time `json:"expires_at" gorm:"not null;"`
+}
+
+// TableName overrides the table name used by CalendarToken to `calendar_tokens`
+func (t CalendarToken) TableName() string {
+	return "calendar_tokens"
+}
+
+// Claims returns the claims the feeds of the token are authorized with, the person's current mail and
+// organization with the role the token was issued with
+func (t CalendarToken) Claims(person *User) *Claims {
+	claims := &Claims{UserID: int(person.ID), Mail: person.Mail, Role: t.Role}
+	if person.OrganizationID != nil {
+		claims.OrganizationID = int(*person.OrganizationID)
+	}
+	return claims
+}
+
+// CalendarEvent keeps the SEQUENCE of a published calendar event, bumped whenever its fingerprint changes
+type CalendarEvent struct {
+	UID         string    `json:"uid" gorm:"primaryKey"`
+	Fingerprint string    `json:"fingerprint" gorm:"not null;"`
+	Sequence    int       `json:"sequence" gorm:"not null; default:0"`
+	CreatedAt   time.Time `json:"CreatedAt"`
+	UpdatedAt   time.Time `json:"UpdatedAt"`
+}
+
+// TableName overrides the table name used by CalendarEvent to `calendar_events`
+func (e CalendarEvent) TableName() string {
+	return "calendar_events"
+}
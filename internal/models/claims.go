@@ -0,0 +1,15 @@
+package models
+
+import (
+	"github.com/golang-jwt/jwt"
+)
+
+// Claims are the JWT claims issued to the callers of the shift service
+type Claims struct {
+	UserID         int    `json:"user_id"`
+	Name           string `json:"name"`
+	Mail           string `json:"mail"`
+	Role           string `json:"role"`
+	OrganizationID int    `json:"org_id"`
+	jwt.StandardClaims
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+)
+
+// Conflict types
+const (
+	ConflictDoubleBooking = "double_booking" // a person is on overlapping shifts of two shift schedules
+	ConflictOverlap       = "overlap"        // two shifts of a shift schedule overlap
+	ConflictOutOfRange    = "out_of_range"   // a shift is outside of the shift schedule start and end date
+)
+
+// ConflictShift is a shift taking part in a conflict
+type ConflictShift struct {
+	ShiftScheduleID uint   `json:"shift_schedule_id"`
+	Alias           string `json:"alias"`
+	ShiftRef
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	User  Contact   `json:"user"`
+}
+
+// Conflict is a scheduling problem found by conflict detection
+type Conflict struct {
+	Type    string          `json:"type"` // double_booking, overlap, out_of_range
+	Message string          `json:"message"`
+	Shifts  []ConflictShift `json:"shifts"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Contact holds the contact details shared by organizations, managers and users
+type Contact struct {
+	ID          uint   `json:"id" gorm:"primaryKey"`
+	Name        string `json:"name" gorm:"not null;"`
+	Mail        string `json:"mail" gorm:"default:null"`
+	Phone       string `json:"phone" gorm:"default:null"`
+	Description string `json:"description" gorm:"default:null"`
+}
+
+// Projection returns the contact in the shape embedded into shift schedule JSONB columns
+func (c Contact) Projection() map[string]interface{} {
+	return map[string]interface{}{
+		"id":          c.ID,
+		"name":        c.Name,
+		"mail":        c.Mail,
+		"phone":       c.Phone,
+		"description": c.Description,
+	}
+}
+
+// PersonKey identifies the person of the contact across shift schedules: by id, or by the case insensitive
+// mail address of people embedded without one. It is empty when neither is known.
+func (c Contact) PersonKey() string {
+	if c.ID != 0 {
+		return fmt.Sprintf("id:%d", c.ID)
+	}
+	if c.Mail != "" {
+		return "mail:" + strings.ToLower(c.Mail)
+	}
+	return ""
+}
+
+// ContactFromJSON reads a contact from a shift schedule JSONB entry
+func ContactFromJSON(entry map[string]interface{}) Contact {
+	var contact Contact
+	if id, ok := JSONInt(entry["id"]); ok && id > 0 {
+		contact.ID = uint(id)
+	}
+	contact.Name, _ = entry["name"].(string)
+	contact.Mail, _ = entry["mail"].(string)
+	contact.Phone, _ = entry["phone"].(string)
+	contact.Description, _ = entry["description"].(string)
+	return contact
+}
@@ -0,0 +1,56 @@
+package models
+
+// ContactListParams are the list query parameters of users, organizations and managers
+type ContactListParams struct {
+	Page     int    `form:"page" default:"1"`
+	PageSize int    `form:"page_size" default:"10"`
+	Search   string `form:"search"`
+
+	SortBy    string `form:"sort_by"`
+	SortOrder string `form:"sort_order"`
+
+	OnlyActive *bool  `form:"active" default:"true"`
+	Name       string `form:"name"`
+	Mail       string `form:"mail"`
+	Phone      string `form:"phone"`
+
+	OrganizationID *int `form:"organization_id"` // managers only
+}
+
+func (p *ContactListParams) GetSortString() string {
+	if p.SortBy == "" {
+		p.SortBy = "created_at"
+	}
+	if p.SortOrder == "" {
+		p.SortOrder = "DESC"
+	}
+
+	allowedColumns := map[string]string{
+		"ID":         "id",
+		"id":         "id",
+		"name":       "name",
+		"mail":       "mail",
+		"phone":      "phone",
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	}
+
+	allowedOrders := map[string]bool{
+		"ASC":  true,
+		"DESC": true,
+		"asc":  true,
+		"desc": true,
+	}
+
+	column, exists := allowedColumns[p.SortBy]
+	if !exists {
+		column = "created_at"
+	}
+
+	order := "DESC"
+	if allowedOrders[p.SortOrder] {
+		order = p.SortOrder
+	}
+
+	return column + " " + order
+}
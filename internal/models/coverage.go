@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+)
+
+// CoverageGap is a period of a shift schedule nobody is assigned to
+type CoverageGap struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Hours float64   `json:"hours"`
+}
+
+// CoverageReport lists the coverage gaps of a shift schedule in a period
+type CoverageReport struct {
+	ShiftScheduleID uint          `json:"shift_schedule_id"`
+	Alias           string        `json:"alias"`
+	From            time.Time     `json:"from"`
+	To              time.Time     `json:"to"`
+	UncoveredHours  float64       `json:"uncovered_hours"`
+	Gaps            []CoverageGap `json:"gaps"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// HolidayDateLayout is the layout holiday dates are written with
+const HolidayDateLayout = "2006-01-02"
+
+// Holiday is a public holiday of an organization's calendar
+type Holiday struct {
+	ID             uint           `json:"id"`
+	CreatedAt      time.Time      `json:"CreatedAt"`
+	UpdatedAt      time.Time      `json:"UpdatedAt"`
+	DeletedAt      gorm.DeletedAt `json:"deleted_at" gorm:"index" swaggerignore:"true"`
+	OrganizationID uint           `json:"organization_id" gorm:"not null;"`
+	Date           time.Time      `json:"date" gorm:"type:date;not null;"` // midnight UTC of the holiday day
+	Name           string         `json:"name" gorm:"not null;"`
+}
+
+// TableName overrides the table name used by Holiday to `holidays`
+func (h Holiday) TableName() string {
+	return "holidays"
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// HREvent is an event of the HR platform that was applied
+type HREvent struct {
+	EventID     string    `json:"event_id" gorm:"primaryKey"`
+	EventType   string    `json:"event_type" gorm:"not null;"`
+	PersonID    *uint     `json:"person_id" gorm:"default:null"`
+	ProcessedAt time.Time `json:"processed_at" gorm:"not null;"`
+}
+
+// TableName overrides the table name used by HREvent to `hr_events`
+func (e HREvent) TableName() string {
+	return "hr_events"
+}
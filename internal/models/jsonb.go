@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"strconv"
 )
 
 // for support jsonb in postgres, create interface
@@ -22,3 +23,52 @@ func (j *JSONB) Scan(value interface{}) error {
 	}
 	return json.Unmarshal(bytes, j) // Remove the "&" here
 }
+
+// JSONInt converts a decoded JSON number (or numeric string) to int
+func JSONInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case float64:
+		return int(v), true
+	case int:
+		return v, true
+	case uint:
+		return int(v), true
+	case json.Number:
+		n, err := v.Int64()
+		return int(n), err == nil
+	case string:
+		n, err := strconv.Atoi(v)
+		return n, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// Copy returns a deep copy of the decoded JSON, nested objects and arrays are copied too
+func (j JSONB) Copy() JSONB {
+	if j == nil {
+		return nil
+	}
+	return copyJSON([]interface{}(j)).([]interface{})
+}
+
+func copyJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			copied[key] = copyJSON(item)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = copyJSON(item)
+		}
+		return copied
+	case JSONB:
+		return v.Copy()
+	default:
+		return v
+	}
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Leave types
+const (
+	LeaveVacation = "vacation"
+	LeaveSick     = "sick"
+	LeaveTraining = "training"
+)
+
+// Leave statuses
+const (
+	LeavePending  = "pending"  // waiting for approval
+	LeaveApproved = "approved" // the person is unavailable during the leave
+	LeaveRejected = "rejected"
+)
+
+// Leave is a period a person is unavailable for shifts once approved
+type Leave struct {
+	ID           uint           `json:"id"`
+	CreatedAt    time.Time      `json:"CreatedAt"`
+	UpdatedAt    time.Time      `json:"UpdatedAt"`
+	DeletedAt    gorm.DeletedAt `json:"deleted_at" gorm:"index" swaggerignore:"true"`
+	PersonID     uint           `json:"person_id" gorm:"not null;"`
+	Person       User           `json:"person" gorm:"foreignKey:PersonID" swaggerignore:"true"`
+	Type         string         `json:"type" gorm:"not null;"` // vacation, sick, training
+	StartAt      time.Time      `json:"start_at" gorm:"not null;"`
+	EndAt        time.Time      `json:"end_at" gorm:"not null;"`
+	Status       string         `json:"status" gorm:"not null; default:pending"` // pending, approved, rejected
+	Reason       string         `json:"reason" gorm:"default:null"`
+	CreatedBy    *int           `json:"created_by" gorm:"default:null"` // user id of the caller who requested the leave
+	DecidedBy    *int           `json:"decided_by" gorm:"default:null"` // user id of the caller who approved or rejected the leave
+	DecidedAt    *time.Time     `json:"decided_at" gorm:"default:null"`
+	DecisionNote string         `json:"decision_note" gorm:"default:null"`
+}
+
+// TableName overrides the table name used by Leave to `leaves`
+func (l Leave) TableName() string {
+	return "leaves"
+}
+
+// IsLeaveType reports whether value is a known leave type
+func IsLeaveType(value string) bool {
+	return value == LeaveVacation || value == LeaveSick || value == LeaveTraining
+}
+
+// LeaveConflict is a shift assigned to a person on an approved leave
+type LeaveConflict struct {
+	ShiftScheduleID uint `json:"shift_schedule_id"`
+	ShiftRef
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	User      Contact   `json:"user"`
+	LeaveID   uint      `json:"leave_id"`
+	LeaveType string    `json:"leave_type"`
+}
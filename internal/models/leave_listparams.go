@@ -0,0 +1,14 @@
+package models
+
+import (
+	"time"
+)
+
+// LeaveListParams filter the leaves listed by the leave endpoints
+type LeaveListParams struct {
+	UserID *uint      `form:"user_id"`
+	Status string     `form:"status"` // pending, approved, rejected
+	Type   string     `form:"type"`   // vacation, sick, training
+	From   *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To     *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
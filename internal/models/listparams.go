@@ -30,6 +30,7 @@ type ListParams struct {
 	ShiftEnd          string `form:"shift_end"`
 	ShiftUser         string `form:"shift_user"`
 	RangeYear         *int   `form:"range_year"`
+	TimeZone          string `form:"tz"` // IANA time zone range_year is taken in, defaults to the time zone of every schedule
 }
 
 func (p *ListParams) GetSortString() string {
@@ -1,9 +1,31 @@
 package models
 
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
 type Manager struct {
-	ID          int    `json:"id"`
-	Name        string `json:"name"`
-	Mail        string `json:"mail"`
-	Phone       string `json:"phone"`
-	Description string `json:"description"`
+	Contact
+	OrganizationID *uint          `json:"organization_id" gorm:"default:null"`
+	CreatedAt      time.Time      `json:"CreatedAt"`
+	UpdatedAt      time.Time      `json:"UpdatedAt"`
+	DeletedAt      gorm.DeletedAt `json:"deleted_at" gorm:"index" swaggerignore:"true"`
+
+	// Person (user) the manager is. Callers are matched against it by the user id of their token, manager ids
+	// and user ids are different sequences.
+	PersonID *uint `json:"person_id" gorm:"default:null"`
+}
+
+// TableName overrides the table name used by Manager to `managers`
+func (m Manager) TableName() string {
+	return "managers"
+}
+
+// Projection returns the manager in the shape embedded into the shift schedule `manager` JSONB column
+func (m Manager) Projection() map[string]interface{} {
+	projection := m.Contact.Projection()
+	projection["person_id"] = m.PersonID
+	return projection
 }
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// Notification statuses
+const (
+	NotificationSending = "sending" // claimed by a replica, left as is when it stops while sending
+	NotificationSent    = "sent"    // accepted by the channel
+	NotificationFailed  = "failed"  // attempted again while the shift is upcoming, until the last attempt
+)
+
+// Notification is a shift reminder or handover notice sent to a person through a channel
+type Notification struct {
+	ID              uint64     `json:"id"`
+	CreatedAt       time.Time  `json:"CreatedAt"`
+	UpdatedAt       time.Time  `json:"UpdatedAt"`
+	DedupKey        string     `json:"dedup_key" gorm:"not null;"` // identifies the notification, unique per channel
+	Channel         string     `json:"channel" gorm:"not null;"`
+	Kind            string     `json:"kind" gorm:"not null;"`
+	ShiftScheduleID uint       `json:"shift_schedule_id" gorm:"not null;"`
+	PersonID        *uint      `json:"person_id" gorm:"default:null"`
+	Recipient       string     `json:"recipient" gorm:"not null;"`
+	ShiftStart      time.Time  `json:"shift_start" gorm:"not null;"`
+	Status          string     `json:"status" gorm:"not null;"`
+	Attempts        int        `json:"attempts" gorm:"not null;"`
+	LastError       string     `json:"last_error" gorm:"not null;"`
+	SentAt          *time.Time `json:"sent_at" gorm:"default:null"`
+}
+
+// TableName overrides the table name used by Notification to `notifications`
+func (n Notification) TableName() string {
+	return "notifications"
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+// OnCallShift is the shift of a person resolved by the on-call endpoint
+type OnCallShift struct {
+	User    Contact   `json:"user"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	OnLeave bool      `json:"on_leave,omitempty"` // the person is on leave and nobody else covers the shift
+}
+
+// OnCall is the person on call for a shift schedule at a given time, followed by the next one
+type OnCall struct {
+	ShiftScheduleID uint         `json:"shift_schedule_id"`
+	Alias           string       `json:"alias"`
+	At              time.Time    `json:"at"`
+	TimeZone        string       `json:"time_zone"`
+	Current         *OnCallShift `json:"current"`     // nil when nobody is on shift at the given time
+	Next            *OnCallShift `json:"next"`        // nil when no later shift is planned
+	HandoverAt      *time.Time   `json:"handover_at"` // when the current person hands over to the next one
+}
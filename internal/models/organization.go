@@ -1,9 +1,19 @@
 package models
 
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
 type Organization struct {
-	ID          int    `json:"id"`
-	Name        string `json:"name"`
-	Mail        string `json:"mail"`
-	Phone       string `json:"phone"`
-	Description string `json:"description"`
+	Contact
+	CreatedAt time.Time      `json:"CreatedAt"`
+	UpdatedAt time.Time      `json:"UpdatedAt"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index" swaggerignore:"true"`
+}
+
+// TableName overrides the table name used by Organization to `organizations`
+func (o Organization) TableName() string {
+	return "organizations"
 }
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// OutboxEvent is an event written in the transaction of the change it describes, published to the broker
+// by the relay once the transaction is committed
+type OutboxEvent struct {
+	ID              uint64     `json:"id"`
+	EventID         string     `json:"event_id" gorm:"not null;"`
+	ShiftScheduleID uint       `json:"shift_schedule_id" gorm:"not null;"` // events of a shift schedule are published in order
+	EventType       string     `json:"event_type" gorm:"not null;"`
+	EventVersion    int        `json:"event_version" gorm:"not null;"`
+	Payload         string     `json:"payload" gorm:"type:jsonb;not null;"`
+	CreatedAt       time.Time  `json:"created_at"`
+	PublishedAt     *time.Time `json:"published_at" gorm:"default:null"`
+	Attempts        int        `json:"attempts" gorm:"not null; default:0"`
+	NextAttemptAt   time.Time  `json:"next_attempt_at" gorm:"not null;"`
+	LastError       string     `json:"last_error" gorm:"not null; default:''"`
+}
+
+// TableName overrides the table name used by OutboxEvent to `outbox_events`
+func (e OutboxEvent) TableName() string {
+	return "outbox_events"
+}
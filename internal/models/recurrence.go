@@ -0,0 +1,40 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+// RecurrenceRule describes a recurring shift pattern of a shift schedule
+type RecurrenceRule struct {
+	RRule    string   `json:"rrule"`              // RFC 5545 RRULE, e.g. "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO"
+	Start    string   `json:"start,omitempty"`    // DTSTART of the first occurrence, defaults to the schedule start date
+	Duration string   `json:"duration"`           // length of each occurrence, e.g. "8h" or "24h"
+	ExDates  []string `json:"exdates,omitempty"`  // EXDATE exceptions, start times of skipped occurrences
+	UserIDs  []int    `json:"user_ids,omitempty"` // users rotating through the occurrences, defaults to the schedule users
+}
+
+// RecurrenceRules is stored as a jsonb array in postgres
+type RecurrenceRules []RecurrenceRule
+
+// Value Marshal
+func (r RecurrenceRules) Value() (driver.Value, error) {
+	if r == nil {
+		return nil, nil
+	}
+	return json.Marshal(r)
+}
+
+// Scan Unmarshal
+func (r *RecurrenceRules) Scan(value interface{}) error {
+	if value == nil {
+		*r = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, r)
+}
@@ -9,3 +9,33 @@ type ShiftScheduleListResponse struct {
 	SortBy     string          `json:"sort_by"`
 	SortOrder  string          `json:"sort_order"`
 }
+
+type UserListResponse struct {
+	Data       []User `json:"data"`
+	Total      int64  `json:"total"`
+	Page       int    `json:"page"`
+	PageSize   int    `json:"page_size"`
+	TotalPages int    `json:"total_pages"`
+	SortBy     string `json:"sort_by"`
+	SortOrder  string `json:"sort_order"`
+}
+
+type OrganizationListResponse struct {
+	Data       []Organization `json:"data"`
+	Total      int64          `json:"total"`
+	Page       int            `json:"page"`
+	PageSize   int            `json:"page_size"`
+	TotalPages int            `json:"total_pages"`
+	SortBy     string         `json:"sort_by"`
+	SortOrder  string         `json:"sort_order"`
+}
+
+type ManagerListResponse struct {
+	Data       []Manager `json:"data"`
+	Total      int64     `json:"total"`
+	Page       int       `json:"page"`
+	PageSize   int       `json:"page_size"`
+	TotalPages int       `json:"total_pages"`
+	SortBy     string    `json:"sort_by"`
+	SortOrder  string    `json:"sort_order"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ShiftOverride is a temporary coverage: the person covers the shift schedule from StartAt to EndAt,
+// whoever the rotation assigns in that period
+type ShiftOverride struct {
+	ID              uint           `json:"id"`
+	CreatedAt       time.Time      `json:"CreatedAt"`
+	UpdatedAt       time.Time      `json:"UpdatedAt"`
+	DeletedAt       gorm.DeletedAt `json:"deleted_at" gorm:"index" swaggerignore:"true"`
+	ShiftScheduleID uint           `json:"shift_schedule_id" gorm:"not null;"`
+	PersonID        uint           `json:"person_id" gorm:"not null;"`
+	Person          User           `json:"person" gorm:"foreignKey:PersonID" swaggerignore:"true"`
+	StartAt         time.Time      `json:"start_at" gorm:"not null;"`
+	EndAt           time.Time      `json:"end_at" gorm:"not null;"`
+	Reason          string         `json:"reason" gorm:"default:null"`
+	CreatedBy       *int           `json:"created_by" gorm:"default:null"` // user id of the caller who created the override
+}
+
+// TableName overrides the table name used by ShiftOverride to `shift_overrides`
+func (o ShiftOverride) TableName() string {
+	return "shift_overrides"
+}
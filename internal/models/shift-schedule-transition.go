@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+// Shift schedule statuses
+const (
+	StatusPending   = 0 // being edited
+	StatusApproved  = 1 // approved by the manager, locked until reopened
+	StatusRejected  = 2 // rejected by the manager, can be edited and submitted again
+	StatusSubmitted = 3 // waiting for the manager approval
+)
+
+// ShiftScheduleTransition records a status change of a shift schedule
+type ShiftScheduleTransition struct {
+	ID              uint      `json:"id"`
+	ShiftScheduleID uint      `json:"shift_schedule_id" gorm:"not null;"`
+	Action          string    `json:"action" gorm:"not null;"` // submit, approve, reject, reopen
+	FromStatus      int       `json:"from_status" gorm:"not null;"`
+	ToStatus        int       `json:"to_status" gorm:"not null;"`
+	ActorID         *int      `json:"actor_id" gorm:"default:null"` // user id of the caller who changed the status
+	Reason          string    `json:"reason" gorm:"default:null"`
+	CreatedAt       time.Time `json:"CreatedAt"`
+}
+
+// TableName overrides the table name used by ShiftScheduleTransition to `shift_schedule_transitions`
+func (t ShiftScheduleTransition) TableName() string {
+	return "shift_schedule_transitions"
+}
@@ -17,14 +17,70 @@ type ShiftSchedule struct {
 	Start_Date   time.Time      `json:"start_date" gorm:"not null;"`
 	End_Date     time.Time      `json:"end_date" gorm:"not null;"`
 	Year         int            `json:"year" gorm:"not null;"`
-	Status       int            `json:"status" gorm:"not null; default:0"` // 0: pending, 1: approved, 2: rejected
+	Status       int            `json:"status" gorm:"not null; default:0"` // 0: pending, 1: approved, 2: rejected, 3: submitted
 	Organization JSONB          `json:"organization" gorm:"type:jsonb;not null"`
 	Manager      JSONB          `json:"manager" gorm:"type:jsonb;not null"`
 	Users        JSONB          `json:"users" gorm:"type:jsonb;not null"`
 	Shifts       JSONB          `json:"shifts" gorm:"type:jsonb;"`
+
+	// Owning organization and manager (first entries of the organization and manager JSONB)
+	OrganizationID *uint `json:"organization_id" gorm:"default:null"`
+	ManagerID      *uint `json:"manager_id" gorm:"default:null"`
+
+	// Recurring shift patterns, expanded into shifts on read
+	Recurrence RecurrenceRules `json:"recurrence" gorm:"type:jsonb;default:null"`
+
+	// IANA time zone the shifts, weeks and handovers of the schedule are laid out in
+	TimeZone string `json:"time_zone" gorm:"not null; default:Europe/Istanbul"`
+
+	// Shift swaps accepted by the responder also need the approval of the manager
+	SwapRequiresApproval bool `json:"swap_requires_approval" gorm:"not null; default:false"`
+
+	// Status changes, only loaded when a single shift schedule is read
+	History []ShiftScheduleTransition `json:"history,omitempty" gorm:"-"`
 }
 
 // TableName overrides the table name used by User to `users`
 func (u ShiftSchedule) TableName() string {
 	return "shift_schedule"
 }
+
+// Location returns the time zone of the shift schedule, DefaultLocation when it has none or an unknown one
+func (u ShiftSchedule) Location() *time.Location {
+	if loc, err := LoadLocation(u.TimeZone); err == nil {
+		return loc
+	}
+	return DefaultLocation
+}
+
+// Clone returns a deep copy of the shift schedule, changes to the shifts of either one do not affect the other
+func (u ShiftSchedule) Clone() *ShiftSchedule {
+	clone := u
+	clone.Organization = u.Organization.Copy()
+	clone.Manager = u.Manager.Copy()
+	clone.Users = u.Users.Copy()
+	clone.Shifts = u.Shifts.Copy()
+	if u.Recurrence != nil {
+		clone.Recurrence = make(RecurrenceRules, len(u.Recurrence))
+		for i, rule := range u.Recurrence {
+			rule.ExDates = append([]string(nil), rule.ExDates...)
+			rule.UserIDs = append([]int(nil), rule.UserIDs...)
+			clone.Recurrence[i] = rule
+		}
+	}
+	clone.History = append([]ShiftScheduleTransition(nil), u.History...)
+	return &clone
+}
+
+// OwnedBy reports whether the shift schedule lists the given organization as its only organization
+func (u ShiftSchedule) OwnedBy(organizationID int) bool {
+	if len(u.Organization) != 1 {
+		return false
+	}
+	values, ok := u.Organization[0].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	id, ok := JSONInt(values["id"])
+	return ok && id == organizationID
+}
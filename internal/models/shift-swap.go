@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+)
+
+// Shift swap request statuses
+const (
+	SwapPending   = "pending"   // waiting for the responder to accept or decline
+	SwapAccepted  = "accepted"  // accepted by the responder, waiting for the manager approval
+	SwapDeclined  = "declined"  // declined by the responder
+	SwapCancelled = "cancelled" // withdrawn by the requester
+	SwapRejected  = "rejected"  // rejected by the manager
+	SwapCompleted = "completed" // the shifts are swapped in the schedule
+)
+
+// ShiftSwap is a request of the requester to swap one of their shifts with a shift of the responder.
+// Shifts are referenced by the `id` of their entry inside the schedule `shifts` JSONB.
+type ShiftSwap struct {
+	ID               uint             `json:"id"`
+	CreatedAt        time.Time        `json:"CreatedAt"`
+	UpdatedAt        time.Time        `json:"UpdatedAt"`
+	ShiftScheduleID  uint             `json:"shift_schedule_id" gorm:"not null;"`
+	RequesterID      uint             `json:"requester_id" gorm:"not null;"`
+	RequesterShiftID int              `json:"requester_shift_id" gorm:"not null;"`
+	ResponderID      uint             `json:"responder_id" gorm:"not null;"`
+	ResponderShiftID int              `json:"responder_shift_id" gorm:"not null;"`
+	Status           string           `json:"status" gorm:"not null; default:pending"`
+	RequiresApproval bool             `json:"requires_approval" gorm:"not null; default:false"`
+	Comment          string           `json:"comment" gorm:"default:null"`
+	History          []ShiftSwapEvent `json:"history,omitempty" gorm:"foreignKey:ShiftSwapID"`
+}
+
+// TableName overrides the table name used by ShiftSwap to `shift_swaps`
+func (s ShiftSwap) TableName() string {
+	return "shift_swaps"
+}
+
+// ShiftSwapEvent records a status change of a shift swap request
+type ShiftSwapEvent struct {
+	ID          uint      `json:"id"`
+	ShiftSwapID uint      `json:"shift_swap_id" gorm:"not null;"`
+	FromStatus  string    `json:"from_status" gorm:"default:null"`
+	ToStatus    string    `json:"to_status" gorm:"not null;"`
+	ActorID     *int      `json:"actor_id" gorm:"default:null"` // user id of the caller who changed the status
+	Comment     string    `json:"comment" gorm:"default:null"`
+	CreatedAt   time.Time `json:"CreatedAt"`
+}
+
+// TableName overrides the table name used by ShiftSwapEvent to `shift_swap_events`
+func (e ShiftSwapEvent) TableName() string {
+	return "shift_swap_events"
+}
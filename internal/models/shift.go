@@ -1,8 +1,123 @@
 package models
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Shift is the shape of an entry of the shift schedule `shifts` JSONB column
 type Shift struct {
-	ID    int    `json:"id"`
-	Start string `json:"start"`
-	End   string `json:"end"`
-	User  User   `json:"user"`
+	ID    int     `json:"id"`
+	Start string  `json:"start"`
+	End   string  `json:"end"`
+	User  Contact `json:"user"`
+	Rule  *int    `json:"rule,omitempty"` // index of the recurrence rule an expanded shift comes from
+}
+
+// ShiftRef identifies a shift entry of the effective shifts of a schedule: a stored shift by its id, a
+// recurrence occurrence by its rule and occurrence index, and an override by its id. The entries keep them
+// under the `id`, `rule` and `occurrence`, and `override` keys, so they cannot be mistaken for each other.
+type ShiftRef struct {
+	ShiftID    *int `json:"shift_id,omitempty"`
+	Rule       *int `json:"rule,omitempty"`
+	Occurrence *int `json:"occurrence,omitempty"`
+	Override   *int `json:"override,omitempty"`
+}
+
+// ShiftRefOf reads the reference of a shift entry
+func ShiftRefOf(values map[string]interface{}) ShiftRef {
+	var ref ShiftRef
+	if id, ok := JSONInt(values["override"]); ok {
+		ref.Override = &id
+	} else if rule, ok := JSONInt(values["rule"]); ok {
+		occurrence, _ := JSONInt(values["occurrence"])
+		ref.Rule, ref.Occurrence = &rule, &occurrence
+	} else if id, ok := JSONInt(values["id"]); ok {
+		ref.ShiftID = &id
+	}
+	return ref
+}
+
+// String describes the shift entry in messages, e.g. "shift 3", "occurrence 5 of rule 0" or "override 12"
+func (r ShiftRef) String() string {
+	switch {
+	case r.Override != nil:
+		return fmt.Sprintf("override %d", *r.Override)
+	case r.Rule != nil && r.Occurrence != nil:
+		return fmt.Sprintf("occurrence %d of rule %d", *r.Occurrence, *r.Rule)
+	case r.ShiftID != nil:
+		return fmt.Sprintf("shift %d", *r.ShiftID)
+	}
+	return "shift"
+}
+
+// ShiftTimeLayout is the layout shift start and end times are written with
+const ShiftTimeLayout = "2006-01-02 15:04:05"
+
+// ScheduledShift is a shift of a shift schedule stored in the `shifts` table
+type ScheduledShift struct {
+	ID              uint      `json:"id"`
+	ShiftScheduleID uint      `json:"shift_schedule_id" gorm:"not null;"`
+	ShiftNo         int       `json:"shift_no" gorm:"not null;"` // id of the shift inside the schedule `shifts` JSONB
+	PersonID        *uint     `json:"person_id" gorm:"default:null"`
+	StartAt         time.Time `json:"start_at" gorm:"not null;"`
+	EndAt           time.Time `json:"end_at" gorm:"not null;"`
+	CreatedAt       time.Time `json:"CreatedAt"`
+	UpdatedAt       time.Time `json:"UpdatedAt"`
+}
+
+// TableName overrides the table name used by ScheduledShift to `shifts`
+func (s ScheduledShift) TableName() string {
+	return "shifts"
+}
+
+// ShiftSchedulePerson links a user to the shift schedules they belong to
+type ShiftSchedulePerson struct {
+	ShiftScheduleID uint `json:"shift_schedule_id" gorm:"primaryKey"`
+	PersonID        uint `json:"person_id" gorm:"primaryKey"`
+	Position        int  `json:"position" gorm:"not null; default:0"` // order of the user inside the schedule `users` JSONB
+}
+
+// TableName overrides the table name used by ShiftSchedulePerson to `shift_schedule_people`
+func (s ShiftSchedulePerson) TableName() string {
+	return "shift_schedule_people"
+}
+
+// DefaultTimeZone is the time zone of shift schedules created without one
+const DefaultTimeZone = "Europe/Istanbul"
+
+// DefaultLocation is used for shift times written without a time zone when the schedule has none
+var DefaultLocation = loadDefaultLocation()
+
+func loadDefaultLocation() *time.Location {
+	loc, err := time.LoadLocation(DefaultTimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// ErrInvalidTimeZone is returned for time zones that are not IANA time zone names
+var ErrInvalidTimeZone = errors.New("time zone must be an IANA time zone name, e.g. Europe/Istanbul")
+
+// LoadLocation loads an IANA time zone, e.g. Europe/Istanbul. The server's local time zone is not accepted.
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" || strings.EqualFold(name, "Local") {
+		return nil, ErrInvalidTimeZone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrInvalidTimeZone
+	}
+	return loc, nil
+}
+
+// ParseShiftTime parses a shift start or end time written either as RFC 3339 or as ShiftTimeLayout in loc
+func ParseShiftTime(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation(ShiftTimeLayout, value, loc)
 }
@@ -0,0 +1,131 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestShiftRefOf(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]interface{}
+		want   string
+	}{
+		{"stored shift", map[string]interface{}{"id": 3.0}, "shift 3"},
+		{"stored shift with a string id", map[string]interface{}{"id": "4"}, "shift 4"},
+		{"occurrence", map[string]interface{}{"rule": 1.0, "occurrence": 5.0}, "occurrence 5 of rule 1"},
+		{"override", map[string]interface{}{"override": 12.0}, "override 12"},
+		{"override keyed by an id too", map[string]interface{}{"override": 12.0, "id": 3.0}, "override 12"},
+		{"occurrence keyed by an id too", map[string]interface{}{"rule": 0.0, "occurrence": 2.0, "id": 3.0}, "occurrence 2 of rule 0"},
+		{"no key", map[string]interface{}{"start": "2026-03-02 09:00:00"}, "shift"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ShiftRefOf(tt.values).String(); got != tt.want {
+				t.Errorf("ShiftRefOf() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	data, _ := json.Marshal(ShiftRefOf(map[string]interface{}{"override": 12.0}))
+	if string(data) != `{"override":12}` {
+		t.Errorf("override reference marshals to %s, want only the override id", data)
+	}
+}
+
+func TestPersonKey(t *testing.T) {
+	tests := []struct {
+		contact Contact
+		want    string
+	}{
+		{Contact{ID: 3, Mail: "ada@example.com"}, "id:3"},
+		{Contact{Mail: "Ada@Example.com"}, "mail:ada@example.com"},
+		{Contact{Name: "Ada"}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.contact.PersonKey(); got != tt.want {
+			t.Errorf("PersonKey() of %+v = %q, want %q", tt.contact, got, tt.want)
+		}
+	}
+}
+
+func TestLoadLocation(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"Europe/Istanbul", false},
+		{"UTC", false},
+		{"", true},
+		{"Local", true},
+		{"local", true},
+		{"Mars/Olympus_Mons", true},
+	}
+	for _, tt := range tests {
+		loc, err := LoadLocation(tt.name)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidTimeZone) {
+				t.Errorf("LoadLocation(%q) = %v, %v, want ErrInvalidTimeZone", tt.name, loc, err)
+			}
+			continue
+		}
+		if err != nil || loc.String() != tt.name {
+			t.Errorf("LoadLocation(%q) = %v, %v", tt.name, loc, err)
+		}
+	}
+
+	if got := (ShiftSchedule{TimeZone: "Local"}).Location(); got != DefaultLocation {
+		t.Errorf("Location() of a schedule with the local time zone = %v, want %v", got, DefaultLocation)
+	}
+}
+
+func TestParseShiftTime(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"2026-03-02T09:00:00Z", time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC), false},
+		{"2026-03-02T09:00:00+03:00", time.Date(2026, 3, 2, 6, 0, 0, 0, time.UTC), false},
+		{"2026-03-02 09:00:00", time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC), false},
+		{"2026-07-02 09:00:00", time.Date(2026, 7, 2, 7, 0, 0, 0, time.UTC), false},
+		{"02.03.2026 09:00", time.Time{}, true},
+		{"", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseShiftTime(tt.value, berlin)
+		if (err != nil) != tt.wantErr || (!tt.wantErr && !got.Equal(tt.want)) {
+			t.Errorf("ParseShiftTime(%q) = %v, %v, want %v", tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestJSONInt(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  int
+		ok    bool
+	}{
+		{3.0, 3, true},
+		{4, 4, true},
+		{uint(5), 5, true},
+		{json.Number("6"), 6, true},
+		{"7", 7, true},
+		{"seven", 0, false},
+		{json.Number("7.5"), 0, false},
+		{nil, 0, false},
+		{true, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := JSONInt(tt.value)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("JSONInt(%#v) = %d, %v, want %d, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -1,7 +1,23 @@
 package models
 
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// User is a person that can be assigned to shifts
 type User struct {
-	Name  string `json:"name"`
-	Mail  string `json:"mail"`
-	Phone string `json:"phone"`
+	Contact
+	CreatedAt time.Time      `json:"CreatedAt"`
+	UpdatedAt time.Time      `json:"UpdatedAt"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index" swaggerignore:"true"`
+
+	// Organization the person belongs to
+	OrganizationID *uint `json:"organization_id" gorm:"default:null"`
+}
+
+// TableName overrides the table name used by User to `people`
+func (u User) TableName() string {
+	return "people"
 }
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Webhook delivery statuses
+const (
+	DeliveryPending   = "pending"   // waiting for its next attempt
+	DeliveryDelivered = "delivered" // answered with a 2xx status
+	DeliveryFailed    = "failed"    // gave up after the last attempt
+)
+
+// StringList is stored as a jsonb array of strings in postgres
+type StringList []string
+
+// Value Marshal
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return json.Marshal([]string{})
+	}
+	return json.Marshal(l)
+}
+
+// Scan Unmarshal
+func (l *StringList) Scan(value interface{}) error {
+	if value == nil {
+		*l = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, l)
+}
+
+// WebhookSubscription delivers the shift schedule events of an organization to a URL
+type WebhookSubscription struct {
+	ID             uint           `json:"id"`
+	CreatedAt      time.Time      `json:"CreatedAt"`
+	UpdatedAt      time.Time      `json:"UpdatedAt"`
+	DeletedAt      gorm.DeletedAt `json:"deleted_at" gorm:"index" swaggerignore:"true"`
+	OrganizationID uint           `json:"organization_id" gorm:"not null;"`
+	Url            string         `json:"url" gorm:"not null;"`
+	EventTypes     StringList     `json:"event_types" gorm:"type:jsonb;not null"` // empty subscribes to every event
+	Secret         string         `json:"-" gorm:"not null;"`                     // key of the HMAC-SHA256 signature of the deliveries
+	Description    string         `json:"description" gorm:"default:null"`
+	Active         bool           `json:"active" gorm:"not null; default:true"`
+}
+
+// TableName overrides the table name used by WebhookSubscription to `webhook_subscriptions`
+func (s WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// Accepts reports whether the subscription is subscribed to the event type
+func (s WebhookSubscription) Accepts(eventType string) bool {
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, accepted := range s.EventTypes {
+		if accepted == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is a delivery of an event to a subscription, with the outcome of its last attempt
+type WebhookDelivery struct {
+	ID             uint64     `json:"id"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	SubscriptionID uint       `json:"subscription_id" gorm:"not null;"`
+	EventID        string     `json:"event_id" gorm:"not null;"`
+	EventType      string     `json:"event_type" gorm:"not null;"`
+	Payload        string     `json:"payload" gorm:"type:jsonb;not null;"`
+	RedeliveryOf   *uint64    `json:"redelivery_of" gorm:"default:null"`       // delivery this one redelivers
+	Status         string     `json:"status" gorm:"not null; default:pending"` // pending, delivered, failed
+	Attempts       int        `json:"attempts" gorm:"not null; default:0"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at" gorm:"not null;"`
+	ResponseStatus *int       `json:"response_status" gorm:"default:null"` // HTTP status of the last attempt
+	LastError      string     `json:"last_error" gorm:"not null; default:''"`
+	DeliveredAt    *time.Time `json:"delivered_at" gorm:"default:null"`
+}
+
+// TableName overrides the table name used by WebhookDelivery to `webhook_deliveries`
+func (d WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
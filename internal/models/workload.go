@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+// Workload sums up the shifts a person worked in a period
+type Workload struct {
+	User         Contact `json:"user"`
+	Shifts       int     `json:"shifts"`
+	Hours        float64 `json:"hours"`
+	WeekendHours float64 `json:"weekend_hours"`
+	HolidayHours float64 `json:"holiday_hours"`
+	NightHours   float64 `json:"night_hours"`
+	Deviation    float64 `json:"deviation"` // hours above (or below, when negative) the team average
+}
+
+// WorkloadReport lists the workload of every person of the shift schedules in a period
+type WorkloadReport struct {
+	From         time.Time  `json:"from"`
+	To           time.Time  `json:"to"`
+	AverageHours float64    `json:"average_hours"`
+	People       []Workload `json:"people"`
+}
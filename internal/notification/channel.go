@@ -0,0 +1,179 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"shyft/internal/models"
+	"shyft/internal/webhook"
+)
+
+// Channel sends notifications to people
+type Channel interface {
+	// Name identifies the channel in the notification log
+	Name() string
+	// Reaches reports whether the channel can send to the contact
+	Reaches(contact models.Contact) bool
+	Send(ctx context.Context, message Message) error
+}
+
+// SMTPOptions of the email channel
+type SMTPOptions struct {
+	Host     string
+	Port     int // defaults to 587
+	Username string
+	Password string
+	From     string
+	Timeout  time.Duration // of sending an email, defaults to 10s
+}
+
+// SMTPChannel sends notifications as plain text emails to the mail address of the recipient
+type SMTPChannel struct {
+	options SMTPOptions
+}
+
+func NewSMTPChannel(options SMTPOptions) *SMTPChannel {
+	if options.Port <= 0 {
+		options.Port = 587
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = 10 * time.Second
+	}
+	return &SMTPChannel{options: options}
+}
+
+func (s *SMTPChannel) Name() string {
+	return "email"
+}
+
+func (s *SMTPChannel) Reaches(contact models.Contact) bool {
+	return contact.Mail != "" && !strings.ContainsAny(contact.Mail, "\r\n")
+}
+
+// Send sends the email like smtp.SendMail, within the timeout: STARTTLS when the server offers it, and
+// PLAIN authentication when a username is set
+func (s *SMTPChannel) Send(ctx context.Context, message Message) error {
+	ctx, cancel := context.WithTimeout(ctx, s.options.Timeout)
+	defer cancel()
+	address := net.JoinHostPort(s.options.Host, strconv.Itoa(s.options.Port))
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+	client, err := smtp.NewClient(conn, s.options.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.options.Host}); err != nil {
+			return err
+		}
+	}
+	if s.options.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.options.Username, s.options.Password, s.options.Host)); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(s.options.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(message.Recipient.Mail); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(s.mail(message)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// mail writes the message as a plain text email
+func (s *SMTPChannel) mail(message Message) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", s.options.From)
+	fmt.Fprintf(&b, "To: %s\r\n", message.Recipient.Mail)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject()))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&b, "Content-Type: text/plain; charset=utf-8\r\n")
+	fmt.Fprintf(&b, "\r\n")
+	b.WriteString(strings.ReplaceAll(message.Text(), "\n", "\r\n"))
+	return b.Bytes()
+}
+
+// WebhookChannel posts notifications as JSON to a url, e.g. a chat or SMS gateway, signed like the webhook
+// deliveries when a secret is set
+type WebhookChannel struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+func NewWebhookChannel(url, secret string, timeout time.Duration) *WebhookChannel {
+	return &WebhookChannel{url: url, secret: secret, client: &http.Client{Timeout: timeout}}
+}
+
+func (w *WebhookChannel) Name() string {
+	return "webhook"
+}
+
+// Reaches every contact, the receiver picks the mail address or phone number
+func (w *WebhookChannel) Reaches(contact models.Contact) bool {
+	return true
+}
+
+func (w *WebhookChannel) Send(ctx context.Context, message Message) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	timestamp := time.Now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "shyft-notifications/1")
+	request.Header.Set(webhook.HeaderEvent, "notification."+message.Kind)
+	request.Header.Set(webhook.HeaderEventID, message.ID)
+	request.Header.Set(webhook.HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	if w.secret != "" {
+		request.Header.Set(webhook.HeaderSignature, webhook.Sign(w.secret, timestamp, body))
+	}
+
+	response, err := w.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(response.Body, 1<<20))
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %d", response.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"shyft/internal/models"
+	"shyft/internal/testutil"
+	"shyft/internal/webhook"
+)
+
+func TestSMTPChannelReaches(t *testing.T) {
+	channel := NewSMTPChannel(SMTPOptions{Host: "localhost"})
+	tests := []struct {
+		contact models.Contact
+		want    bool
+	}{
+		{models.Contact{Mail: "alice@example.com"}, true},
+		{models.Contact{Phone: "+90 555 000 00 00"}, false},
+		{models.Contact{Mail: "alice@example.com\r\nBcc: eve@example.com"}, false},
+	}
+	for _, tt := range tests {
+		if got := channel.Reaches(tt.contact); got != tt.want {
+			t.Errorf("Reaches(%q) = %v, want %v", tt.contact.Mail, got, tt.want)
+		}
+	}
+}
+
+func TestSMTPChannelMail(t *testing.T) {
+	channel := NewSMTPChannel(SMTPOptions{Host: "localhost", From: "shyft@example.com"})
+	message := Message{Kind: KindReminder, Recipient: alice, Alias: "nöbet", TimeZone: "UTC", Start: testutil.At(3, 9, 0), End: testutil.At(4, 9, 0)}
+	mail := string(channel.mail(message))
+
+	header, body, ok := strings.Cut(mail, "\r\n\r\n")
+	if !ok {
+		t.Fatalf("mail has no body:\n%s", mail)
+	}
+	for _, want := range []string{"From: shyft@example.com", "To: alice@example.com", "Subject: =?utf-8?q?", "Content-Type: text/plain; charset=utf-8"} {
+		if !strings.Contains(header, want) {
+			t.Errorf("mail header is missing %q:\n%s", want, header)
+		}
+	}
+	if strings.Contains(strings.ReplaceAll(body, "\r\n", ""), "\n") {
+		t.Errorf("mail body has bare line feeds:\n%q", body)
+	}
+}
+
+func TestWebhookChannelSend(t *testing.T) {
+	tests := []struct {
+		name    string
+		secret  string
+		status  int
+		wantErr bool
+	}{
+		{"signed", "secret", http.StatusOK, false},
+		{"unsigned", "", http.StatusAccepted, false},
+		{"rejected", "secret", http.StatusBadGateway, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var request *http.Request
+			var body []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				request = r
+				body, _ = io.ReadAll(r.Body)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			message := Message{ID: "reminder:1", Kind: KindReminder, Recipient: alice}
+			err := NewWebhookChannel(server.URL, tt.secret, 0).Send(context.Background(), message)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Send() error = %v, want error %v", err, tt.wantErr)
+			}
+
+			var sent Message
+			if err := json.Unmarshal(body, &sent); err != nil || sent.ID != message.ID {
+				t.Errorf("sent %s, want the message", body)
+			}
+			if request.Header.Get(webhook.HeaderEvent) != "notification.reminder" || request.Header.Get(webhook.HeaderEventID) != message.ID {
+				t.Errorf("sent headers %v, want the event and its id", request.Header)
+			}
+			timestamp, _ := strconv.ParseInt(request.Header.Get(webhook.HeaderTimestamp), 10, 64)
+			signature := request.Header.Get(webhook.HeaderSignature)
+			if tt.secret == "" && signature != "" {
+				t.Errorf("unsigned message has signature %q", signature)
+			}
+			if tt.secret != "" && !webhook.Verify(tt.secret, timestamp, body, signature) {
+				t.Errorf("signature %q does not verify", signature)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package notification
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"shyft/internal/models"
+)
+
+// Due returns the notifications of the shifts of the schedule that are due at now. A shift that has not
+// started gets a reminder once now passes its start minus a lead time, only the shortest due lead time is
+// reminded of so a shift planned at short notice is not reminded of several times at once. Once now passes
+// the start of a shift minus handoverLead, the person on the shift it follows and the incoming person get
+// a handover notice; a shift after a gap or continuing a shift of the same person has no handover.
+func Due(schedule *models.ShiftSchedule, shifts []models.OnCallShift, now time.Time, leads []time.Duration, handoverLead time.Duration) []Message {
+	leads = append([]time.Duration{}, leads...)
+	sort.Slice(leads, func(i, j int) bool { return leads[i] < leads[j] })
+	loc := schedule.Location()
+
+	var messages []Message
+	for i := range shifts {
+		shift := &shifts[i]
+		if !shift.Start.After(now) {
+			continue
+		}
+
+		for _, lead := range leads {
+			if shift.Start.Add(-lead).After(now) {
+				continue
+			}
+			message := newMessage(schedule, KindReminder, shift.User, shift, loc)
+			message.ID = fmt.Sprintf("reminder:%d:%s:%d:%d", schedule.ID, shift.User.PersonKey(), shift.Start.Unix(), int(lead.Minutes()))
+			message.LeadMinutes = int(lead.Minutes())
+			messages = append(messages, message)
+			break
+		}
+
+		if shift.Start.Add(-handoverLead).After(now) {
+			continue
+		}
+		outgoing := handedOver(shifts, shift)
+		if outgoing == nil {
+			continue
+		}
+		handoverAt := shift.Start.In(loc)
+		id := fmt.Sprintf("handover:%d:%d:%s:%s", schedule.ID, shift.Start.Unix(), outgoing.User.PersonKey(), shift.User.PersonKey())
+
+		out := newMessage(schedule, KindHandover, outgoing.User, outgoing, loc)
+		out.ID, out.Role, out.Peer, out.HandoverAt = id+":"+RoleOutgoing, RoleOutgoing, &shift.User, &handoverAt
+		in := newMessage(schedule, KindHandover, shift.User, shift, loc)
+		in.ID, in.Role, in.Peer, in.HandoverAt = id+":"+RoleIncoming, RoleIncoming, &outgoing.User, &handoverAt
+		messages = append(messages, out, in)
+	}
+	return messages
+}
+
+// handedOver returns the shift of another person that incoming takes over: the latest started shift running
+// when incoming starts, nil when nobody is on shift then or the same person already is
+func handedOver(shifts []models.OnCallShift, incoming *models.OnCallShift) *models.OnCallShift {
+	var outgoing *models.OnCallShift
+	for i := range shifts {
+		shift := &shifts[i]
+		if shift == incoming || !shift.Start.Before(incoming.Start) || shift.End.Before(incoming.Start) {
+			continue
+		}
+		if shift.User.PersonKey() == incoming.User.PersonKey() {
+			return nil
+		}
+		if outgoing == nil || shift.Start.After(outgoing.Start) {
+			outgoing = shift
+		}
+	}
+	return outgoing
+}
+
+func newMessage(schedule *models.ShiftSchedule, kind string, recipient models.Contact, shift *models.OnCallShift, loc *time.Location) Message {
+	return Message{
+		Kind:            kind,
+		Recipient:       recipient,
+		ShiftScheduleID: schedule.ID,
+		OrganizationID:  schedule.OrganizationID,
+		Alias:           schedule.Alias,
+		TimeZone:        loc.String(),
+		Start:           shift.Start.In(loc),
+		End:             shift.End.In(loc),
+	}
+}
+
+// convert shift JSONB entries into shifts of their assignee, skipping entries without valid times or an
+// assignee identified by id or mail
+func shiftsOf(entries models.JSONB, loc *time.Location) []models.OnCallShift {
+	var shifts []models.OnCallShift
+	for _, entry := range entries {
+		values, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		user, ok := values["user"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		start, _ := values["start"].(string)
+		end, _ := values["end"].(string)
+		startAt, err := models.ParseShiftTime(start, loc)
+		if err != nil {
+			continue
+		}
+		endAt, err := models.ParseShiftTime(end, loc)
+		if err != nil || !endAt.After(startAt) {
+			continue
+		}
+		contact := models.ContactFromJSON(user)
+		if contact.PersonKey() == "" {
+			continue
+		}
+		shifts = append(shifts, models.OnCallShift{User: contact, Start: startAt, End: endAt})
+	}
+	return shifts
+}
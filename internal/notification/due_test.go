@@ -0,0 +1,140 @@
+package notification
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"shyft/internal/models"
+	"shyft/internal/testutil"
+)
+
+var (
+	alice = models.Contact{ID: 1, Name: "Alice", Mail: "alice@example.com"}
+	bob   = models.Contact{ID: 2, Name: "Bob", Phone: "+90 555 000 00 00"}
+	carol = models.Contact{Name: "Carol", Mail: "carol@example.com"}
+)
+
+// describe writes a message as "<kind> <role> <recipient> <peer> <lead minutes>"
+func describe(messages []Message) string {
+	var lines []string
+	for _, m := range messages {
+		peer := "-"
+		if m.Peer != nil {
+			peer = m.Peer.Name
+		}
+		role := m.Role
+		if role == "" {
+			role = "-"
+		}
+		lines = append(lines, fmt.Sprintf("%s %s %s %s %d", m.Kind, role, m.Recipient.Name, peer, m.LeadMinutes))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestDue(t *testing.T) {
+	schedule := &models.ShiftSchedule{ID: 5, Alias: "ops", TimeZone: "UTC"}
+	shifts := []models.OnCallShift{
+		{User: alice, Start: testutil.At(2, 9, 0), End: testutil.At(3, 9, 0)},
+		{User: bob, Start: testutil.At(3, 9, 0), End: testutil.At(4, 9, 0)},
+		{User: bob, Start: testutil.At(4, 9, 0), End: testutil.At(5, 9, 0)},   // continues the shift of the same person
+		{User: carol, Start: testutil.At(6, 9, 0), End: testutil.At(7, 9, 0)}, // after a gap
+	}
+	leads := []time.Duration{24 * time.Hour, time.Hour}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want []string
+	}{
+		{"the longest lead time", testutil.At(2, 10, 0), []string{"reminder - Bob - 1440"}},
+		{"only the shortest due lead time, with the handover", testutil.At(3, 8, 30), []string{
+			"reminder - Bob - 60",
+			"handover outgoing Alice Bob 0",
+			"handover incoming Bob Alice 0",
+		}},
+		{"no handover to the same person", testutil.At(4, 8, 30), []string{"reminder - Bob - 60"}},
+		{"no handover after a gap", testutil.At(6, 8, 30), []string{"reminder - Carol - 60"}},
+		{"started shifts", testutil.At(7, 0, 0), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := describe(Due(schedule, shifts, tt.now, leads, time.Hour))
+			if want := strings.Join(tt.want, "\n"); got != want {
+				t.Errorf("Due() =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+
+	messages := Due(schedule, shifts, testutil.At(3, 8, 30), leads, time.Hour)
+	ids := []string{
+		fmt.Sprintf("reminder:5:id:2:%d:60", testutil.At(3, 9, 0).Unix()),
+		fmt.Sprintf("handover:5:%d:id:1:id:2:outgoing", testutil.At(3, 9, 0).Unix()),
+		fmt.Sprintf("handover:5:%d:id:1:id:2:incoming", testutil.At(3, 9, 0).Unix()),
+	}
+	for i, id := range ids {
+		if messages[i].ID != id {
+			t.Errorf("message %d id = %q, want %q", i, messages[i].ID, id)
+		}
+	}
+	if again := Due(schedule, shifts, testutil.At(3, 8, 45), leads, time.Hour); again[0].ID != messages[0].ID {
+		t.Errorf("the id of a reminder changed between scans: %q and %q", messages[0].ID, again[0].ID)
+	}
+}
+
+func TestShiftsOf(t *testing.T) {
+	entries := models.JSONB{
+		map[string]interface{}{"start": "2026-03-02 09:00:00", "end": "2026-03-03 09:00:00", "user": alice.Projection()},
+		map[string]interface{}{"start": "2026-03-03T09:00:00Z", "end": "2026-03-04T09:00:00Z", "user": carol.Projection()},
+		map[string]interface{}{"start": "2026-03-04 09:00:00", "end": "2026-03-05 09:00:00", "user": models.Contact{Name: "Nobody"}.Projection()},
+		map[string]interface{}{"start": "2026-03-04 09:00:00", "end": "2026-03-04 09:00:00", "user": alice.Projection()},
+		map[string]interface{}{"start": "2026-03-04 09:00:00", "end": "later", "user": alice.Projection()},
+		map[string]interface{}{"start": "2026-03-04 09:00:00", "end": "2026-03-05 09:00:00"},
+		"not an object",
+	}
+	shifts := shiftsOf(entries, time.UTC)
+	if len(shifts) != 2 || shifts[0].User.ID != alice.ID || !shifts[0].Start.Equal(testutil.At(2, 9, 0)) || shifts[1].User.Mail != carol.Mail {
+		t.Errorf("shiftsOf() = %+v, want the shifts of alice and carol", shifts)
+	}
+}
+
+func TestMessage(t *testing.T) {
+	handoverAt := testutil.At(3, 9, 0)
+	reminder := Message{Kind: KindReminder, Recipient: alice, Alias: "ops", TimeZone: "UTC", Start: testutil.At(3, 9, 0), End: testutil.At(4, 9, 0)}
+	outgoing := Message{Kind: KindHandover, Role: RoleOutgoing, Recipient: alice, Peer: &bob, Alias: "ops", TimeZone: "UTC", Start: testutil.At(2, 9, 0), End: testutil.At(3, 9, 0), HandoverAt: &handoverAt}
+	incoming := Message{Kind: KindHandover, Role: RoleIncoming, Recipient: bob, Peer: &alice, Alias: "ops", TimeZone: "UTC", Start: testutil.At(3, 9, 0), End: testutil.At(4, 9, 0), HandoverAt: &handoverAt}
+
+	tests := []struct {
+		name    string
+		message Message
+		subject string
+		text    []string
+	}{
+		{"reminder", reminder, "Your shift on ops starts Tue 3 Mar 2026 09:00 UTC", []string{
+			"Hello Alice,", "your shift on ops starts at Tue 3 Mar 2026 09:00 UTC and ends at Wed 4 Mar 2026 09:00 UTC.", "Times are in UTC.",
+		}},
+		{"outgoing handover", outgoing, "Handover of ops to Bob at Tue 3 Mar 2026 09:00 UTC", []string{
+			"your shift on ops ends with a handover to Bob at Tue 3 Mar 2026 09:00 UTC.", "Bob can be reached at:", "  +90 555 000 00 00",
+		}},
+		{"incoming handover", incoming, "Handover of ops from Alice at Tue 3 Mar 2026 09:00 UTC", []string{
+			"Hello Bob,", "you take over ops from Alice at Tue 3 Mar 2026 09:00 UTC, your shift ends at Wed 4 Mar 2026 09:00 UTC.", "  alice@example.com",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.message.Subject(); got != tt.subject {
+				t.Errorf("Subject() = %q, want %q", got, tt.subject)
+			}
+			lines := map[string]bool{}
+			for _, line := range strings.Split(tt.message.Text(), "\n") {
+				lines[line] = true
+			}
+			for _, want := range tt.text {
+				if !lines[want] {
+					t.Errorf("Text() is missing %q:\n%s", want, tt.message.Text())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package notification
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"shyft/internal/models"
+)
+
+// Kinds of notifications
+const (
+	KindReminder = "reminder" // the shift of the recipient starts soon
+	KindHandover = "handover" // the recipient hands over the shift schedule, or takes it over
+)
+
+// Roles of the recipient of a handover notice
+const (
+	RoleOutgoing = "outgoing"
+	RoleIncoming = "incoming"
+)
+
+// timeLayout of the times written in the notifications
+const timeLayout = "Mon 2 Jan 2006 15:04 MST"
+
+// Message is a notification to a person, sent as is by the webhook channel
+type Message struct {
+	ID              string          `json:"id"` // the same for every attempt and channel of the notification
+	Kind            string          `json:"kind"`
+	Role            string          `json:"role,omitempty"` // of a handover
+	Recipient       models.Contact  `json:"recipient"`
+	Peer            *models.Contact `json:"peer,omitempty"` // the other person of a handover
+	ShiftScheduleID uint            `json:"shift_schedule_id"`
+	OrganizationID  *uint           `json:"organization_id"`
+	Alias           string          `json:"alias"`
+	TimeZone        string          `json:"time_zone"`
+	Start           time.Time       `json:"start"`                  // of the recipient's shift
+	End             time.Time       `json:"end"`                    // of the recipient's shift
+	HandoverAt      *time.Time      `json:"handover_at,omitempty"`  // of a handover
+	LeadMinutes     int             `json:"lead_minutes,omitempty"` // of a reminder
+}
+
+// Subject of the message
+func (m Message) Subject() string {
+	switch {
+	case m.Kind == KindHandover && m.Role == RoleOutgoing:
+		return fmt.Sprintf("Handover of %s to %s at %s", m.Alias, m.Peer.Name, m.HandoverAt.Format(timeLayout))
+	case m.Kind == KindHandover:
+		return fmt.Sprintf("Handover of %s from %s at %s", m.Alias, m.Peer.Name, m.HandoverAt.Format(timeLayout))
+	default:
+		return fmt.Sprintf("Your shift on %s starts %s", m.Alias, m.Start.Format(timeLayout))
+	}
+}
+
+// Text of the message
+func (m Message) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Hello %s,\n\n", m.Recipient.Name)
+	switch {
+	case m.Kind == KindHandover && m.Role == RoleOutgoing:
+		fmt.Fprintf(&b, "your shift on %s ends with a handover to %s at %s.\n", m.Alias, m.Peer.Name, m.HandoverAt.Format(timeLayout))
+	case m.Kind == KindHandover:
+		fmt.Fprintf(&b, "you take over %s from %s at %s, your shift ends at %s.\n", m.Alias, m.Peer.Name, m.HandoverAt.Format(timeLayout), m.End.Format(timeLayout))
+	default:
+		fmt.Fprintf(&b, "your shift on %s starts at %s and ends at %s.\n", m.Alias, m.Start.Format(timeLayout), m.End.Format(timeLayout))
+	}
+	if m.Peer != nil {
+		fmt.Fprintf(&b, "\n%s can be reached at:\n", m.Peer.Name)
+		for _, contact := range []string{m.Peer.Mail, m.Peer.Phone} {
+			if contact != "" {
+				fmt.Fprintf(&b, "  %s\n", contact)
+			}
+		}
+	}
+	fmt.Fprintf(&b, "\nTimes are in %s.\n", m.TimeZone)
+	return b.String()
+}
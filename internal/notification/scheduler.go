@@ -0,0 +1,173 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"shyft/internal/models"
+	"shyft/internal/override"
+	"shyft/internal/recurrence"
+	"shyft/internal/repository"
+	"shyft/pkg/logger"
+)
+
+const (
+	defaultInterval     = time.Minute
+	defaultHandoverLead = 30 * time.Minute
+	defaultMaxAttempts  = 5
+
+	// retryDelay before a failed notification is sent again, doubled per attempt
+	retryDelay = time.Minute
+	// sendLease after which a notification still sending is claimed again, it outlasts the timeout of the channels
+	sendLease = 5 * time.Minute
+)
+
+// defaultLeadTimes of the reminders
+var defaultLeadTimes = []time.Duration{24 * time.Hour, time.Hour}
+
+// Options of the scheduler, zero values take the defaults
+type Options struct {
+	Interval     time.Duration   // between two scans of the upcoming shifts
+	LeadTimes    []time.Duration // before a shift starts its reminders are sent
+	HandoverLead time.Duration   // before a handover its notices are sent
+	MaxAttempts  int             // of a notification on a channel
+}
+
+// Scheduler scans the upcoming shifts of the shift schedules and sends their reminders and handover notices
+// through every channel reaching the recipient. Each notification is claimed in the database before it is
+// sent, so it is sent once however many replicas run a scheduler.
+type Scheduler struct {
+	db            *gorm.DB
+	notifications *repository.NotificationRepository
+	channels      []Channel
+	options       Options
+}
+
+func NewScheduler(db *gorm.DB, channels []Channel, options Options) *Scheduler {
+	if options.Interval <= 0 {
+		options.Interval = defaultInterval
+	}
+	if len(options.LeadTimes) == 0 {
+		options.LeadTimes = defaultLeadTimes
+	}
+	if options.HandoverLead <= 0 {
+		options.HandoverLead = defaultHandoverLead
+	}
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = defaultMaxAttempts
+	}
+	return &Scheduler{
+		db:            db,
+		notifications: repository.NewNotificationRepository(db),
+		channels:      channels,
+		options:       options,
+	}
+}
+
+// Run scans the upcoming shifts every interval until ctx is done
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.options.Interval)
+	defer ticker.Stop()
+	for {
+		if err := s.scan(ctx, time.Now()); err != nil {
+			logger.CLogger.Errorf("Cannot scan upcoming shifts for notifications: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// scan sends the notifications due at now
+func (s *Scheduler) scan(ctx context.Context, now time.Time) error {
+	// shifts starting before the next scan may be due then, the longest lead time ahead
+	horizon := s.options.HandoverLead
+	for _, lead := range s.options.LeadTimes {
+		if lead > horizon {
+			horizon = lead
+		}
+	}
+	to := now.Add(horizon + s.options.Interval)
+
+	shiftSchedules, err := repository.NewShiftScheduleRepository(s.db).ListOverlapping(now, to, 0)
+	if err != nil {
+		return err
+	}
+	ids := make([]uint, 0, len(shiftSchedules))
+	for _, shiftSchedule := range shiftSchedules {
+		ids = append(ids, shiftSchedule.ID)
+	}
+	overrides, err := repository.NewShiftOverrideRepository(s.db).ListForSchedules(ids, now, to)
+	if err != nil {
+		return err
+	}
+
+	for i := range shiftSchedules {
+		schedule := &shiftSchedules[i]
+		if schedule.Status == models.StatusRejected {
+			continue
+		}
+		entries, err := recurrence.WithOccurrences(schedule, now, to)
+		if err != nil {
+			logger.CLogger.Warnf("Cannot expand shift schedule %d for notifications: %v", schedule.ID, err)
+			continue
+		}
+		loc := schedule.Location()
+		shifts := shiftsOf(override.Apply(entries, overrides[schedule.ID], loc), loc)
+		for _, message := range Due(schedule, shifts, now, s.options.LeadTimes, s.options.HandoverLead) {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			s.send(ctx, message, now)
+		}
+	}
+	return nil
+}
+
+// send claims the message on every channel reaching its recipient and sends it
+func (s *Scheduler) send(ctx context.Context, message Message, now time.Time) {
+	for _, channel := range s.channels {
+		if !channel.Reaches(message.Recipient) {
+			continue
+		}
+		notification := models.Notification{
+			DedupKey:        message.ID,
+			Channel:         channel.Name(),
+			Kind:            message.Kind,
+			ShiftScheduleID: message.ShiftScheduleID,
+			Recipient:       message.Recipient.Name,
+			ShiftStart:      message.Start,
+		}
+		if message.Recipient.Mail != "" {
+			notification.Recipient = message.Recipient.Mail
+		}
+		if message.Recipient.ID > 0 {
+			notification.PersonID = &message.Recipient.ID
+		}
+		claimed, err := s.notifications.Claim(&notification, now, retryDelay, sendLease, s.options.MaxAttempts)
+		if err != nil {
+			logger.CLogger.Errorf("Cannot claim notification %s: %v", message.ID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		notification.Status, notification.LastError = models.NotificationSent, ""
+		if err := channel.Send(ctx, message); err != nil {
+			notification.Status, notification.LastError = models.NotificationFailed, err.Error()
+			logger.CLogger.Warnf("Cannot send notification %s by %s (attempt %d): %v", message.ID, channel.Name(), notification.Attempts, err)
+		} else {
+			sentAt := time.Now()
+			notification.SentAt = &sentAt
+		}
+		if err := s.notifications.Record(&notification); err != nil {
+			logger.CLogger.Errorf("Cannot record notification %s: %v", message.ID, err)
+		}
+	}
+}
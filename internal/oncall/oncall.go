@@ -0,0 +1,115 @@
+package oncall
+
+import (
+	"time"
+
+	"shyft/internal/availability"
+	"shyft/internal/models"
+	"shyft/internal/override"
+	"shyft/internal/recurrence"
+)
+
+// Lookahead bounds how far after the requested time the next shift is searched for
+const Lookahead = 31 * 24 * time.Hour
+
+// Resolve returns the person on call for the schedule at the given time, together with the next
+// person and the handover time. Stored shifts and recurrence occurrences are both taken into account,
+// overrides take precedence over them, and the latest started shift wins when several shifts cover
+// the given time. Shifts of people on leave are skipped, unless nobody else is on shift. Times are
+// reported in the time zone of the schedule.
+func Resolve(schedule *models.ShiftSchedule, overrides []models.ShiftOverride, leaves *availability.Calendar, at time.Time) (models.OnCall, error) {
+	loc := schedule.Location()
+	result := models.OnCall{
+		ShiftScheduleID: schedule.ID,
+		Alias:           schedule.Alias,
+		At:              at.In(loc),
+		TimeZone:        loc.String(),
+	}
+
+	entries, err := recurrence.WithOccurrences(schedule, at, at.Add(Lookahead))
+	if err != nil {
+		return result, err
+	}
+	shifts := shiftsOf(override.Apply(entries, overrides, loc), loc)
+	for i := range shifts {
+		shifts[i].OnLeave = leaves.Unavailable(shifts[i].User.ID, shifts[i].Start, shifts[i].End)
+	}
+
+	for i := range shifts {
+		shift := &shifts[i]
+		if shift.Start.After(at) || !shift.End.After(at) {
+			continue
+		}
+		if result.Current == nil || (result.Current.OnLeave && !shift.OnLeave) ||
+			(result.Current.OnLeave == shift.OnLeave && shift.Start.After(result.Current.Start)) {
+			result.Current = shift
+		}
+	}
+
+	// The next shift is the first one starting after the current shift (or after at when nobody is on call),
+	// skipping people on leave
+	after := at
+	if result.Current != nil {
+		after = result.Current.Start
+	}
+	var nextOnLeave *models.OnCallShift
+	for i := range shifts {
+		shift := &shifts[i]
+		if shift == result.Current || !shift.Start.After(after) {
+			continue
+		}
+		if shift.OnLeave {
+			if nextOnLeave == nil || shift.Start.Before(nextOnLeave.Start) {
+				nextOnLeave = shift
+			}
+			continue
+		}
+		if result.Next == nil || shift.Start.Before(result.Next.Start) {
+			result.Next = shift
+		}
+	}
+	if result.Next == nil {
+		result.Next = nextOnLeave
+	}
+
+	switch {
+	case result.Current != nil && result.Next != nil && result.Next.Start.Before(result.Current.End):
+		handover := result.Next.Start
+		result.HandoverAt = &handover
+	case result.Current != nil:
+		handover := result.Current.End
+		result.HandoverAt = &handover
+	case result.Next != nil:
+		handover := result.Next.Start
+		result.HandoverAt = &handover
+	}
+	return result, nil
+}
+
+// convert shift JSONB entries into on-call shifts, skipping entries without valid times
+func shiftsOf(entries models.JSONB, loc *time.Location) []models.OnCallShift {
+	var shifts []models.OnCallShift
+	for _, entry := range entries {
+		values, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		start, _ := values["start"].(string)
+		end, _ := values["end"].(string)
+		startAt, err := models.ParseShiftTime(start, loc)
+		if err != nil {
+			continue
+		}
+		endAt, err := models.ParseShiftTime(end, loc)
+		if err != nil || !endAt.After(startAt) {
+			continue
+		}
+
+		shift := models.OnCallShift{Start: startAt.In(loc), End: endAt.In(loc)}
+		if user, ok := values["user"].(map[string]interface{}); ok {
+			shift.User = models.ContactFromJSON(user)
+		}
+		shifts = append(shifts, shift)
+	}
+	return shifts
+}
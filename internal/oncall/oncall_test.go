@@ -0,0 +1,102 @@
+package oncall
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"shyft/internal/availability"
+	"shyft/internal/models"
+	"shyft/internal/testutil"
+)
+
+var (
+	alice = models.Contact{ID: 1, Name: "Alice"}
+	bob   = models.Contact{ID: 2, Name: "Bob"}
+	carol = models.Contact{ID: 3, Name: "Carol"}
+	dave  = models.Contact{ID: 4, Name: "Dave"}
+)
+
+func entry(id int, start, end time.Time, user models.Contact) map[string]interface{} {
+	shift := testutil.Entry(start.Format(time.RFC3339), end.Format(time.RFC3339), user)
+	shift["id"] = id
+	return shift
+}
+
+func schedule(rules models.RecurrenceRules, extra ...interface{}) *models.ShiftSchedule {
+	shiftSchedule := testutil.Schedule("UTC", "2026-03-02 09:00:00", "2026-03-16 09:00:00", alice, bob, carol)
+	shiftSchedule.ID = 8
+	shiftSchedule.Alias = "ops"
+	shiftSchedule.Shifts = append(models.JSONB{
+		entry(0, testutil.At(2, 9, 0), testutil.At(9, 9, 0), alice),
+		entry(1, testutil.At(9, 9, 0), testutil.At(16, 9, 0), bob),
+	}, extra...)
+	shiftSchedule.Recurrence = rules
+	return shiftSchedule
+}
+
+func name(shift *models.OnCallShift) string {
+	switch {
+	case shift == nil:
+		return "nobody"
+	case shift.OnLeave:
+		return shift.User.Name + " (on leave)"
+	}
+	return shift.User.Name
+}
+
+func TestResolve(t *testing.T) {
+	onLeave := availability.NewCalendar([]models.Leave{{ID: 1, PersonID: alice.ID, Status: models.LeaveApproved, StartAt: testutil.At(3, 0, 0), EndAt: testutil.At(4, 0, 0)}})
+	daily := models.RecurrenceRules{{RRule: "FREQ=DAILY", Start: "2026-03-03 12:00:00", Duration: "2h", UserIDs: []int{3}}}
+
+	tests := []struct {
+		name      string
+		schedule  *models.ShiftSchedule
+		overrides []models.ShiftOverride
+		leaves    *availability.Calendar
+		at        time.Time
+		current   string
+		next      string
+		handover  string
+	}{
+		{"on shift", schedule(nil), nil, nil, testutil.At(3, 9, 0), "Alice", "Bob", "2026-03-09T09:00:00Z"},
+		{"at the handover", schedule(nil), nil, nil, testutil.At(9, 9, 0), "Bob", "nobody", "2026-03-16T09:00:00Z"},
+		{"before the first shift", schedule(nil), nil, nil, testutil.At(1, 9, 0), "nobody", "Alice", "2026-03-02T09:00:00Z"},
+		{"after the last shift", schedule(nil), nil, nil, testutil.At(20, 9, 0), "nobody", "nobody", ""},
+		{
+			"an override takes precedence", schedule(nil),
+			[]models.ShiftOverride{{ID: 1, Person: models.User{Contact: carol}, StartAt: testutil.At(4, 9, 0), EndAt: testutil.At(4, 10, 0)}},
+			nil, testutil.At(4, 9, 30), "Carol", "Alice", "2026-03-04T10:00:00Z",
+		},
+		{"the latest started shift wins", schedule(daily), nil, nil, testutil.At(3, 12, 30), "Carol", "Carol", "2026-03-03T14:00:00Z"},
+		{"nobody else covers a person on leave", schedule(nil), nil, onLeave, testutil.At(3, 9, 0), "Alice (on leave)", "Bob", "2026-03-09T09:00:00Z"},
+		{
+			"another person on shift covers a person on leave", schedule(nil, entry(2, testutil.At(3, 0, 0), testutil.At(4, 0, 0), dave)),
+			nil, onLeave, testutil.At(3, 9, 0), "Dave", "Bob", "2026-03-04T00:00:00Z",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Resolve(tt.schedule, tt.overrides, tt.leaves, tt.at)
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			handover := ""
+			if result.HandoverAt != nil {
+				handover = result.HandoverAt.Format(time.RFC3339)
+			}
+			got := fmt.Sprintf("%s, %s, %s", name(result.Current), name(result.Next), handover)
+			if want := fmt.Sprintf("%s, %s, %s", tt.current, tt.next, tt.handover); got != want {
+				t.Errorf("Resolve() = %s, want %s", got, want)
+			}
+			if result.ShiftScheduleID != 8 || result.Alias != "ops" || result.TimeZone != "UTC" {
+				t.Errorf("Resolve() = %+v, want the schedule id, alias and time zone", result)
+			}
+		})
+	}
+
+	invalid := schedule(models.RecurrenceRules{{RRule: "FREQ=DAILY"}})
+	if _, err := Resolve(invalid, nil, nil, testutil.At(3, 9, 0)); err == nil {
+		t.Error("Resolve() with an invalid recurrence rule error = nil, want an error")
+	}
+}
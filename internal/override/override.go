@@ -0,0 +1,91 @@
+package override
+
+import (
+	"errors"
+	"time"
+
+	"shyft/internal/models"
+)
+
+var (
+	ErrInvalidRange   = errors.New("override end must be after its start")
+	ErrOutsideOfRange = errors.New("override must fall inside the shift schedule start and end date")
+)
+
+// Validate checks that the override period is valid and falls inside the schedule start and end date
+func Validate(schedule *models.ShiftSchedule, start, end time.Time) error {
+	if !end.After(start) {
+		return ErrInvalidRange
+	}
+	if start.Before(schedule.Start_Date) || end.After(schedule.End_Date) {
+		return ErrOutsideOfRange
+	}
+	return nil
+}
+
+// Apply layers the overrides on top of the shift entries: the part of every shift covered by an
+// override is cut out, and every override becomes a shift of its own assigned to the covering person,
+// keyed by the override id, never by an `id` of the stored shifts.
+// Overrides are applied in order, so a later override wins over an earlier one it overlaps.
+func Apply(entries models.JSONB, overrides []models.ShiftOverride, loc *time.Location) models.JSONB {
+	if len(overrides) == 0 {
+		return entries
+	}
+
+	result := append(models.JSONB{}, entries...)
+	for _, o := range overrides {
+		var next models.JSONB
+		for _, entry := range result {
+			next = append(next, cut(entry, o.StartAt, o.EndAt, loc)...)
+		}
+		next = append(next, map[string]interface{}{
+			"start":    o.StartAt.In(loc).Format(time.RFC3339),
+			"end":      o.EndAt.In(loc).Format(time.RFC3339),
+			"user":     o.Person.Projection(),
+			"override": o.ID,
+		})
+		result = next
+	}
+	return result
+}
+
+// cut removes [start, end) from a shift entry, entries without valid times are kept as they are
+func cut(entry interface{}, start, end time.Time, loc *time.Location) models.JSONB {
+	values, ok := entry.(map[string]interface{})
+	if !ok {
+		return models.JSONB{entry}
+	}
+	shiftStart, _ := values["start"].(string)
+	shiftEnd, _ := values["end"].(string)
+	startAt, err := models.ParseShiftTime(shiftStart, loc)
+	if err != nil {
+		return models.JSONB{entry}
+	}
+	endAt, err := models.ParseShiftTime(shiftEnd, loc)
+	if err != nil {
+		return models.JSONB{entry}
+	}
+	if !startAt.Before(end) || !endAt.After(start) {
+		return models.JSONB{entry}
+	}
+
+	var pieces models.JSONB
+	if startAt.Before(start) {
+		pieces = append(pieces, withTimes(values, startAt, start, loc))
+	}
+	if endAt.After(end) {
+		pieces = append(pieces, withTimes(values, end, endAt, loc))
+	}
+	return pieces
+}
+
+// copy of a shift entry with other start and end times
+func withTimes(values map[string]interface{}, start, end time.Time, loc *time.Location) map[string]interface{} {
+	piece := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		piece[key] = value
+	}
+	piece["start"] = start.In(loc).Format(time.RFC3339)
+	piece["end"] = end.In(loc).Format(time.RFC3339)
+	return piece
+}
@@ -0,0 +1,125 @@
+package override
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"shyft/internal/models"
+	"shyft/internal/testutil"
+)
+
+func shift(id int, start, end time.Time, userID uint) map[string]interface{} {
+	entry := testutil.Entry(start.Format(models.ShiftTimeLayout), end.Format(models.ShiftTimeLayout), models.Contact{ID: userID})
+	entry["id"] = id
+	return entry
+}
+
+func override(id uint, start, end time.Time, personID uint) models.ShiftOverride {
+	return models.ShiftOverride{ID: id, PersonID: personID, Person: models.User{Contact: models.Contact{ID: personID}}, StartAt: start, EndAt: end}
+}
+
+func TestValidate(t *testing.T) {
+	schedule := &models.ShiftSchedule{Start_Date: testutil.At(2, 9, 0), End_Date: testutil.At(16, 9, 0)}
+	tests := []struct {
+		name       string
+		start, end time.Time
+		want       error
+	}{
+		{"inside of the schedule", testutil.At(3, 9, 0), testutil.At(4, 9, 0), nil},
+		{"the whole schedule", testutil.At(2, 9, 0), testutil.At(16, 9, 0), nil},
+		{"end before start", testutil.At(4, 9, 0), testutil.At(3, 9, 0), ErrInvalidRange},
+		{"empty", testutil.At(4, 9, 0), testutil.At(4, 9, 0), ErrInvalidRange},
+		{"before the schedule start", testutil.At(2, 8, 0), testutil.At(3, 9, 0), ErrOutsideOfRange},
+		{"after the schedule end", testutil.At(15, 9, 0), testutil.At(16, 10, 0), ErrOutsideOfRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(schedule, tt.start, tt.end); !errors.Is(err, tt.want) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	entries := models.JSONB{
+		shift(0, testutil.At(2, 9, 0), testutil.At(9, 9, 0), 1),
+		shift(1, testutil.At(9, 9, 0), testutil.At(16, 9, 0), 2),
+	}
+
+	tests := []struct {
+		name      string
+		overrides []models.ShiftOverride
+		want      []string
+	}{
+		{
+			name: "no overrides",
+			want: []string{
+				"shift 0 2026-03-02 09:00:00 2026-03-09 09:00:00 1",
+				"shift 1 2026-03-09 09:00:00 2026-03-16 09:00:00 2",
+			},
+		},
+		{
+			name:      "an override inside of a shift splits it",
+			overrides: []models.ShiftOverride{override(7, testutil.At(4, 9, 0), testutil.At(5, 9, 0), 3)},
+			want: []string{
+				"shift 0 2026-03-02T09:00:00Z 2026-03-04T09:00:00Z 1",
+				"shift 0 2026-03-05T09:00:00Z 2026-03-09T09:00:00Z 1",
+				"shift 1 2026-03-09 09:00:00 2026-03-16 09:00:00 2",
+				"override 7 2026-03-04T09:00:00Z 2026-03-05T09:00:00Z 3",
+			},
+		},
+		{
+			name:      "an override across a handover cuts both shifts",
+			overrides: []models.ShiftOverride{override(7, testutil.At(8, 9, 0), testutil.At(10, 9, 0), 3)},
+			want: []string{
+				"shift 0 2026-03-02T09:00:00Z 2026-03-08T09:00:00Z 1",
+				"shift 1 2026-03-10T09:00:00Z 2026-03-16T09:00:00Z 2",
+				"override 7 2026-03-08T09:00:00Z 2026-03-10T09:00:00Z 3",
+			},
+		},
+		{
+			name:      "an override covering a whole shift removes it",
+			overrides: []models.ShiftOverride{override(7, testutil.At(2, 9, 0), testutil.At(9, 9, 0), 3)},
+			want: []string{
+				"shift 1 2026-03-09 09:00:00 2026-03-16 09:00:00 2",
+				"override 7 2026-03-02T09:00:00Z 2026-03-09T09:00:00Z 3",
+			},
+		},
+		{
+			name: "a later override wins over an earlier one",
+			overrides: []models.ShiftOverride{
+				override(7, testutil.At(10, 9, 0), testutil.At(12, 9, 0), 3),
+				override(8, testutil.At(11, 9, 0), testutil.At(13, 9, 0), 4),
+			},
+			want: []string{
+				"shift 0 2026-03-02 09:00:00 2026-03-09 09:00:00 1",
+				"shift 1 2026-03-09T09:00:00Z 2026-03-10T09:00:00Z 2",
+				"shift 1 2026-03-13T09:00:00Z 2026-03-16T09:00:00Z 2",
+				"override 7 2026-03-10T09:00:00Z 2026-03-11T09:00:00Z 3",
+				"override 8 2026-03-11T09:00:00Z 2026-03-13T09:00:00Z 4",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := testutil.DescribeEntries(Apply(entries, tt.overrides, time.UTC))
+			if want := strings.Join(tt.want, "\n"); got != want {
+				t.Errorf("Apply() =\n%s\nwant\n%s", got, want)
+			}
+			if len(entries) != 2 || entries[0].(map[string]interface{})["start"] != "2026-03-02 09:00:00" {
+				t.Errorf("Apply() modified the given entries: %v", entries)
+			}
+		})
+	}
+}
+
+func TestApplyKeepsInvalidEntries(t *testing.T) {
+	entries := models.JSONB{"not an object", map[string]interface{}{"id": 0, "start": "soon", "end": "later"}}
+	result := Apply(entries, []models.ShiftOverride{override(7, testutil.At(2, 9, 0), testutil.At(3, 9, 0), 3)}, time.UTC)
+	if len(result) != 3 || result[0] != "not an object" || result[1].(map[string]interface{})["start"] != "soon" {
+		t.Errorf("Apply() = %v, want the invalid entries kept and the override added", result)
+	}
+}
@@ -0,0 +1,247 @@
+package policy
+
+import (
+	"strings"
+
+	"shyft/internal/models"
+)
+
+// Permission is an operation a role may be granted
+type Permission string
+
+const (
+	ReadSchedules    Permission = "schedules.read"
+	CreateSchedules  Permission = "schedules.create"
+	UpdateSchedules  Permission = "schedules.update"
+	DeleteSchedules  Permission = "schedules.delete"
+	RestoreSchedules Permission = "schedules.restore"
+	ApproveSchedules Permission = "schedules.approve"
+
+	ReadUsers    Permission = "users.read"
+	CreateUsers  Permission = "users.create"
+	UpdateUsers  Permission = "users.update"
+	DeleteUsers  Permission = "users.delete"
+	RestoreUsers Permission = "users.restore"
+
+	// ":own" organization, manager and user permissions are limited to the caller's organization
+	ReadOrganizations    Permission = "organizations.read"
+	CreateOrganizations  Permission = "organizations.create"
+	UpdateOrganizations  Permission = "organizations.update"
+	DeleteOrganizations  Permission = "organizations.delete"
+	RestoreOrganizations Permission = "organizations.restore"
+
+	ReadManagers    Permission = "managers.read"
+	CreateManagers  Permission = "managers.create"
+	UpdateManagers  Permission = "managers.update"
+	DeleteManagers  Permission = "managers.delete"
+	RestoreManagers Permission = "managers.restore"
+
+	// ":own" leave permissions are limited to the caller's own leaves and, except for leaves.create, the
+	// leaves of the people of the schedules the caller manages; all leave permissions stay within the caller's
+	// organization
+	ReadLeaves    Permission = "leaves.read"
+	CreateLeaves  Permission = "leaves.create"
+	ApproveLeaves Permission = "leaves.approve"
+	DeleteLeaves  Permission = "leaves.delete"
+)
+
+// Scope limits a granted permission to a subset of schedules
+type Scope int
+
+const (
+	ScopeNone Scope = iota // permission is not granted
+	ScopeOwn               // only schedules the caller manages (or belongs to, for reads)
+	ScopeAny               // every schedule
+)
+
+const ownSuffix = ":own"
+
+// DefaultRoles is used when no role mapping is configured
+var DefaultRoles = map[string][]string{
+	"admin": {
+		string(ReadSchedules),
+		string(CreateSchedules),
+		string(UpdateSchedules),
+		string(DeleteSchedules),
+		string(RestoreSchedules),
+		string(ApproveSchedules),
+		string(ReadUsers),
+		string(CreateUsers),
+		string(UpdateUsers),
+		string(DeleteUsers),
+		string(RestoreUsers),
+		string(ReadOrganizations),
+		string(CreateOrganizations),
+		string(UpdateOrganizations) + ownSuffix,
+		string(DeleteOrganizations) + ownSuffix,
+		string(RestoreOrganizations) + ownSuffix,
+		string(ReadManagers),
+		string(CreateManagers) + ownSuffix,
+		string(UpdateManagers) + ownSuffix,
+		string(DeleteManagers) + ownSuffix,
+		string(RestoreManagers) + ownSuffix,
+		string(ReadLeaves),
+		string(CreateLeaves),
+		string(ApproveLeaves),
+		string(DeleteLeaves),
+	},
+	"manager": {
+		string(ReadSchedules),
+		string(UpdateSchedules) + ownSuffix,
+		string(ApproveSchedules) + ownSuffix,
+		string(ReadUsers) + ownSuffix,
+		string(CreateUsers) + ownSuffix,
+		string(UpdateUsers) + ownSuffix,
+		string(ReadOrganizations),
+		string(ReadManagers),
+		string(ReadLeaves) + ownSuffix,
+		string(CreateLeaves),
+		string(ApproveLeaves) + ownSuffix,
+		string(DeleteLeaves) + ownSuffix,
+	},
+	"user": {
+		string(ReadSchedules) + ownSuffix,
+		string(ReadUsers) + ownSuffix,
+		string(ReadOrganizations),
+		string(ReadManagers),
+		string(ReadLeaves) + ownSuffix,
+		string(CreateLeaves) + ownSuffix,
+		string(DeleteLeaves) + ownSuffix,
+	},
+}
+
+// Policy maps roles to the permissions they are granted
+type Policy struct {
+	roles map[string]map[Permission]Scope
+}
+
+// New creates a policy from a role to permission mapping, e.g. {"manager": ["schedules.read", "schedules.update:own"]}
+func New(roles map[string][]string) *Policy {
+	if len(roles) == 0 {
+		roles = DefaultRoles
+	}
+
+	p := &Policy{roles: map[string]map[Permission]Scope{}}
+	for role, permissions := range roles {
+		granted := map[Permission]Scope{}
+		for _, permission := range permissions {
+			scope := ScopeAny
+			if strings.HasSuffix(permission, ownSuffix) {
+				scope = ScopeOwn
+				permission = strings.TrimSuffix(permission, ownSuffix)
+			}
+			if granted[Permission(permission)] < scope {
+				granted[Permission(permission)] = scope
+			}
+		}
+		p.roles[strings.ToLower(role)] = granted
+	}
+	return p
+}
+
+// Scope returns the scope in which role is granted permission
+func (p *Policy) Scope(role string, permission Permission) Scope {
+	return p.roles[strings.ToLower(role)][permission]
+}
+
+// Allows reports whether claims may perform permission on schedule.
+// When schedule is nil only the permission itself is checked.
+func (p *Policy) Allows(claims *models.Claims, permission Permission, schedule *models.ShiftSchedule) bool {
+	if claims == nil {
+		return false
+	}
+
+	switch p.Scope(claims.Role, permission) {
+	case ScopeAny:
+		return true
+	case ScopeOwn:
+		if schedule == nil {
+			return true
+		}
+		if IsManager(claims, schedule) {
+			return true
+		}
+		return permission == ReadSchedules && IsMember(claims, schedule)
+	default:
+		return false
+	}
+}
+
+// AllowsOrganization reports whether claims may perform permission on the given organization.
+// When organizationID is nil only a permission granted on every organization is accepted.
+func (p *Policy) AllowsOrganization(claims *models.Claims, permission Permission, organizationID *uint) bool {
+	if claims == nil {
+		return false
+	}
+
+	switch p.Scope(claims.Role, permission) {
+	case ScopeAny:
+		return true
+	case ScopeOwn:
+		return organizationID != nil && claims.OrganizationID != 0 && *organizationID == uint(claims.OrganizationID)
+	default:
+		return false
+	}
+}
+
+// AllowsPerson reports whether claims may perform permission on the given person's records (e.g. leaves)
+func (p *Policy) AllowsPerson(claims *models.Claims, permission Permission, personID uint) bool {
+	if claims == nil {
+		return false
+	}
+
+	switch p.Scope(claims.Role, permission) {
+	case ScopeAny:
+		return true
+	case ScopeOwn:
+		return claims.UserID != 0 && uint(claims.UserID) == personID
+	default:
+		return false
+	}
+}
+
+// IsManager reports whether the caller is one of the schedule's managers. Managers are matched by the person
+// they are linked to (or mail), never by their manager id: manager and user ids are different sequences.
+func IsManager(claims *models.Claims, schedule *models.ShiftSchedule) bool {
+	for _, entry := range schedule.Manager {
+		values, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		person := models.Contact{Mail: models.ContactFromJSON(values).Mail}
+		if id, ok := models.JSONInt(values["person_id"]); ok && id > 0 {
+			person.ID = uint(id)
+		}
+		if IsCaller(claims, person) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsMember reports whether the caller appears in the schedule's users
+func IsMember(claims *models.Claims, schedule *models.ShiftSchedule) bool {
+	return containsCaller(claims, schedule.Users)
+}
+
+// IsCaller reports whether the contact is the caller, matched by id or mail
+func IsCaller(claims *models.Claims, contact models.Contact) bool {
+	if claims.UserID != 0 && int(contact.ID) == claims.UserID {
+		return true
+	}
+	return claims.Mail != "" && strings.EqualFold(contact.Mail, claims.Mail)
+}
+
+// match JSONB entries by id or mail
+func containsCaller(claims *models.Claims, entries models.JSONB) bool {
+	for _, entry := range entries {
+		values, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if IsCaller(claims, models.ContactFromJSON(values)) {
+			return true
+		}
+	}
+	return false
+}
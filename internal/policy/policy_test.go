@@ -0,0 +1,159 @@
+package policy
+
+import (
+	"testing"
+
+	"shyft/internal/models"
+)
+
+func TestScope(t *testing.T) {
+	p := New(map[string][]string{
+		"Lead":    {"schedules.read", "schedules.update:own", "schedules.update", "users.read:own"},
+		"auditor": {"schedules.read:own", "schedules.read"},
+	})
+
+	tests := []struct {
+		role       string
+		permission Permission
+		want       Scope
+	}{
+		{"lead", ReadSchedules, ScopeAny},
+		{"LEAD", ReadSchedules, ScopeAny},
+		{"lead", UpdateSchedules, ScopeAny}, // the widest grant wins
+		{"lead", ReadUsers, ScopeOwn},
+		{"lead", DeleteSchedules, ScopeNone},
+		{"auditor", ReadSchedules, ScopeAny},
+		{"admin", ReadSchedules, ScopeNone}, // configured roles replace the defaults
+		{"", ReadSchedules, ScopeNone},
+	}
+	for _, tt := range tests {
+		if got := p.Scope(tt.role, tt.permission); got != tt.want {
+			t.Errorf("Scope(%q, %s) = %v, want %v", tt.role, tt.permission, got, tt.want)
+		}
+	}
+}
+
+func TestDefaultRoles(t *testing.T) {
+	p := New(nil)
+
+	tests := []struct {
+		role       string
+		permission Permission
+		want       Scope
+	}{
+		{"admin", DeleteSchedules, ScopeAny},
+		{"admin", UpdateOrganizations, ScopeOwn},
+		{"manager", ReadSchedules, ScopeAny},
+		{"manager", UpdateSchedules, ScopeOwn},
+		{"manager", DeleteSchedules, ScopeNone},
+		{"manager", UpdateUsers, ScopeOwn},
+		{"manager", DeleteUsers, ScopeNone},
+		{"manager", ApproveLeaves, ScopeOwn},
+		{"manager", CreateLeaves, ScopeAny},
+		{"user", ReadSchedules, ScopeOwn},
+		{"user", UpdateSchedules, ScopeNone},
+		{"user", ReadUsers, ScopeOwn},
+		{"user", CreateLeaves, ScopeOwn},
+		{"user", ApproveLeaves, ScopeNone},
+		{"unknown", ReadSchedules, ScopeNone},
+	}
+	for _, tt := range tests {
+		if got := p.Scope(tt.role, tt.permission); got != tt.want {
+			t.Errorf("Scope(%q, %s) = %v, want %v", tt.role, tt.permission, got, tt.want)
+		}
+	}
+}
+
+func TestAllows(t *testing.T) {
+	p := New(nil)
+	schedule := &models.ShiftSchedule{
+		Manager: models.JSONB{map[string]interface{}{"id": 1.0, "person_id": 5.0, "mail": "lead@example.com"}},
+		Users: models.JSONB{
+			map[string]interface{}{"id": 2.0, "mail": "member@example.com"},
+			map[string]interface{}{"mail": "Embedded@Example.com"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		claims     *models.Claims
+		permission Permission
+		schedule   *models.ShiftSchedule
+		want       bool
+	}{
+		{"no claims", nil, ReadSchedules, schedule, false},
+		{"any scope", &models.Claims{Role: "admin"}, DeleteSchedules, schedule, true},
+		{"not granted", &models.Claims{UserID: 1, Role: "user"}, UpdateSchedules, schedule, false},
+		{"own scope without schedule", &models.Claims{Role: "manager"}, UpdateSchedules, nil, true},
+		{"manager by person", &models.Claims{UserID: 5, Role: "manager"}, UpdateSchedules, schedule, true},
+		{"user with the manager's id", &models.Claims{UserID: 1, Role: "manager"}, UpdateSchedules, schedule, false},
+		{"manager by mail", &models.Claims{Mail: "LEAD@example.com", Role: "manager"}, UpdateSchedules, schedule, true},
+		{"another manager", &models.Claims{UserID: 9, Role: "manager"}, UpdateSchedules, schedule, false},
+		{"member reads", &models.Claims{UserID: 2, Role: "user"}, ReadSchedules, schedule, true},
+		{"member embedded by mail reads", &models.Claims{Mail: "embedded@example.com", Role: "user"}, ReadSchedules, schedule, true},
+		{"member does not update", &models.Claims{UserID: 2, Role: "manager"}, UpdateSchedules, schedule, false},
+		{"outsider reads", &models.Claims{UserID: 9, Role: "user"}, ReadSchedules, schedule, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.Allows(tt.claims, tt.permission, tt.schedule); got != tt.want {
+				t.Errorf("Allows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllowsOrganization(t *testing.T) {
+	p := New(nil)
+	own, other := uint(1), uint(2)
+
+	tests := []struct {
+		name         string
+		claims       *models.Claims
+		permission   Permission
+		organization *uint
+		want         bool
+	}{
+		{"no claims", nil, ReadUsers, &own, false},
+		{"any scope", &models.Claims{Role: "admin", OrganizationID: 1}, DeleteUsers, &other, true},
+		{"own organization", &models.Claims{Role: "manager", OrganizationID: 1}, UpdateUsers, &own, true},
+		{"another organization", &models.Claims{Role: "manager", OrganizationID: 1}, UpdateUsers, &other, false},
+		{"another organization of an admin", &models.Claims{Role: "admin", OrganizationID: 1}, UpdateOrganizations, &other, false},
+		{"no organization", &models.Claims{Role: "manager", OrganizationID: 1}, UpdateUsers, nil, false},
+		{"caller without organization", &models.Claims{Role: "manager"}, UpdateUsers, &own, false},
+		{"not granted", &models.Claims{Role: "user", OrganizationID: 1}, UpdateUsers, &own, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.AllowsOrganization(tt.claims, tt.permission, tt.organization); got != tt.want {
+				t.Errorf("AllowsOrganization() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllowsPerson(t *testing.T) {
+	p := New(nil)
+
+	tests := []struct {
+		name       string
+		claims     *models.Claims
+		permission Permission
+		personID   uint
+		want       bool
+	}{
+		{"no claims", nil, CreateLeaves, 1, false},
+		{"any scope", &models.Claims{UserID: 1, Role: "manager"}, CreateLeaves, 2, true},
+		{"own leaves", &models.Claims{UserID: 1, Role: "user"}, CreateLeaves, 1, true},
+		{"leaves of someone else", &models.Claims{UserID: 1, Role: "user"}, CreateLeaves, 2, false},
+		{"caller without id", &models.Claims{Role: "user"}, CreateLeaves, 0, false},
+		{"not granted", &models.Claims{UserID: 1, Role: "user"}, ApproveLeaves, 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.AllowsPerson(tt.claims, tt.permission, tt.personID); got != tt.want {
+				t.Errorf("AllowsPerson() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
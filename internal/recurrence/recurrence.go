@@ -0,0 +1,185 @@
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/teambition/rrule-go"
+
+	"shyft/internal/models"
+)
+
+// maxOccurrences bounds the occurrences of a single rule before the expanded window, they are counted to
+// keep the rotation independent of the window
+const maxOccurrences = 100000
+
+// ErrTooFrequent is returned for rules repeating more often than hourly
+var ErrTooFrequent = errors.New("occurrences must be at least an hour apart")
+
+// Validate checks that every rule can be expanded
+func Validate(rules models.RecurrenceRules) error {
+	for i, rule := range rules {
+		if _, _, err := parse(rule, time.Now(), models.DefaultLocation); err != nil {
+			return fmt.Errorf("invalid recurrence rule %d: %v", i, err)
+		}
+		for _, exdate := range rule.ExDates {
+			if _, err := models.ParseShiftTime(exdate, models.DefaultLocation); err != nil {
+				return fmt.Errorf("invalid recurrence rule %d: invalid exdate %q", i, exdate)
+			}
+		}
+	}
+	return nil
+}
+
+// Expand materializes the occurrences of the schedule recurrence rules that overlap [from, to),
+// clipped to the schedule start and end date. Occurrences rotate through the rule users (or the
+// schedule users) in order, exdates skip an occurrence without shifting the rotation.
+func Expand(schedule *models.ShiftSchedule, from, to time.Time) ([]models.Shift, error) {
+	if from.Before(schedule.Start_Date) {
+		from = schedule.Start_Date
+	}
+	if to.After(schedule.End_Date) {
+		to = schedule.End_Date
+	}
+	if !to.After(from) {
+		return nil, nil
+	}
+
+	loc := schedule.Location()
+	var shifts []models.Shift
+	for ruleIndex, rule := range schedule.Recurrence {
+		r, duration, err := parse(rule, schedule.Start_Date, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recurrence rule %d: %v", ruleIndex, err)
+		}
+		users := ruleUsers(schedule, rule)
+		exdates := map[int64]bool{}
+		for _, exdate := range rule.ExDates {
+			if t, err := models.ParseShiftTime(exdate, loc); err == nil {
+				exdates[t.Unix()] = true
+			}
+		}
+
+		// occurrences overlap [from, to) when they start after from - duration, the earlier ones are only counted
+		windowStart := from.Add(-duration)
+		first, err := countUntil(r, windowStart)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recurrence rule %d: %v", ruleIndex, err)
+		}
+		for i, start := range r.Between(windowStart, to, false) {
+			occurrence := first + i
+			end := start.Add(duration)
+			if exdates[start.Unix()] {
+				continue
+			}
+
+			index := ruleIndex
+			shift := models.Shift{
+				ID:    occurrence,
+				Start: start.Format(time.RFC3339),
+				End:   end.Format(time.RFC3339),
+				Rule:  &index,
+			}
+			if len(users) > 0 {
+				shift.User = users[occurrence%len(users)]
+			}
+			shifts = append(shifts, shift)
+		}
+	}
+	return shifts, nil
+}
+
+// WithOccurrences returns the stored shifts of the schedule followed by the recurrence
+// occurrences overlapping [from, to). Occurrences are keyed by their rule and occurrence index,
+// never by an `id` of the stored shifts.
+func WithOccurrences(schedule *models.ShiftSchedule, from, to time.Time) (models.JSONB, error) {
+	result := append(models.JSONB{}, schedule.Shifts...)
+	if len(schedule.Recurrence) == 0 {
+		return result, nil
+	}
+
+	occurrences, err := Expand(schedule, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for _, occurrence := range occurrences {
+		entry := map[string]interface{}{
+			"occurrence": occurrence.ID,
+			"rule":       *occurrence.Rule,
+			"start":      occurrence.Start,
+			"end":        occurrence.End,
+			"user":       occurrence.User.Projection(),
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+// countUntil counts the occurrences of r starting at or before t
+func countUntil(r *rrule.RRule, t time.Time) (int, error) {
+	count := 0
+	next := r.Iterator()
+	for start, ok := next(); ok && !start.After(t); start, ok = next() {
+		if count++; count > maxOccurrences {
+			return 0, fmt.Errorf("more than %d occurrences before %s", maxOccurrences, t.Format(time.RFC3339))
+		}
+	}
+	return count, nil
+}
+
+func parse(rule models.RecurrenceRule, defaultStart time.Time, loc *time.Location) (*rrule.RRule, time.Duration, error) {
+	duration, err := time.ParseDuration(rule.Duration)
+	if err != nil || duration <= 0 {
+		return nil, 0, fmt.Errorf("invalid duration %q", rule.Duration)
+	}
+
+	option, err := rrule.StrToROption(strings.TrimPrefix(strings.TrimSpace(rule.RRule), "RRULE:"))
+	if err != nil {
+		return nil, 0, err
+	}
+	if option.Freq == rrule.MINUTELY || option.Freq == rrule.SECONDLY || len(option.Byminute) > 1 || len(option.Bysecond) > 1 {
+		return nil, 0, ErrTooFrequent
+	}
+	option.Dtstart = defaultStart.In(loc)
+	if rule.Start != "" {
+		if option.Dtstart, err = models.ParseShiftTime(rule.Start, loc); err != nil {
+			return nil, 0, fmt.Errorf("invalid start %q", rule.Start)
+		}
+		// occurrences keep the wall clock time of the start in loc across daylight saving time changes
+		option.Dtstart = option.Dtstart.In(loc)
+	}
+
+	r, err := rrule.NewRRule(*option)
+	if err != nil {
+		return nil, 0, err
+	}
+	return r, duration, nil
+}
+
+// users rotating through the occurrences of rule
+func ruleUsers(schedule *models.ShiftSchedule, rule models.RecurrenceRule) []models.Contact {
+	var users []models.Contact
+	byID := map[int]models.Contact{}
+	for _, entry := range schedule.Users {
+		values, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		user := models.ContactFromJSON(values)
+		users = append(users, user)
+		byID[int(user.ID)] = user
+	}
+	if len(rule.UserIDs) == 0 {
+		return users
+	}
+
+	users = nil
+	for _, id := range rule.UserIDs {
+		if user, ok := byID[id]; ok {
+			users = append(users, user)
+		}
+	}
+	return users
+}
@@ -0,0 +1,209 @@
+package recurrence
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"shyft/internal/models"
+	"shyft/internal/testutil"
+)
+
+// schedule returns a shift schedule of Berlin with three users, a stored shift and the recurrence rules
+func schedule(start, end string, rules ...models.RecurrenceRule) *models.ShiftSchedule {
+	shiftSchedule := testutil.Schedule("Europe/Berlin", start, end,
+		models.Contact{ID: 1, Name: "a"}, models.Contact{ID: 2, Name: "b"}, models.Contact{ID: 3, Name: "c"})
+	shiftSchedule.Shifts = models.JSONB{map[string]interface{}{"id": 0.0, "start": "2026-03-02 09:00:00", "end": "2026-03-03 09:00:00"}}
+	shiftSchedule.Recurrence = models.RecurrenceRules(rules)
+	return shiftSchedule
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   models.RecurrenceRules
+		wantErr bool
+	}{
+		{"no rules", nil, false},
+		{"weekly rule", models.RecurrenceRules{{RRule: "FREQ=WEEKLY;BYDAY=MO", Duration: "24h"}}, false},
+		{"prefixed rule with start and exdates", models.RecurrenceRules{{
+			RRule: "RRULE:FREQ=DAILY", Start: "2026-03-02 09:00:00", Duration: "8h",
+			ExDates: []string{"2026-03-03 09:00:00", "2026-03-04T09:00:00+01:00"},
+		}}, false},
+		{"invalid rrule", models.RecurrenceRules{{RRule: "FREQ=SOMETIMES", Duration: "8h"}}, true},
+		{"missing duration", models.RecurrenceRules{{RRule: "FREQ=DAILY"}}, true},
+		{"negative duration", models.RecurrenceRules{{RRule: "FREQ=DAILY", Duration: "-8h"}}, true},
+		{"invalid start", models.RecurrenceRules{{RRule: "FREQ=DAILY", Duration: "8h", Start: "monday"}}, true},
+		{"invalid exdate", models.RecurrenceRules{{RRule: "FREQ=DAILY", Duration: "8h", ExDates: []string{"tuesday"}}}, true},
+		{"a later invalid rule", models.RecurrenceRules{{RRule: "FREQ=DAILY", Duration: "8h"}, {RRule: "FREQ=DAILY", Duration: "0s"}}, true},
+		{"hourly rule", models.RecurrenceRules{{RRule: "FREQ=HOURLY;INTERVAL=4", Duration: "4h"}}, false},
+		{"minutely rule", models.RecurrenceRules{{RRule: "FREQ=MINUTELY;INTERVAL=30", Duration: "30m"}}, true},
+		{"secondly rule", models.RecurrenceRules{{RRule: "FREQ=SECONDLY", Duration: "1s"}}, true},
+		{"several minutes an hour", models.RecurrenceRules{{RRule: "FREQ=HOURLY;BYMINUTE=0,30", Duration: "30m"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(tt.rules); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExpand(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule *models.ShiftSchedule
+		from, to time.Time
+		want     []string
+	}{
+		{
+			name:     "daily occurrences rotate through the schedule users",
+			schedule: schedule("2026-03-02 09:00:00", "2026-03-06 09:00:00", models.RecurrenceRule{RRule: "FREQ=DAILY", Duration: "8h"}),
+			want: []string{
+				"occurrence 0 of rule 0 2026-03-02T09:00:00+01:00 2026-03-02T17:00:00+01:00 1",
+				"occurrence 1 of rule 0 2026-03-03T09:00:00+01:00 2026-03-03T17:00:00+01:00 2",
+				"occurrence 2 of rule 0 2026-03-04T09:00:00+01:00 2026-03-04T17:00:00+01:00 3",
+				"occurrence 3 of rule 0 2026-03-05T09:00:00+01:00 2026-03-05T17:00:00+01:00 1",
+			},
+		},
+		{
+			name: "exdates skip an occurrence without shifting the rotation",
+			schedule: schedule("2026-03-02 09:00:00", "2026-03-05 09:00:00", models.RecurrenceRule{
+				RRule: "FREQ=DAILY", Duration: "8h", ExDates: []string{"2026-03-03 09:00:00"},
+			}),
+			want: []string{
+				"occurrence 0 of rule 0 2026-03-02T09:00:00+01:00 2026-03-02T17:00:00+01:00 1",
+				"occurrence 2 of rule 0 2026-03-04T09:00:00+01:00 2026-03-04T17:00:00+01:00 3",
+			},
+		},
+		{
+			name: "rule users and start",
+			schedule: schedule("2026-03-02 09:00:00", "2026-03-20 09:00:00", models.RecurrenceRule{
+				RRule: "FREQ=WEEKLY;BYDAY=SA", Start: "2026-03-07 10:00:00", Duration: "24h", UserIDs: []int{3, 4, 2},
+			}),
+			want: []string{
+				"occurrence 0 of rule 0 2026-03-07T10:00:00+01:00 2026-03-08T10:00:00+01:00 3",
+				"occurrence 1 of rule 0 2026-03-14T10:00:00+01:00 2026-03-15T10:00:00+01:00 2",
+			},
+		},
+		{
+			name: "occurrences keep the wall clock time across a daylight saving time change",
+			schedule: schedule("2026-03-28 09:00:00", "2026-03-30 10:00:00", models.RecurrenceRule{
+				RRule: "FREQ=DAILY", Start: "2026-03-28 09:00:00", Duration: "1h",
+			}),
+			want: []string{
+				"occurrence 0 of rule 0 2026-03-28T09:00:00+01:00 2026-03-28T10:00:00+01:00 1",
+				"occurrence 1 of rule 0 2026-03-29T09:00:00+02:00 2026-03-29T10:00:00+02:00 2",
+				"occurrence 2 of rule 0 2026-03-30T09:00:00+02:00 2026-03-30T10:00:00+02:00 3",
+			},
+		},
+		{
+			name: "several rules",
+			schedule: schedule("2026-03-02 00:00:00", "2026-03-03 00:00:00",
+				models.RecurrenceRule{RRule: "FREQ=DAILY", Start: "2026-03-02 00:00:00", Duration: "12h", UserIDs: []int{1}},
+				models.RecurrenceRule{RRule: "FREQ=DAILY", Start: "2026-03-02 12:00:00", Duration: "12h", UserIDs: []int{2}},
+			),
+			want: []string{
+				"occurrence 0 of rule 0 2026-03-02T00:00:00+01:00 2026-03-02T12:00:00+01:00 1",
+				"occurrence 0 of rule 1 2026-03-02T12:00:00+01:00 2026-03-03T00:00:00+01:00 2",
+			},
+		},
+		{
+			name:     "only occurrences overlapping the range",
+			schedule: schedule("2026-03-02 09:00:00", "2026-03-06 09:00:00", models.RecurrenceRule{RRule: "FREQ=DAILY", Duration: "8h"}),
+			from:     time.Date(2026, 3, 3, 15, 0, 0, 0, time.UTC),
+			to:       time.Date(2026, 3, 4, 8, 30, 0, 0, time.UTC),
+			want: []string{
+				"occurrence 1 of rule 0 2026-03-03T09:00:00+01:00 2026-03-03T17:00:00+01:00 2",
+				"occurrence 2 of rule 0 2026-03-04T09:00:00+01:00 2026-03-04T17:00:00+01:00 3",
+			},
+		},
+		{
+			name:     "the rotation of a late range counts the earlier occurrences",
+			schedule: schedule("2026-03-02 09:00:00", "2027-03-02 09:00:00", models.RecurrenceRule{RRule: "FREQ=HOURLY;INTERVAL=6", Duration: "6h"}),
+			from:     time.Date(2027, 3, 1, 5, 0, 0, 0, time.UTC),
+			to:       time.Date(2027, 3, 1, 11, 0, 0, 0, time.UTC),
+			want: []string{
+				"occurrence 1455 of rule 0 2027-03-01T03:00:00+01:00 2027-03-01T09:00:00+01:00 1",
+				"occurrence 1456 of rule 0 2027-03-01T09:00:00+01:00 2027-03-01T15:00:00+01:00 2",
+			},
+		},
+		{
+			name:     "a range outside of the schedule",
+			schedule: schedule("2026-03-02 09:00:00", "2026-03-06 09:00:00", models.RecurrenceRule{RRule: "FREQ=DAILY", Duration: "8h"}),
+			from:     time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+			to:       time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := tt.from, tt.to
+			if from.IsZero() {
+				from, to = tt.schedule.Start_Date, tt.schedule.End_Date
+			}
+			shifts, err := Expand(tt.schedule, from, to)
+			if err != nil {
+				t.Fatalf("Expand() error = %v", err)
+			}
+			if got, want := testutil.DescribeShifts(shifts), strings.Join(tt.want, "\n"); got != want {
+				t.Errorf("Expand() =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+
+	invalid := schedule("2026-03-02 09:00:00", "2026-03-06 09:00:00", models.RecurrenceRule{RRule: "FREQ=DAILY"})
+	if _, err := Expand(invalid, invalid.Start_Date, invalid.End_Date); err == nil {
+		t.Error("Expand() of an invalid rule error = nil, want an error")
+	}
+}
+
+func TestWithOccurrences(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule *models.ShiftSchedule
+		want     []models.ShiftRef
+	}{
+		{
+			name:     "stored shifts only",
+			schedule: schedule("2026-03-02 09:00:00", "2026-03-04 09:00:00"),
+			want:     []models.ShiftRef{{ShiftID: intPtr(0)}},
+		},
+		{
+			name:     "occurrences are keyed by rule and occurrence, not by id",
+			schedule: schedule("2026-03-02 09:00:00", "2026-03-04 09:00:00", models.RecurrenceRule{RRule: "FREQ=DAILY", Duration: "8h"}),
+			want: []models.ShiftRef{
+				{ShiftID: intPtr(0)},
+				{Rule: intPtr(0), Occurrence: intPtr(0)},
+				{Rule: intPtr(0), Occurrence: intPtr(1)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := WithOccurrences(tt.schedule, tt.schedule.Start_Date, tt.schedule.End_Date)
+			if err != nil {
+				t.Fatalf("WithOccurrences() error = %v", err)
+			}
+			if len(entries) != len(tt.want) {
+				t.Fatalf("WithOccurrences() returned %d entries, want %d: %v", len(entries), len(tt.want), entries)
+			}
+			for i, entry := range entries {
+				values := entry.(map[string]interface{})
+				if got := models.ShiftRefOf(values); got.String() != tt.want[i].String() {
+					t.Errorf("entry %d = %s, want %s", i, got, tt.want[i])
+				}
+				if _, ok := values["id"]; ok && tt.want[i].ShiftID == nil {
+					t.Errorf("occurrence entry %d has an id: %v", i, values)
+				}
+			}
+			if len(tt.schedule.Shifts) != 1 {
+				t.Errorf("WithOccurrences() modified the stored shifts: %v", tt.schedule.Shifts)
+			}
+		})
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
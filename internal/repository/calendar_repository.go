@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"shyft/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CalendarRepository struct {
+	db *gorm.DB
+}
+
+func NewCalendarRepository(db *gorm.DB) *CalendarRepository {
+	return &CalendarRepository{db: db}
+}
+
+// FindToken finds the calendar token with the given SHA-256 hash that has not expired at now
+func (r *CalendarRepository) FindToken(tokenHash string, now time.Time) (*models.CalendarToken, error) {
+	var token models.CalendarToken
+	if err := r.db.Where("token_hash = ? AND expires_at > ?", tokenHash, now).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// ReplaceToken stores the calendar token of a person, revoking the previous one
+func (r *CalendarRepository) ReplaceToken(token *models.CalendarToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		tx = tx.Session(&gorm.Session{NewDB: true})
+		if err := tx.Where("person_id = ?", token.PersonID).Delete(&models.CalendarToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(token).Error
+	})
+}
+
+// DeleteToken revokes the calendar token of a person
+func (r *CalendarRepository) DeleteToken(personID uint) error {
+	return r.db.Where("person_id = ?", personID).Delete(&models.CalendarToken{}).Error
+}
+
+// Sequences returns the SEQUENCE of the events, given their fingerprint by uid. New events start at 0 and
+// the sequence of an event is bumped whenever its fingerprint changes.
+func (r *CalendarRepository) Sequences(fingerprints map[string]string) (map[string]int, error) {
+	sequences := map[string]int{}
+	if len(fingerprints) == 0 {
+		return sequences, nil
+	}
+
+	events := make([]models.CalendarEvent, 0, len(fingerprints))
+	uids := make([]string, 0, len(fingerprints))
+	for uid, fingerprint := range fingerprints {
+		events = append(events, models.CalendarEvent{UID: uid, Fingerprint: fingerprint})
+		uids = append(uids, uid)
+	}
+	err := r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "uid"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"fingerprint": gorm.Expr("EXCLUDED.fingerprint"),
+			"sequence":    gorm.Expr("calendar_events.sequence + 1"),
+			"updated_at":  gorm.Expr("NOW()"),
+		}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "calendar_events.fingerprint <> EXCLUDED.fingerprint"},
+		}},
+	}).CreateInBatches(&events, 500).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var stored []models.CalendarEvent
+	if err := r.db.Where("uid IN ?", uids).Find(&stored).Error; err != nil {
+		return nil, err
+	}
+	for _, event := range stored {
+		sequences[event.UID] = event.Sequence
+	}
+	return sequences, nil
+}
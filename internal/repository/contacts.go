@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"gorm.io/gorm"
+
+	"shyft/internal/models"
+)
+
+// listContacts applies the contact filters, sorting and pagination to query and fills dest
+func listContacts(query *gorm.DB, params models.ContactListParams, dest interface{}) (int64, error) {
+	var total int64
+
+	// OnlyActive filter
+	if params.OnlyActive != nil {
+		if *params.OnlyActive {
+			query = query.Where("deleted_at IS NULL")
+		} else {
+			query = query.Unscoped().Where("deleted_at IS NOT NULL")
+		}
+	}
+
+	// Search filter
+	if params.Search != "" {
+		searchPattern := "%" + params.Search + "%"
+		query = query.Where("(name ILIKE ? OR mail ILIKE ? OR phone ILIKE ? OR description ILIKE ?)",
+			searchPattern, searchPattern, searchPattern, searchPattern)
+	}
+
+	// Contact filters
+	if params.Name != "" {
+		query = query.Where("name ILIKE ?", "%"+params.Name+"%")
+	}
+	if params.Mail != "" {
+		query = query.Where("mail ILIKE ?", "%"+params.Mail+"%")
+	}
+	if params.Phone != "" {
+		query = query.Where("phone ILIKE ?", "%"+params.Phone+"%")
+	}
+
+	// Get total count
+	if err := query.Count(&total).Error; err != nil {
+		return 0, err
+	}
+
+	// Apply sorting and pagination
+	offset := (params.Page - 1) * params.PageSize
+	if err := query.Order(params.GetSortString()).Offset(offset).Limit(params.PageSize).Find(dest).Error; err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func totalPages(total int64, pageSize int) int {
+	pages := int(total) / pageSize
+	if int(total)%pageSize > 0 {
+		pages++
+	}
+	return pages
+}
+
+// refreshProjection rewrites the JSONB entries of every shift schedule that reference
+// the contact id with its projection, so a rename in its table is visible in every schedule.
+// Only the schedules of organizationID are rewritten when it is given.
+func refreshProjection(tx *gorm.DB, table string, id uint, projection map[string]interface{}, organizationID *uint) error {
+	entry, err := json.Marshal(projection)
+	if err != nil {
+		return err
+	}
+	tx = tx.Session(&gorm.Session{NewDB: true})
+	schedules := tx.Table("shift_schedule")
+	if organizationID != nil {
+		schedules = schedules.Where("organization_id = ?", *organizationID)
+	}
+
+	var columns []string
+	switch table {
+	case organizationsTable:
+		columns = []string{"organization"}
+	case managersTable:
+		columns = []string{"manager"}
+	case peopleTable:
+		columns = []string{"users"}
+	}
+
+	for _, column := range columns {
+		err := schedules.Session(&gorm.Session{}).
+			Where("jsonb_typeof("+column+") = 'array'").
+			Where("EXISTS (SELECT 1 FROM jsonb_array_elements("+column+") AS e WHERE e->>'id' = ?)", contactID(id)).
+			Update(column, gorm.Expr(`(SELECT jsonb_agg(CASE WHEN e->>'id' = ? THEN ?::jsonb ELSE e END ORDER BY position)
+            FROM jsonb_array_elements(`+column+`) WITH ORDINALITY AS t(e, position))`, contactID(id), string(entry))).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	if table == peopleTable {
+		err := schedules.Session(&gorm.Session{}).
+			Where("jsonb_typeof(shifts) = 'array'").
+			Where("EXISTS (SELECT 1 FROM jsonb_array_elements(shifts) AS s WHERE s->'user'->>'id' = ?)", contactID(id)).
+			Update("shifts", gorm.Expr(`(SELECT jsonb_agg(CASE WHEN s->'user'->>'id' = ? THEN jsonb_set(s, '{user}', ?::jsonb) ELSE s END ORDER BY position)
+            FROM jsonb_array_elements(shifts) WITH ORDINALITY AS t(s, position))`, contactID(id), string(entry))).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func contactID(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
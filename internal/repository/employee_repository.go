@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"errors"
+	"shyft/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EmployeeRepository applies the joiner, leaver and transfer events of the HR platform
+type EmployeeRepository struct {
+	db *gorm.DB
+}
+
+func NewEmployeeRepository(db *gorm.DB) *EmployeeRepository {
+	return &EmployeeRepository{db: db}
+}
+
+// Apply calls apply in a transaction and records the event as processed in the same transaction. Events
+// processed before are skipped, applied is false then. apply returns the person the event is about, if any.
+func (r *EmployeeRepository) Apply(eventID, eventType string, apply func(tx *EmployeeRepository) (*models.User, error)) (applied bool, err error) {
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		tx = tx.Session(&gorm.Session{NewDB: true})
+		event := models.HREvent{EventID: eventID, EventType: eventType, ProcessedAt: time.Now()}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&event)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		person, err := apply(&EmployeeRepository{db: tx})
+		if err != nil {
+			return err
+		}
+		if person != nil {
+			if err := tx.Model(&event).Update("person_id", person.ID).Error; err != nil {
+				return err
+			}
+		}
+		applied = true
+		return nil
+	})
+	return applied, err
+}
+
+// FindPerson finds a person by id, or by mail without an id, including deactivated ones. nil is returned
+// when there is no such person.
+func (r *EmployeeRepository) FindPerson(id uint, mail string) (*models.User, error) {
+	var person models.User
+	query := r.db.Unscoped()
+	switch {
+	case id > 0:
+		query = query.Where("id = ?", id)
+	case mail != "":
+		query = query.Where("lower(mail) = lower(?)", mail).Order("deleted_at DESC NULLS FIRST, id DESC")
+	default:
+		return nil, nil
+	}
+	if err := query.Take(&person).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &person, nil
+}
+
+// HasOrganization reports whether the organization exists
+func (r *EmployeeRepository) HasOrganization(id uint) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.Organization{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// SavePerson creates or updates (and reactivates) the person, together with the copies of their contact
+// details embedded in shift schedules
+func (r *EmployeeRepository) SavePerson(person *models.User) error {
+	if person.ID == 0 {
+		return r.db.Create(person).Error
+	}
+	person.DeletedAt = gorm.DeletedAt{}
+	if err := r.db.Unscoped().Save(person).Error; err != nil {
+		return err
+	}
+	return refreshProjection(r.db, peopleTable, person.ID, person.Projection(), person.OrganizationID)
+}
+
+// Deactivate soft deletes the person
+func (r *EmployeeRepository) Deactivate(person *models.User) error {
+	return r.db.Delete(person).Error
+}
+
+// RevokeCalendarToken revokes the calendar token of the person
+func (r *EmployeeRepository) RevokeCalendarToken(personID uint) error {
+	return r.db.Where("person_id = ?", personID).Delete(&models.CalendarToken{}).Error
+}
+
+// Unassign removes the person from the users of the live shift schedules, and from their shifts starting at
+// or after from. Only the schedules matched by scope are changed when it is given. event returns the outbox
+// event of the change of a schedule from before. The changed schedules are returned.
+func (r *EmployeeRepository) Unassign(personID uint, from time.Time, scope func(*gorm.DB) *gorm.DB, event func(before *models.ShiftSchedule) EventFunc) ([]models.ShiftSchedule, error) {
+	assigned := r.db.Where("id IN (SELECT shift_schedule_id FROM shift_schedule_people WHERE person_id = ?)", personID).
+		Or("id IN (SELECT shift_schedule_id FROM shifts WHERE person_id = ? AND start_at >= ?)", personID, from)
+	query := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where(assigned)
+	if scope != nil {
+		query = scope(query)
+	}
+	var schedules []models.ShiftSchedule
+	if err := query.Order("id").Find(&schedules).Error; err != nil {
+		return nil, err
+	}
+
+	var changed []models.ShiftSchedule
+	for i := range schedules {
+		schedule := &schedules[i]
+		before := schedule.Clone()
+		if !unassign(schedule, personID, from) {
+			continue
+		}
+		if err := r.db.Save(schedule).Error; err != nil {
+			return nil, err
+		}
+		if err := linkRelations(r.db, schedule); err != nil {
+			return nil, err
+		}
+		if err := recordEvents(r.db, schedule, []EventFunc{event(before)}); err != nil {
+			return nil, err
+		}
+		changed = append(changed, *schedule)
+	}
+	return changed, nil
+}
+
+// unassign removes the person from the users of the schedule and from its shifts starting at or after from,
+// it reports whether the schedule changed
+func unassign(schedule *models.ShiftSchedule, personID uint, from time.Time) bool {
+	isPerson := func(entry interface{}) bool {
+		values, ok := entry.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		id, ok := models.JSONInt(values["id"])
+		return ok && id == int(personID)
+	}
+
+	changed := false
+	users := models.JSONB{}
+	for _, entry := range schedule.Users {
+		if isPerson(entry) {
+			changed = true
+			continue
+		}
+		users = append(users, entry)
+	}
+	schedule.Users = users
+
+	for _, entry := range schedule.Shifts {
+		shift, ok := entry.(map[string]interface{})
+		if !ok || !isPerson(shift["user"]) {
+			continue
+		}
+		start, _ := shift["start"].(string)
+		startAt, err := models.ParseShiftTime(start, schedule.Location())
+		if err != nil || startAt.Before(from) {
+			continue
+		}
+		delete(shift, "user") // left unassigned, reported as a coverage gap
+		changed = true
+	}
+	return changed
+}
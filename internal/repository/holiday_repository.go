@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"errors"
+	"shyft/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type HolidayRepository struct {
+	db *gorm.DB
+}
+
+func NewHolidayRepository(db *gorm.DB) *HolidayRepository {
+	return &HolidayRepository{db: db}
+}
+
+// ListByOrganization lists the holidays of an organization in [from, to), all of them when from and to are nil
+func (r *HolidayRepository) ListByOrganization(organizationID uint, from, to *time.Time) ([]models.Holiday, error) {
+	var holidays []models.Holiday
+	query := r.db.Where("organization_id = ?", organizationID)
+	if from != nil {
+		query = query.Where("date >= ?", from.Format(models.HolidayDateLayout))
+	}
+	if to != nil {
+		query = query.Where("date < ?", to.Format(models.HolidayDateLayout))
+	}
+	if err := query.Order("date, id").Find(&holidays).Error; err != nil {
+		return nil, err
+	}
+	return holidays, nil
+}
+
+// ListForOrganizations lists the holidays of the given organizations between the days of from and to
+func (r *HolidayRepository) ListForOrganizations(organizationIDs []uint, from, to time.Time) ([]models.Holiday, error) {
+	var holidays []models.Holiday
+	if len(organizationIDs) == 0 {
+		return holidays, nil
+	}
+	err := r.db.Where("organization_id IN ? AND date >= ? AND date <= ?", organizationIDs,
+		from.AddDate(0, 0, -1).Format(models.HolidayDateLayout), to.AddDate(0, 0, 1).Format(models.HolidayDateLayout)).
+		Order("date, id").Find(&holidays).Error
+	if err != nil {
+		return nil, err
+	}
+	return holidays, nil
+}
+
+func (r *HolidayRepository) FindByID(organizationID uint, id string) (*models.Holiday, error) {
+	var holiday models.Holiday
+	if err := r.db.Where("organization_id = ? AND id = ?", organizationID, id).First(&holiday).Error; err != nil {
+		return nil, err
+	}
+	return &holiday, nil
+}
+
+// Save creates the holidays of an organization, renaming the existing holiday of a day instead of duplicating it
+func (r *HolidayRepository) Save(holidays []models.Holiday) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		tx = tx.Session(&gorm.Session{NewDB: true})
+		for i := range holidays {
+			var existing models.Holiday
+			err := tx.Where("organization_id = ? AND date = ?", holidays[i].OrganizationID, holidays[i].Date.Format(models.HolidayDateLayout)).
+				First(&existing).Error
+			switch {
+			case err == nil:
+				existing.Name = holidays[i].Name
+				if err := tx.Save(&existing).Error; err != nil {
+					return err
+				}
+				holidays[i] = existing
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				if err := tx.Create(&holidays[i]).Error; err != nil {
+					return err
+				}
+			default:
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Delete soft deletes the holiday
+func (r *HolidayRepository) Delete(holiday *models.Holiday) error {
+	return r.db.Delete(holiday).Error
+}
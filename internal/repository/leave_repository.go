@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"shyft/internal/models"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type LeaveRepository struct {
+	db *gorm.DB
+}
+
+func NewLeaveRepository(db *gorm.DB) *LeaveRepository {
+	return &LeaveRepository{db: db}
+}
+
+// ForOrganization returns a repository whose queries are limited to the leaves of the people of the given
+// organization (tenant)
+func (r *LeaveRepository) ForOrganization(organizationID int) *LeaveRepository {
+	return &LeaveRepository{db: r.db.Where("leaves.person_id IN (SELECT id FROM people WHERE organization_id = ?)", organizationID).
+		Session(&gorm.Session{})}
+}
+
+// VisibleTo returns a repository whose queries are limited to the given person's own leaves and the leaves
+// of the people of the shift schedules they manage
+func (r *LeaveRepository) VisibleTo(userID int, mail string) *LeaveRepository {
+	id := strconv.Itoa(userID)
+	return &LeaveRepository{db: r.db.Where(`(leaves.person_id = ? OR leaves.person_id IN (
+        SELECT sp.person_id FROM shift_schedule_people AS sp
+        JOIN shift_schedule AS s ON s.id = sp.shift_schedule_id
+        WHERE s.deleted_at IS NULL AND EXISTS (SELECT 1 FROM jsonb_array_elements(s.manager) AS m
+            WHERE m->>'person_id' = ? OR (? <> '' AND lower(m->>'mail') = lower(?)))))`, userID, id, mail, mail).
+		Session(&gorm.Session{})}
+}
+
+// List lists the leaves matching params, overlapping [From, To) when given
+func (r *LeaveRepository) List(params models.LeaveListParams) ([]models.Leave, error) {
+	var leaves []models.Leave
+	query := r.withPerson()
+	if params.UserID != nil {
+		query = query.Where("person_id = ?", *params.UserID)
+	}
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+	if params.Type != "" {
+		query = query.Where("type = ?", params.Type)
+	}
+	if params.From != nil {
+		query = query.Where("end_at > ?", *params.From)
+	}
+	if params.To != nil {
+		query = query.Where("start_at < ?", *params.To)
+	}
+	if err := query.Order("start_at, id").Find(&leaves).Error; err != nil {
+		return nil, err
+	}
+	return leaves, nil
+}
+
+// ListApproved lists the approved leaves of the given people overlapping [from, to)
+func (r *LeaveRepository) ListApproved(personIDs []uint, from, to time.Time) ([]models.Leave, error) {
+	var leaves []models.Leave
+	if len(personIDs) == 0 {
+		return leaves, nil
+	}
+	err := r.db.Where("person_id IN ? AND status = ? AND end_at > ? AND start_at < ?", personIDs, models.LeaveApproved, from, to).
+		Order("start_at, id").Find(&leaves).Error
+	if err != nil {
+		return nil, err
+	}
+	return leaves, nil
+}
+
+func (r *LeaveRepository) FindByID(id string) (*models.Leave, error) {
+	var leave models.Leave
+	if err := r.withPerson().Where("leaves.id = ?", id).First(&leave).Error; err != nil {
+		return nil, err
+	}
+	return &leave, nil
+}
+
+func (r *LeaveRepository) Create(leave *models.Leave) error {
+	return r.db.Omit("Person").Create(leave).Error
+}
+
+func (r *LeaveRepository) Save(leave *models.Leave) error {
+	return r.db.Omit("Person").Save(leave).Error
+}
+
+// Delete soft deletes the leave
+func (r *LeaveRepository) Delete(leave *models.Leave) error {
+	return r.db.Delete(leave).Error
+}
+
+// leaves keep showing their person, even once the person is deleted
+func (r *LeaveRepository) withPerson() *gorm.DB {
+	return r.db.Preload("Person", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	})
+}
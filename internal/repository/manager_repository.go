@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"shyft/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type ManagerRepository struct {
+	db *gorm.DB
+}
+
+func NewManagerRepository(db *gorm.DB) *ManagerRepository {
+	return &ManagerRepository{db: db}
+}
+
+// List lists managers, limited to the managers of organizationID when given
+func (r *ManagerRepository) List(params models.ContactListParams, organizationID *uint) (*models.ManagerListResponse, error) {
+	var managers []models.Manager
+
+	query := r.db.Model(&models.Manager{})
+	if organizationID != nil {
+		query = query.Where("organization_id = ?", *organizationID)
+	}
+	if params.OrganizationID != nil {
+		query = query.Where("organization_id = ?", *params.OrganizationID)
+	}
+	total, err := listContacts(query, params, &managers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ManagerListResponse{
+		Data:       managers,
+		Total:      total,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+		TotalPages: totalPages(total, params.PageSize),
+		SortBy:     params.SortBy,
+		SortOrder:  params.SortOrder,
+	}, nil
+}
+
+func (r *ManagerRepository) FindByID(id string) (*models.Manager, error) {
+	var manager models.Manager
+	if err := r.db.Where("id = ?", id).First(&manager).Error; err != nil {
+		return nil, err
+	}
+	return &manager, nil
+}
+
+// FindByIDWithDeleted finds a manager by id including soft deleted ones
+func (r *ManagerRepository) FindByIDWithDeleted(id string) (*models.Manager, error) {
+	var manager models.Manager
+	if err := r.db.Unscoped().Where("id = ?", id).First(&manager).Error; err != nil {
+		return nil, err
+	}
+	return &manager, nil
+}
+
+// Create creates the manager, linked to the person of its organization with the same mail when no person is given
+func (r *ManagerRepository) Create(manager *models.Manager) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := linkPerson(tx, manager); err != nil {
+			return err
+		}
+		return tx.Create(manager).Error
+	})
+}
+
+// Save updates the manager and the copies of their details embedded in shift schedules
+func (r *ManagerRepository) Save(manager *models.Manager) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := linkPerson(tx, manager); err != nil {
+			return err
+		}
+		if err := tx.Save(manager).Error; err != nil {
+			return err
+		}
+		return refreshProjection(tx, managersTable, manager.ID, manager.Projection(), manager.OrganizationID)
+	})
+}
+
+// Delete soft deletes the manager
+func (r *ManagerRepository) Delete(manager *models.Manager) error {
+	return r.db.Delete(manager).Error
+}
+
+func (r *ManagerRepository) Restore(manager *models.Manager) error {
+	return r.db.Unscoped().Model(manager).Update("deleted_at", nil).Error
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"shyft/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+// Claim reserves the notification for the caller and reports whether it got it. A new notification is
+// claimed by inserting it, the dedup key lets a single replica succeed; a failed one is claimed again once
+// its backoff (retryDelay doubled per attempt) has passed, and one left sending for longer than lease (its
+// replica stopped before recording the outcome) is claimed again too, both until maxAttempts.
+func (r *NotificationRepository) Claim(notification *models.Notification, now time.Time, retryDelay, lease time.Duration, maxAttempts int) (bool, error) {
+	notification.Status, notification.Attempts = models.NotificationSending, 1
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(notification)
+	if result.Error != nil || result.RowsAffected == 1 {
+		return result.Error == nil, result.Error
+	}
+
+	result = r.db.Raw(`UPDATE notifications SET status = ?, attempts = attempts + 1, updated_at = ?
+        WHERE dedup_key = ? AND channel = ? AND attempts < ? AND (
+            (status = ? AND updated_at <= ?::timestamptz - make_interval(secs => ? * power(2, attempts - 1))) OR
+            (status = ? AND updated_at <= ?))
+        RETURNING *`, models.NotificationSending, now, notification.DedupKey, notification.Channel, maxAttempts,
+		models.NotificationFailed, now, retryDelay.Seconds(), models.NotificationSending, now.Add(-lease)).Scan(notification)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Record saves the outcome of sending the notification
+func (r *NotificationRepository) Record(notification *models.Notification) error {
+	return r.db.Model(notification).Select("status", "last_error", "sent_at").Updates(notification).Error
+}
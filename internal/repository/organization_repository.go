@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"shyft/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type OrganizationRepository struct {
+	db *gorm.DB
+}
+
+func NewOrganizationRepository(db *gorm.DB) *OrganizationRepository {
+	return &OrganizationRepository{db: db}
+}
+
+// List lists organizations, limited to organizationID when given
+func (r *OrganizationRepository) List(params models.ContactListParams, organizationID *uint) (*models.OrganizationListResponse, error) {
+	var organizations []models.Organization
+
+	query := r.db.Model(&models.Organization{})
+	if organizationID != nil {
+		query = query.Where("id = ?", *organizationID)
+	}
+	total, err := listContacts(query, params, &organizations)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.OrganizationListResponse{
+		Data:       organizations,
+		Total:      total,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+		TotalPages: totalPages(total, params.PageSize),
+		SortBy:     params.SortBy,
+		SortOrder:  params.SortOrder,
+	}, nil
+}
+
+func (r *OrganizationRepository) FindByID(id string) (*models.Organization, error) {
+	var organization models.Organization
+	if err := r.db.Where("id = ?", id).First(&organization).Error; err != nil {
+		return nil, err
+	}
+	return &organization, nil
+}
+
+// FindByIDWithDeleted finds an organization by id including soft deleted ones
+func (r *OrganizationRepository) FindByIDWithDeleted(id string) (*models.Organization, error) {
+	var organization models.Organization
+	if err := r.db.Unscoped().Where("id = ?", id).First(&organization).Error; err != nil {
+		return nil, err
+	}
+	return &organization, nil
+}
+
+func (r *OrganizationRepository) Create(organization *models.Organization) error {
+	return r.db.Create(organization).Error
+}
+
+// Save updates the organization and the copies of its details embedded in shift schedules
+func (r *OrganizationRepository) Save(organization *models.Organization) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(organization).Error; err != nil {
+			return err
+		}
+		return refreshProjection(tx, organizationsTable, organization.ID, organization.Projection(), nil)
+	})
+}
+
+// Delete soft deletes the organization
+func (r *OrganizationRepository) Delete(organization *models.Organization) error {
+	return r.db.Delete(organization).Error
+}
+
+func (r *OrganizationRepository) Restore(organization *models.Organization) error {
+	return r.db.Unscoped().Model(organization).Update("deleted_at", nil).Error
+}
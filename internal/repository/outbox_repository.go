@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"shyft/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// outboxLock is the key of the advisory lock of the relay, a single replica claims events at a time so the
+// events of a shift schedule are published in order
+const outboxLock = 20261018230000
+
+// EventFunc returns the outbox event of a change of the shift schedule. It is called with the saved shift
+// schedule in the transaction of the change, so the event is written if and only if the change is.
+type EventFunc func(schedule *models.ShiftSchedule) (*models.OutboxEvent, error)
+
+// recordEvents writes the events of the change of schedule to the outbox
+func recordEvents(tx *gorm.DB, schedule *models.ShiftSchedule, events []EventFunc) error {
+	for _, event := range events {
+		if event == nil {
+			continue
+		}
+		entry, err := event(schedule)
+		if err != nil {
+			return err
+		}
+		if entry.NextAttemptAt.IsZero() {
+			entry.NextAttemptAt = time.Now()
+		}
+		if err := tx.Session(&gorm.Session{NewDB: true}).Create(entry).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// Claim takes up to limit pending events, oldest first, for lease: their next attempt is moved to the end of
+// the lease, so a relay that stops while publishing them leaves them to the next claim. Shift schedules with
+// an event claimed or waiting for its next attempt are left out, their later events wait behind it. Claims
+// are made in a short transaction holding the outbox lock, locked is false when another replica holds it.
+func (r *OutboxRepository) Claim(limit int, now time.Time, lease time.Duration) (pending []models.OutboxEvent, locked bool, err error) {
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		tx = tx.Session(&gorm.Session{NewDB: true})
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", outboxLock).Scan(&locked).Error; err != nil {
+			return err
+		}
+		if !locked {
+			return nil
+		}
+
+		waiting := tx.Model(&models.OutboxEvent{}).Select("shift_schedule_id").
+			Where("published_at IS NULL AND next_attempt_at > ?", now)
+		err := tx.Where("published_at IS NULL AND shift_schedule_id NOT IN (?)", waiting).
+			Order("id").Limit(limit).Find(&pending).Error
+		if err != nil || len(pending) == 0 {
+			return err
+		}
+		ids := make([]uint64, 0, len(pending))
+		for _, entry := range pending {
+			ids = append(ids, entry.ID)
+		}
+		return tx.Model(&models.OutboxEvent{}).Where("id IN ?", ids).Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return pending, locked, nil
+}
+
+// MarkPublished marks the events published
+func (r *OutboxRepository) MarkPublished(ids []uint64, at time.Time) error {
+	return r.db.Model(&models.OutboxEvent{}).Where("id IN ?", ids).
+		Updates(map[string]interface{}{"published_at": at, "last_error": ""}).Error
+}
+
+// MarkFailed records a failed attempt to publish the events, the next one is made at next
+func (r *OutboxRepository) MarkFailed(ids []uint64, next time.Time, cause string) error {
+	return r.db.Model(&models.OutboxEvent{}).Where("id IN ?", ids).
+		Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"next_attempt_at": next,
+			"last_error":      cause,
+		}).Error
+}
+
+// Backlog returns the number of pending events and the creation time of the oldest one, nil without pending events
+func (r *OutboxRepository) Backlog() (int64, *time.Time, error) {
+	var backlog struct {
+		Count  int64
+		Oldest *time.Time
+	}
+	err := r.db.Model(&models.OutboxEvent{}).Select("COUNT(*) AS count, MIN(created_at) AS oldest").
+		Where("published_at IS NULL").Scan(&backlog).Error
+	if err != nil {
+		return 0, nil, err
+	}
+	return backlog.Count, backlog.Oldest, nil
+}
+
+// DeletePublished deletes the events published before the given time
+func (r *OutboxRepository) DeletePublished(before time.Time) (int64, error) {
+	result := r.db.Where("published_at IS NOT NULL AND published_at < ?", before).Delete(&models.OutboxEvent{})
+	return result.RowsAffected, result.Error
+}
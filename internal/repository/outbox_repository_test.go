@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"shyft/internal/testutil"
+)
+
+func TestClaim(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	lease := 5 * time.Minute
+	lock := regexp.QuoteMeta(`SELECT pg_try_advisory_xact_lock($1)`)
+
+	t.Run("pending events are leased in a transaction of their own", func(t *testing.T) {
+		db, mock := testutil.MockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WithArgs(outboxLock).
+			WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(true))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "outbox_events" WHERE published_at IS NULL AND shift_schedule_id NOT IN ` +
+			`(SELECT "shift_schedule_id" FROM "outbox_events" WHERE published_at IS NULL AND next_attempt_at > $1) ORDER BY id LIMIT 10`)).
+			WithArgs(now).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "shift_schedule_id", "event_type"}).
+				AddRow(1, 3, "shift_schedule.created").
+				AddRow(2, 4, "shift_schedule.updated"))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "outbox_events" SET "next_attempt_at"=$1 WHERE id IN ($2,$3)`)).
+			WithArgs(now.Add(lease), 1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		pending, locked, err := NewOutboxRepository(db).Claim(10, now, lease)
+		if err != nil {
+			t.Fatalf("Claim() error = %v", err)
+		}
+		if !locked || len(pending) != 2 || pending[0].ID != 1 || pending[1].ID != 2 {
+			t.Errorf("Claim() = %v, %v, want events 1 and 2", pending, locked)
+		}
+	})
+
+	t.Run("another replica holds the lock", func(t *testing.T) {
+		db, mock := testutil.MockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WithArgs(outboxLock).
+			WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(false))
+		mock.ExpectCommit()
+
+		pending, locked, err := NewOutboxRepository(db).Claim(10, now, lease)
+		if err != nil || locked || len(pending) != 0 {
+			t.Errorf("Claim() = %v, %v, %v, want nothing claimed", pending, locked, err)
+		}
+	})
+
+	t.Run("nothing is leased when the lease fails", func(t *testing.T) {
+		db, mock := testutil.MockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WithArgs(outboxLock).
+			WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(true))
+		mock.ExpectQuery(`SELECT \* FROM "outbox_events"`).WithArgs(now).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "shift_schedule_id"}).AddRow(1, 3))
+		mock.ExpectExec(`UPDATE "outbox_events"`).WillReturnError(errors.New("connection reset"))
+		mock.ExpectRollback()
+
+		pending, locked, err := NewOutboxRepository(db).Claim(10, now, lease)
+		if err == nil || locked || len(pending) != 0 {
+			t.Errorf("Claim() = %v, %v, %v, want an error", pending, locked, err)
+		}
+	})
+}
@@ -0,0 +1,274 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"shyft/internal/models"
+)
+
+// The organizations, managers, people and shifts tables are the source of truth for
+// the entries embedded into a shift schedule. The JSONB columns are kept as a read
+// projection of those tables so existing API responses and filters keep working.
+
+const (
+	organizationsTable = "organizations"
+	managersTable      = "managers"
+	peopleTable        = "people"
+)
+
+// ErrUnknownContact is returned when a schedule references an organization, manager or person by an id that
+// is not a live row of the schedule's organization
+var ErrUnknownContact = errors.New("unknown organization, manager or user")
+
+// resolveRelations resolves the organization, manager and users referenced by the schedule JSONB columns
+// and rewrites the JSONB entries from the stored rows. Managers and users are looked up in the schedule's
+// organization only, entries without an id are created in it.
+func resolveRelations(tx *gorm.DB, schedule *models.ShiftSchedule) error {
+	tx = tx.Session(&gorm.Session{NewDB: true})
+
+	organizations, err := resolveContacts(tx, organizationsTable, nil, schedule.Organization)
+	if err != nil {
+		return err
+	}
+	schedule.OrganizationID, schedule.ManagerID = nil, nil
+	if len(organizations) > 0 {
+		schedule.OrganizationID = &organizations[0].ID
+	}
+	managers, err := resolveContacts(tx, managersTable, schedule.OrganizationID, schedule.Manager)
+	if err != nil {
+		return err
+	}
+	users, err := resolveContacts(tx, peopleTable, schedule.OrganizationID, schedule.Users)
+	if err != nil {
+		return err
+	}
+	if len(managers) > 0 {
+		schedule.ManagerID = &managers[0].ID
+	}
+
+	schedule.Organization = projection(organizations)
+	schedule.Manager, err = managerProjection(tx, managers)
+	if err != nil {
+		return err
+	}
+	schedule.Users = projection(users)
+
+	// Shift users are resolved the same way as schedule users
+	for _, entry := range schedule.Shifts {
+		shift, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		user, ok := shift["user"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		contact, err := resolveContact(tx, peopleTable, schedule.OrganizationID, models.ContactFromJSON(user))
+		if err != nil {
+			return err
+		}
+		shift["user"] = contact.Projection()
+	}
+
+	return nil
+}
+
+// linkRelations replaces the schedule membership and shift rows of a saved schedule
+func linkRelations(tx *gorm.DB, schedule *models.ShiftSchedule) error {
+	tx = tx.Session(&gorm.Session{NewDB: true})
+
+	if err := tx.Where("shift_schedule_id = ?", schedule.ID).Delete(&models.ShiftSchedulePerson{}).Error; err != nil {
+		return err
+	}
+	var members []models.ShiftSchedulePerson
+	seen := map[uint]bool{}
+	for position, entry := range schedule.Users {
+		user, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, ok := models.JSONInt(user["id"])
+		if !ok || seen[uint(id)] {
+			continue
+		}
+		seen[uint(id)] = true
+		members = append(members, models.ShiftSchedulePerson{ShiftScheduleID: schedule.ID, PersonID: uint(id), Position: position})
+	}
+	if len(members) > 0 {
+		if err := tx.Create(&members).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Where("shift_schedule_id = ?", schedule.ID).Delete(&models.ScheduledShift{}).Error; err != nil {
+		return err
+	}
+	shifts := ScheduledShiftsOf(schedule)
+	if len(shifts) > 0 {
+		if err := tx.Create(&shifts).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ScheduledShiftsOf converts the schedule `shifts` JSONB into shift rows, skipping entries without valid times
+func ScheduledShiftsOf(schedule *models.ShiftSchedule) []models.ScheduledShift {
+	var shifts []models.ScheduledShift
+	seen := map[int]bool{}
+	for index, entry := range schedule.Shifts {
+		shift, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		start, _ := shift["start"].(string)
+		end, _ := shift["end"].(string)
+		startAt, err := models.ParseShiftTime(start, schedule.Location())
+		if err != nil {
+			continue
+		}
+		endAt, err := models.ParseShiftTime(end, schedule.Location())
+		if err != nil {
+			continue
+		}
+
+		shiftNo, ok := models.JSONInt(shift["id"])
+		if !ok || seen[shiftNo] {
+			shiftNo = index
+		}
+		seen[shiftNo] = true
+
+		row := models.ScheduledShift{ShiftScheduleID: schedule.ID, ShiftNo: shiftNo, StartAt: startAt, EndAt: endAt}
+		if user, ok := shift["user"].(map[string]interface{}); ok {
+			if id, ok := models.JSONInt(user["id"]); ok && id > 0 {
+				personID := uint(id)
+				row.PersonID = &personID
+			}
+		}
+		shifts = append(shifts, row)
+	}
+	return shifts
+}
+
+// resolve every entry of a JSONB column against table
+func resolveContacts(tx *gorm.DB, table string, organizationID *uint, entries models.JSONB) ([]models.Contact, error) {
+	var contacts []models.Contact
+	for _, entry := range entries {
+		values, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		contact, err := resolveContact(tx, table, organizationID, models.ContactFromJSON(values))
+		if err != nil {
+			return nil, err
+		}
+		contacts = append(contacts, contact)
+	}
+	return contacts, nil
+}
+
+// resolveContact returns the live row for an entry referenced by id (or mail), creating it when an entry
+// without an id is unknown. Managers and people are only looked up in, and created in, organizationID.
+// Stored rows win over the details embedded in the entry, so an entry may reference a row by id only.
+func resolveContact(tx *gorm.DB, table string, organizationID *uint, entry models.Contact) (models.Contact, error) {
+	query := tx.Table(table).Where("deleted_at IS NULL")
+	if table != organizationsTable {
+		if organizationID == nil {
+			return models.Contact{}, fmt.Errorf("%w: %s cannot be resolved without an organization", ErrUnknownContact, table)
+		}
+		query = query.Where("organization_id = ?", *organizationID)
+	}
+
+	var stored models.Contact
+	switch {
+	case entry.ID > 0:
+		err := query.Where("id = ?", entry.ID).Take(&stored).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return stored, fmt.Errorf("%w: %s %d", ErrUnknownContact, table, entry.ID)
+		}
+		return stored, err
+	case entry.Mail != "":
+		err := query.Where("lower(mail) = lower(?)", entry.Mail).Order("id").Take(&stored).Error
+		if err == nil || !errors.Is(err, gorm.ErrRecordNotFound) {
+			return stored, err
+		}
+	}
+
+	if entry.Name == "" {
+		return stored, fmt.Errorf("cannot create %s entry without a name", table)
+	}
+	if err := tx.Table(table).Create(&entry).Error; err != nil {
+		return stored, err
+	}
+	if table != organizationsTable {
+		updates := map[string]interface{}{"organization_id": *organizationID}
+		if table == managersTable {
+			manager := models.Manager{Contact: entry, OrganizationID: organizationID}
+			if err := linkPerson(tx, &manager); err != nil {
+				return stored, err
+			}
+			updates["person_id"] = manager.PersonID
+		}
+		if err := tx.Table(table).Where("id = ?", entry.ID).Updates(updates).Error; err != nil {
+			return stored, err
+		}
+	}
+	return entry, nil
+}
+
+// linkPerson checks that the person of the manager belongs to the manager's organization. A manager without
+// a person is linked to the person of its organization with the same mail, when there is one.
+func linkPerson(tx *gorm.DB, manager *models.Manager) error {
+	tx = tx.Session(&gorm.Session{NewDB: true})
+	if manager.OrganizationID == nil {
+		if manager.PersonID != nil {
+			return fmt.Errorf("%w: a manager without an organization cannot be linked to a user", ErrUnknownContact)
+		}
+		return nil
+	}
+
+	var person models.User
+	query := tx.Where("organization_id = ?", *manager.OrganizationID)
+	switch {
+	case manager.PersonID != nil:
+		err := query.Where("id = ?", *manager.PersonID).Take(&person).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: %s %d", ErrUnknownContact, peopleTable, *manager.PersonID)
+		}
+		return err
+	case manager.Mail != "":
+		err := query.Where("lower(mail) = lower(?)", manager.Mail).Order("id").Take(&person).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		manager.PersonID = &person.ID
+	}
+	return nil
+}
+
+func projection(contacts []models.Contact) models.JSONB {
+	result := models.JSONB{}
+	for _, contact := range contacts {
+		result = append(result, contact.Projection())
+	}
+	return result
+}
+
+// managerProjection projects the resolved managers together with the person each of them is
+func managerProjection(tx *gorm.DB, contacts []models.Contact) (models.JSONB, error) {
+	result := models.JSONB{}
+	for _, contact := range contacts {
+		var manager models.Manager
+		if err := tx.Where("id = ?", contact.ID).Take(&manager).Error; err != nil {
+			return nil, err
+		}
+		result = append(result, manager.Projection())
+	}
+	return result, nil
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"shyft/internal/models"
+	"shyft/internal/testutil"
+)
+
+var contactColumns = []string{"id", "name", "mail"}
+
+func TestResolveRelations(t *testing.T) {
+	t.Run("entries are resolved in the organization of the schedule", func(t *testing.T) {
+		db, mock := testutil.MockDB(t)
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "organizations" WHERE deleted_at IS NULL AND id = $1 LIMIT 1`)).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(contactColumns).AddRow(1, "Acme", ""))
+		// the manager is matched by mail case insensitively, the oldest one wins
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "managers" WHERE deleted_at IS NULL AND organization_id = $1 AND lower(mail) = lower($2) ORDER BY id LIMIT 1`)).
+			WithArgs(1, "Bob@Example.com").
+			WillReturnRows(sqlmock.NewRows(contactColumns).AddRow(4, "Bob", "bob@example.com"))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "people" WHERE deleted_at IS NULL AND organization_id = $1 AND id = $2 LIMIT 1`)).
+			WithArgs(1, 5).
+			WillReturnRows(sqlmock.NewRows(contactColumns).AddRow(5, "Bob", "bob@example.com"))
+		// the manager projection carries the person the manager is
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "managers" WHERE id = $1`)).
+			WithArgs(4).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "mail", "organization_id", "person_id"}).AddRow(4, "Bob", "bob@example.com", 1, 5))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "people" WHERE deleted_at IS NULL AND organization_id = $1 AND id = $2 LIMIT 1`)).
+			WithArgs(1, 5).
+			WillReturnRows(sqlmock.NewRows(contactColumns).AddRow(5, "Bob", "bob@example.com"))
+
+		schedule := &models.ShiftSchedule{
+			Organization: models.JSONB{map[string]interface{}{"id": 1.0}},
+			Manager:      models.JSONB{map[string]interface{}{"name": "Bob", "mail": "Bob@Example.com"}},
+			Users:        models.JSONB{map[string]interface{}{"id": 5.0}},
+			Shifts:       models.JSONB{map[string]interface{}{"id": 0.0, "user": map[string]interface{}{"id": 5.0}}},
+		}
+		if err := resolveRelations(db, schedule); err != nil {
+			t.Fatalf("resolveRelations() error = %v", err)
+		}
+		if schedule.OrganizationID == nil || *schedule.OrganizationID != 1 || schedule.ManagerID == nil || *schedule.ManagerID != 4 {
+			t.Errorf("resolveRelations() organization, manager = %v, %v, want 1, 4", schedule.OrganizationID, schedule.ManagerID)
+		}
+		manager := schedule.Manager[0].(map[string]interface{})
+		if personID, _ := manager["person_id"].(*uint); personID == nil || *personID != 5 {
+			t.Errorf("resolveRelations() manager = %v, want person 5", manager)
+		}
+		user := schedule.Shifts[0].(map[string]interface{})["user"].(map[string]interface{})
+		if user["name"] != "Bob" {
+			t.Errorf("resolveRelations() shift user = %v, want the stored person", user)
+		}
+	})
+
+	t.Run("a user of another organization is unknown", func(t *testing.T) {
+		db, mock := testutil.MockDB(t)
+		mock.ExpectQuery(`SELECT \* FROM "organizations"`).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(contactColumns).AddRow(1, "Acme", ""))
+		mock.ExpectQuery(`SELECT \* FROM "people"`).WithArgs(1, 9).
+			WillReturnRows(sqlmock.NewRows(contactColumns))
+
+		schedule := &models.ShiftSchedule{
+			Organization: models.JSONB{map[string]interface{}{"id": 1.0}},
+			Users:        models.JSONB{map[string]interface{}{"id": 9.0}},
+		}
+		if err := resolveRelations(db, schedule); !errors.Is(err, ErrUnknownContact) {
+			t.Errorf("resolveRelations() error = %v, want ErrUnknownContact", err)
+		}
+	})
+
+	t.Run("users cannot be resolved without an organization", func(t *testing.T) {
+		db, _ := testutil.MockDB(t)
+		schedule := &models.ShiftSchedule{Users: models.JSONB{map[string]interface{}{"id": 5.0}}}
+		if err := resolveRelations(db, schedule); !errors.Is(err, ErrUnknownContact) {
+			t.Errorf("resolveRelations() error = %v, want ErrUnknownContact", err)
+		}
+	})
+}
+
+func TestLinkPerson(t *testing.T) {
+	organizationID := uint(1)
+
+	t.Run("a manager is linked to the person of the organization with the same mail", func(t *testing.T) {
+		db, mock := testutil.MockDB(t)
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "people" WHERE organization_id = $1 AND lower(mail) = lower($2) AND "people"."deleted_at" IS NULL ORDER BY id LIMIT 1`)).
+			WithArgs(1, "Bob@Example.com").
+			WillReturnRows(sqlmock.NewRows(contactColumns).AddRow(5, "Bob", "bob@example.com"))
+
+		manager := &models.Manager{Contact: models.Contact{Name: "Bob", Mail: "Bob@Example.com"}, OrganizationID: &organizationID}
+		if err := linkPerson(db, manager); err != nil {
+			t.Fatalf("linkPerson() error = %v", err)
+		}
+		if manager.PersonID == nil || *manager.PersonID != 5 {
+			t.Errorf("linkPerson() person = %v, want 5", manager.PersonID)
+		}
+	})
+
+	t.Run("a manager without a person of the same mail stays unlinked", func(t *testing.T) {
+		db, mock := testutil.MockDB(t)
+		mock.ExpectQuery(`SELECT \* FROM "people"`).WithArgs(1, "carol@example.com").
+			WillReturnRows(sqlmock.NewRows(contactColumns))
+
+		manager := &models.Manager{Contact: models.Contact{Name: "Carol", Mail: "carol@example.com"}, OrganizationID: &organizationID}
+		if err := linkPerson(db, manager); err != nil || manager.PersonID != nil {
+			t.Errorf("linkPerson() = %v, person %v, want no person", err, manager.PersonID)
+		}
+	})
+
+	t.Run("the given person must belong to the organization", func(t *testing.T) {
+		db, mock := testutil.MockDB(t)
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "people" WHERE organization_id = $1 AND id = $2`)).
+			WithArgs(1, 9).
+			WillReturnRows(sqlmock.NewRows(contactColumns))
+
+		manager := &models.Manager{Contact: models.Contact{Name: "Bob"}, OrganizationID: &organizationID, PersonID: uintPtr(9)}
+		if err := linkPerson(db, manager); !errors.Is(err, ErrUnknownContact) {
+			t.Errorf("linkPerson() error = %v, want ErrUnknownContact", err)
+		}
+	})
+
+	t.Run("a manager without an organization cannot be linked", func(t *testing.T) {
+		db, _ := testutil.MockDB(t)
+		manager := &models.Manager{Contact: models.Contact{Name: "Bob"}, PersonID: uintPtr(5)}
+		if err := linkPerson(db, manager); !errors.Is(err, ErrUnknownContact) {
+			t.Errorf("linkPerson() error = %v, want ErrUnknownContact", err)
+		}
+	})
+}
+
+func uintPtr(i uint) *uint {
+	return &i
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// limit shift schedules to the ones owned by the given organization
+func organizationScope(organizationID int) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("shift_schedule.organization_id = ?", organizationID)
+	}
+}
+
+// limit shift schedules to the ones the given person manages or belongs to. Managers are matched by the
+// person they are linked to, mails case insensitively like policy.IsCaller.
+func visibleToScope(userID int, mail string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		id := strconv.Itoa(userID)
+		return db.Where(
+			`(EXISTS (SELECT 1 FROM jsonb_array_elements(manager) AS m WHERE m->>'person_id' = ? OR (? <> '' AND lower(m->>'mail') = lower(?))) OR
+        EXISTS (SELECT 1 FROM jsonb_array_elements(users) AS u WHERE u->>'id' = ? OR (? <> '' AND lower(u->>'mail') = lower(?))))`,
+			id, mail, mail, id, mail, mail,
+		)
+	}
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"shyft/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ShiftOverrideRepository struct {
+	db *gorm.DB
+}
+
+func NewShiftOverrideRepository(db *gorm.DB) *ShiftOverrideRepository {
+	return &ShiftOverrideRepository{db: db}
+}
+
+// ListBySchedule lists the overrides of a shift schedule overlapping [from, to), all of them when from and to are nil
+func (r *ShiftOverrideRepository) ListBySchedule(scheduleID uint, from, to *time.Time) ([]models.ShiftOverride, error) {
+	var overrides []models.ShiftOverride
+	query := r.withPerson().Where("shift_schedule_id = ?", scheduleID)
+	if from != nil {
+		query = query.Where("end_at > ?", *from)
+	}
+	if to != nil {
+		query = query.Where("start_at < ?", *to)
+	}
+	if err := query.Order("start_at, id").Find(&overrides).Error; err != nil {
+		return nil, err
+	}
+	return overrides, nil
+}
+
+// ListForSchedules lists the overrides of the given shift schedules overlapping [from, to), grouped by schedule
+func (r *ShiftOverrideRepository) ListForSchedules(scheduleIDs []uint, from, to time.Time) (map[uint][]models.ShiftOverride, error) {
+	result := map[uint][]models.ShiftOverride{}
+	if len(scheduleIDs) == 0 {
+		return result, nil
+	}
+	var overrides []models.ShiftOverride
+	err := r.withPerson().
+		Where("shift_schedule_id IN ? AND end_at > ? AND start_at < ?", scheduleIDs, from, to).
+		Order("id").Find(&overrides).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, override := range overrides {
+		result[override.ShiftScheduleID] = append(result[override.ShiftScheduleID], override)
+	}
+	return result, nil
+}
+
+func (r *ShiftOverrideRepository) FindByID(scheduleID uint, id string) (*models.ShiftOverride, error) {
+	var override models.ShiftOverride
+	if err := r.withPerson().Where("shift_schedule_id = ? AND id = ?", scheduleID, id).First(&override).Error; err != nil {
+		return nil, err
+	}
+	return &override, nil
+}
+
+// Create creates the override of the shift schedule and records its events in the same transaction
+func (r *ShiftOverrideRepository) Create(schedule *models.ShiftSchedule, override *models.ShiftOverride, events ...EventFunc) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Person").Create(override).Error; err != nil {
+			return err
+		}
+		return recordEvents(tx, schedule, events)
+	})
+}
+
+// Delete soft deletes the override of the shift schedule and records its events in the same transaction
+func (r *ShiftOverrideRepository) Delete(schedule *models.ShiftSchedule, override *models.ShiftOverride, events ...EventFunc) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(override).Error; err != nil {
+			return err
+		}
+		return recordEvents(tx, schedule, events)
+	})
+}
+
+// overrides keep showing the person that covered, even once the person is deleted
+func (r *ShiftOverrideRepository) withPerson() *gorm.DB {
+	return r.db.Preload("Person", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	})
+}
@@ -1,12 +1,18 @@
 package repository
 
 import (
+	"errors"
+	"fmt"
 	"shyft/internal/models"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+// ErrStaleShiftSchedule is returned when a shift schedule changed status since it was read
+var ErrStaleShiftSchedule = errors.New("shift schedule status was changed concurrently")
+
 type ShiftScheduleRepository struct {
 	db *gorm.DB
 }
@@ -15,6 +21,200 @@ func NewShiftScheduleRepository(db *gorm.DB) *ShiftScheduleRepository {
 	return &ShiftScheduleRepository{db: db}
 }
 
+// ForOrganization returns a repository whose queries are limited to the given organization (tenant)
+func (r *ShiftScheduleRepository) ForOrganization(organizationID int) *ShiftScheduleRepository {
+	return &ShiftScheduleRepository{db: r.db.Scopes(organizationScope(organizationID)).Session(&gorm.Session{})}
+}
+
+// VisibleTo returns a repository whose queries are limited to the schedules the given person manages or belongs to
+func (r *ShiftScheduleRepository) VisibleTo(userID int, mail string) *ShiftScheduleRepository {
+	return &ShiftScheduleRepository{db: r.db.Scopes(visibleToScope(userID, mail)).Session(&gorm.Session{})}
+}
+
+func (r *ShiftScheduleRepository) FindByID(id string) (*models.ShiftSchedule, error) {
+	var schedule models.ShiftSchedule
+	if err := r.db.Where("id = ?", id).First(&schedule).Error; err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// FindByIDWithDeleted finds a shift schedule by id including soft deleted ones
+func (r *ShiftScheduleRepository) FindByIDWithDeleted(id string) (*models.ShiftSchedule, error) {
+	var schedule models.ShiftSchedule
+	if err := r.db.Unscoped().Where("id = ?", id).First(&schedule).Error; err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (r *ShiftScheduleRepository) ListActive() ([]models.ShiftSchedule, error) {
+	var schedules []models.ShiftSchedule
+	if err := r.db.Where("deleted_at IS NULL").Find(&schedules).Error; err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+func (r *ShiftScheduleRepository) ListByYear(year string) ([]models.ShiftSchedule, error) {
+	var schedules []models.ShiftSchedule
+	if err := r.db.Where("deleted_at IS NULL AND year = ?", year).Find(&schedules).Error; err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// ListActiveAt lists the shift schedules whose start and end date cover the given time
+func (r *ShiftScheduleRepository) ListActiveAt(at time.Time) ([]models.ShiftSchedule, error) {
+	var schedules []models.ShiftSchedule
+	if err := r.db.Where("deleted_at IS NULL AND start_date <= ? AND end_date > ?", at, at).Order("id").Find(&schedules).Error; err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// ListOverlapping lists the shift schedules whose start and end date overlap [from, to), except the given one
+func (r *ShiftScheduleRepository) ListOverlapping(from, to time.Time, exceptID uint) ([]models.ShiftSchedule, error) {
+	var schedules []models.ShiftSchedule
+	err := r.db.Where("deleted_at IS NULL AND start_date < ? AND end_date > ? AND id <> ?", to, from, exceptID).
+		Order("id").Find(&schedules).Error
+	if err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// shiftTimeSQL reads the start or end of a shift JSONB entry `e` as a timestamp: RFC 3339 times carry their
+// offset, times written without one are in the time zone of the schedule, and other values (invalid dates
+// included) are NULL. See the shyft_shift_time migration.
+const shiftTimeSQL = `shyft_shift_time(e->>'%[1]s', shift_schedule.time_zone)`
+
+// ListTimeline lists the shift schedules whose start and end date overlap [from, to), with only the shifts
+// overlapping [from, to). Shifts are filtered in the database.
+func (r *ShiftScheduleRepository) ListTimeline(from, to time.Time) ([]models.ShiftSchedule, error) {
+	stmt := &gorm.Statement{DB: r.db}
+	if err := stmt.Parse(&models.ShiftSchedule{}); err != nil {
+		return nil, err
+	}
+	columns := make([]string, 0, len(stmt.Schema.DBNames))
+	for _, name := range stmt.Schema.DBNames {
+		if name != "shifts" {
+			columns = append(columns, "shift_schedule."+name)
+		}
+	}
+
+	overlapping := `COALESCE((SELECT jsonb_agg(e) FROM jsonb_array_elements(COALESCE(shift_schedule.shifts, '[]'::jsonb)) AS e
+		WHERE jsonb_typeof(e) = 'object' AND ` + fmt.Sprintf(shiftTimeSQL, "start") + ` < ? AND ` + fmt.Sprintf(shiftTimeSQL, "end") + ` > ?), '[]'::jsonb) AS shifts`
+
+	var schedules []models.ShiftSchedule
+	err := r.db.Select(strings.Join(columns, ", ")+", "+overlapping, to, from).
+		Where("deleted_at IS NULL AND start_date < ? AND end_date > ?", to, from).
+		Order("id").Find(&schedules).Error
+	if err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+func (r *ShiftScheduleRepository) ListDeleted() ([]models.ShiftSchedule, error) {
+	var schedules []models.ShiftSchedule
+	if err := r.db.Unscoped().Where("deleted_at IS NOT NULL").Find(&schedules).Error; err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// Create creates the shift schedule together with its organization, manager, users and shifts rows,
+// and writes the events of the creation to the outbox
+func (r *ShiftScheduleRepository) Create(schedule *models.ShiftSchedule, events ...EventFunc) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := resolveRelations(tx, schedule); err != nil {
+			return err
+		}
+		if err := tx.Create(schedule).Error; err != nil {
+			return err
+		}
+		if err := linkRelations(tx, schedule); err != nil {
+			return err
+		}
+		return recordEvents(tx, schedule, events)
+	})
+}
+
+// Save updates the shift schedule together with its organization, manager, users and shifts rows,
+// and writes the events of the update to the outbox
+func (r *ShiftScheduleRepository) Save(schedule *models.ShiftSchedule, events ...EventFunc) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := resolveRelations(tx, schedule); err != nil {
+			return err
+		}
+		if err := tx.Save(schedule).Error; err != nil {
+			return err
+		}
+		if err := linkRelations(tx, schedule); err != nil {
+			return err
+		}
+		return recordEvents(tx, schedule, events)
+	})
+}
+
+// Transition changes the status of the shift schedule, records the change in its history and writes the
+// events of the change to the outbox. ErrStaleShiftSchedule is returned when the schedule left its status
+// in the meantime.
+func (r *ShiftScheduleRepository) Transition(schedule *models.ShiftSchedule, transition *models.ShiftScheduleTransition, events ...EventFunc) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.ShiftSchedule{}).
+			Where("id = ? AND status = ?", schedule.ID, transition.FromStatus).
+			Update("status", transition.ToStatus)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrStaleShiftSchedule
+		}
+
+		transition.ShiftScheduleID = schedule.ID
+		if err := tx.Session(&gorm.Session{NewDB: true}).Create(transition).Error; err != nil {
+			return err
+		}
+		schedule.Status = transition.ToStatus
+		return recordEvents(tx, schedule, events)
+	})
+}
+
+// ListTransitions lists the status changes of the shift schedule, oldest first
+func (r *ShiftScheduleRepository) ListTransitions(schedule *models.ShiftSchedule) ([]models.ShiftScheduleTransition, error) {
+	var transitions []models.ShiftScheduleTransition
+	err := r.db.Session(&gorm.Session{NewDB: true}).
+		Where("shift_schedule_id = ?", schedule.ID).
+		Order("created_at, id").Find(&transitions).Error
+	if err != nil {
+		return nil, err
+	}
+	return transitions, nil
+}
+
+// Delete soft deletes the shift schedule and writes the events of the deletion to the outbox
+func (r *ShiftScheduleRepository) Delete(schedule *models.ShiftSchedule, events ...EventFunc) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(schedule).Error; err != nil {
+			return err
+		}
+		return recordEvents(tx, schedule, events)
+	})
+}
+
+// Restore restores the soft deleted shift schedule and writes the events of the restoration to the outbox
+func (r *ShiftScheduleRepository) Restore(schedule *models.ShiftSchedule, events ...EventFunc) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(schedule).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		schedule.DeletedAt = gorm.DeletedAt{}
+		return recordEvents(tx, schedule, events)
+	})
+}
+
 func (r *ShiftScheduleRepository) List(params models.ListParams) (*models.ShiftScheduleListResponse, error) {
 	var schedules []models.ShiftSchedule
 	var total int64
@@ -86,6 +286,14 @@ func (r *ShiftScheduleRepository) applyFilters(query *gorm.DB, params models.Lis
 		query = query.Where("year = ?", *params.Year)
 	}
 
+	// Owning organization and manager filters
+	if params.OrganizationID != nil {
+		query = query.Where("organization_id = ?", *params.OrganizationID)
+	}
+	if params.ManagerID != nil {
+		query = query.Where("manager_id = ?", *params.ManagerID)
+	}
+
 	// Organization filters
 	if params.OrganizationName != "" {
 		query = query.Where(
@@ -171,10 +379,16 @@ func (r *ShiftScheduleRepository) applyFilters(query *gorm.DB, params models.Lis
 	}
 
 	if params.RangeYear != nil {
-		loc, _ := time.LoadLocation("Europe/Istanbul")
-		yearStart := time.Date(*params.RangeYear, 1, 1, 0, 0, 0, 0, loc)
-		yearEnd := time.Date(*params.RangeYear, 12, 31, 23, 59, 59, 0, loc)
-		query = query.Where("start_date <= ? AND end_date >= ?", yearEnd, yearStart)
+		if loc, err := models.LoadLocation(params.TimeZone); err == nil {
+			yearStart := time.Date(*params.RangeYear, 1, 1, 0, 0, 0, 0, loc)
+			yearEnd := yearStart.AddDate(1, 0, 0)
+			query = query.Where("start_date < ? AND end_date >= ?", yearEnd, yearStart)
+		} else {
+			// The year is taken in the time zone of every schedule
+			yearStart := time.Date(*params.RangeYear, 1, 1, 0, 0, 0, 0, time.UTC).Format(models.ShiftTimeLayout)
+			yearEnd := time.Date(*params.RangeYear+1, 1, 1, 0, 0, 0, 0, time.UTC).Format(models.ShiftTimeLayout)
+			query = query.Where("start_date < (?::timestamp AT TIME ZONE time_zone) AND end_date >= (?::timestamp AT TIME ZONE time_zone)", yearEnd, yearStart)
+		}
 	}
 
 	return query
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"shyft/internal/models"
+	"shyft/internal/testutil"
+)
+
+func TestScopes(t *testing.T) {
+	// Managers are matched by the person they are linked to, never by their own id, and mails only when the
+	// caller has one
+	visible := regexp.QuoteMeta(`WHERE id = $1 AND shift_schedule.organization_id = $2 AND ` +
+		`((EXISTS (SELECT 1 FROM jsonb_array_elements(manager) AS m WHERE m->>'person_id' = $3 OR ($4 <> '' AND lower(m->>'mail') = lower($5))) OR`)
+	users := regexp.QuoteMeta(`EXISTS (SELECT 1 FROM jsonb_array_elements(users) AS u WHERE u->>'id' = $6 OR ($7 <> '' AND lower(u->>'mail') = lower($8)))))`)
+
+	tests := []struct {
+		name string
+		mail string
+	}{
+		{"a caller with a mail", "Alice@Example.com"},
+		{"a caller without a mail", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := testutil.MockDB(t)
+			mock.ExpectQuery(visible+`\s+`+users).
+				WithArgs("3", 7, "5", tt.mail, tt.mail, "5", tt.mail, tt.mail).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "alias"}).AddRow(3, "ops"))
+
+			schedule, err := NewShiftScheduleRepository(db).ForOrganization(7).VisibleTo(5, tt.mail).FindByID("3")
+			if err != nil {
+				t.Fatalf("FindByID() error = %v", err)
+			}
+			if schedule.ID != 3 || schedule.Alias != "ops" {
+				t.Errorf("FindByID() = %d %q, want 3 \"ops\"", schedule.ID, schedule.Alias)
+			}
+		})
+	}
+}
+
+func TestListTimeline(t *testing.T) {
+	db, mock := testutil.MockDB(t)
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+
+	// Shifts are filtered in the database by their times read in the time zone of the schedule
+	mock.ExpectQuery(regexp.QuoteMeta(`shift_schedule.time_zone, shift_schedule.swap_requires_approval, COALESCE((SELECT jsonb_agg(e) FROM jsonb_array_elements(COALESCE(shift_schedule.shifts, '[]'::jsonb)) AS e`)+
+		`\s+`+regexp.QuoteMeta(`WHERE jsonb_typeof(e) = 'object' AND shyft_shift_time(e->>'start', shift_schedule.time_zone) < $1 AND shyft_shift_time(e->>'end', shift_schedule.time_zone) > $2), '[]'::jsonb) AS shifts`)+
+		`.*`+regexp.QuoteMeta(`deleted_at IS NULL AND start_date < $3 AND end_date > $4`)+`.*ORDER BY id`).
+		WithArgs(to, from, to, from).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "alias", "time_zone", "shifts"}).
+			AddRow(3, "ops", "Europe/Istanbul", []byte(`[{"id": 1, "start": "2026-03-03 09:00:00", "end": "2026-03-04 09:00:00"}]`)))
+
+	schedules, err := NewShiftScheduleRepository(db).ListTimeline(from, to)
+	if err != nil {
+		t.Fatalf("ListTimeline() error = %v", err)
+	}
+	if len(schedules) != 1 || len(schedules[0].Shifts) != 1 {
+		t.Fatalf("ListTimeline() = %v, want a schedule with a shift", schedules)
+	}
+	if ref := models.ShiftRefOf(schedules[0].Shifts[0].(map[string]interface{})); ref.String() != "shift 1" {
+		t.Errorf("ListTimeline() shift = %s, want shift 1", ref)
+	}
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"errors"
+	"shyft/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrStaleShiftSwap is returned when a shift swap request changed status since it was read
+var ErrStaleShiftSwap = errors.New("shift swap was changed concurrently")
+
+type ShiftSwapRepository struct {
+	db *gorm.DB
+}
+
+func NewShiftSwapRepository(db *gorm.DB) *ShiftSwapRepository {
+	return &ShiftSwapRepository{db: db}
+}
+
+// ForOrganization returns a repository whose queries are limited to the swaps of the given organization (tenant)
+func (r *ShiftSwapRepository) ForOrganization(organizationID int) *ShiftSwapRepository {
+	return &ShiftSwapRepository{db: r.db.Where(
+		"shift_swaps.shift_schedule_id IN (SELECT id FROM shift_schedule WHERE organization_id = ?)", organizationID,
+	).Session(&gorm.Session{})}
+}
+
+// FindByID finds a shift swap request by id together with its history
+func (r *ShiftSwapRepository) FindByID(id string) (*models.ShiftSwap, error) {
+	var request models.ShiftSwap
+	if err := r.withHistory().Where("id = ?", id).First(&request).Error; err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// ListBySchedule lists the swap requests of a shift schedule, optionally only those in status
+func (r *ShiftSwapRepository) ListBySchedule(scheduleID uint, status string) ([]models.ShiftSwap, error) {
+	query := r.withHistory().Where("shift_schedule_id = ?", scheduleID)
+	return r.list(query, status)
+}
+
+// ListByUser lists the swap requests a user proposed or was asked for, optionally only those in status
+func (r *ShiftSwapRepository) ListByUser(userID uint, status string) ([]models.ShiftSwap, error) {
+	query := r.withHistory().Where("(requester_id = ? OR responder_id = ?)", userID, userID)
+	return r.list(query, status)
+}
+
+// Create creates the swap request and the first entry of its history
+func (r *ShiftSwapRepository) Create(request *models.ShiftSwap, actorID *int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		tx = tx.Session(&gorm.Session{NewDB: true})
+		request.Status = models.SwapPending
+		if err := tx.Omit("History").Create(request).Error; err != nil {
+			return err
+		}
+		event := models.ShiftSwapEvent{ShiftSwapID: request.ID, ToStatus: request.Status, ActorID: actorID, Comment: request.Comment}
+		if err := tx.Create(&event).Error; err != nil {
+			return err
+		}
+		request.History = []models.ShiftSwapEvent{event}
+		return nil
+	})
+}
+
+// Transition moves the swap request to status and records it in its history. When apply is given, it is
+// called with the locked shift schedule, which is saved in the same transaction (the swap is completed)
+// together with the events of the change. An error of apply (e.g. the schedule is approved or the swapped
+// shifts conflict) rolls the transition back. ErrStaleShiftSwap is returned when the request left its status
+// in the meantime.
+func (r *ShiftSwapRepository) Transition(request *models.ShiftSwap, status string, actorID *int, comment string, apply func(*models.ShiftSchedule) error, events ...EventFunc) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		tx = tx.Session(&gorm.Session{NewDB: true})
+
+		result := tx.Model(&models.ShiftSwap{}).
+			Where("id = ? AND status = ?", request.ID, request.Status).
+			Update("status", status)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrStaleShiftSwap
+		}
+
+		if apply != nil {
+			var schedule models.ShiftSchedule
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&schedule, request.ShiftScheduleID).Error; err != nil {
+				return err
+			}
+			if err := apply(&schedule); err != nil {
+				return err
+			}
+			if err := tx.Save(&schedule).Error; err != nil {
+				return err
+			}
+			if err := linkRelations(tx, &schedule); err != nil {
+				return err
+			}
+			if err := recordEvents(tx, &schedule, events); err != nil {
+				return err
+			}
+		}
+
+		event := models.ShiftSwapEvent{ShiftSwapID: request.ID, FromStatus: request.Status, ToStatus: status, ActorID: actorID, Comment: comment}
+		if err := tx.Create(&event).Error; err != nil {
+			return err
+		}
+		request.Status = status
+		request.History = append(request.History, event)
+		return nil
+	})
+}
+
+func (r *ShiftSwapRepository) list(query *gorm.DB, status string) ([]models.ShiftSwap, error) {
+	var requests []models.ShiftSwap
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Order("created_at DESC").Find(&requests).Error; err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+func (r *ShiftSwapRepository) withHistory() *gorm.DB {
+	return r.db.Preload("History", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at, id")
+	})
+}
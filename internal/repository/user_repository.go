@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"shyft/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type UserRepository struct {
+	db *gorm.DB
+}
+
+func NewUserRepository(db *gorm.DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+// List lists users, limited to the people of organizationID when given
+func (r *UserRepository) List(params models.ContactListParams, organizationID *uint) (*models.UserListResponse, error) {
+	var users []models.User
+
+	query := r.db.Model(&models.User{})
+	if organizationID != nil {
+		query = query.Where("organization_id = ?", *organizationID)
+	}
+	total, err := listContacts(query, params, &users)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.UserListResponse{
+		Data:       users,
+		Total:      total,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+		TotalPages: totalPages(total, params.PageSize),
+		SortBy:     params.SortBy,
+		SortOrder:  params.SortOrder,
+	}, nil
+}
+
+func (r *UserRepository) FindByID(id string) (*models.User, error) {
+	var user models.User
+	if err := r.db.Where("id = ?", id).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// FindByIDWithDeleted finds a user by id including soft deleted ones
+func (r *UserRepository) FindByIDWithDeleted(id string) (*models.User, error) {
+	var user models.User
+	if err := r.db.Unscoped().Where("id = ?", id).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *UserRepository) Create(user *models.User) error {
+	return r.db.Create(user).Error
+}
+
+// Save updates the user and the copies of their contact details embedded in shift schedules
+func (r *UserRepository) Save(user *models.User) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(user).Error; err != nil {
+			return err
+		}
+		return refreshProjection(tx, peopleTable, user.ID, user.Projection(), user.OrganizationID)
+	})
+}
+
+// Delete soft deletes the user
+func (r *UserRepository) Delete(user *models.User) error {
+	return r.db.Delete(user).Error
+}
+
+func (r *UserRepository) Restore(user *models.User) error {
+	return r.db.Unscoped().Model(user).Update("deleted_at", nil).Error
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"shyft/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+// ListByOrganization lists the webhook subscriptions of an organization
+func (r *WebhookRepository) ListByOrganization(organizationID uint) ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	if err := r.db.Where("organization_id = ?", organizationID).Order("id").Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// ListSubscribed lists the active webhook subscriptions of an organization to the event type
+func (r *WebhookRepository) ListSubscribed(organizationID uint, eventType string) ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	err := r.db.Where("organization_id = ? AND active", organizationID).
+		Where("(event_types = '[]'::jsonb OR event_types @> jsonb_build_array(?::text))", eventType).
+		Order("id").Find(&subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (r *WebhookRepository) FindByID(organizationID uint, id string) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	if err := r.db.Where("organization_id = ? AND id = ?", organizationID, id).First(&subscription).Error; err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// FindByIDs finds the webhook subscriptions by id, deleted ones are left out
+func (r *WebhookRepository) FindByIDs(ids []uint) (map[uint]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	if err := r.db.Where("id IN ?", ids).Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]models.WebhookSubscription, len(subscriptions))
+	for _, subscription := range subscriptions {
+		byID[subscription.ID] = subscription
+	}
+	return byID, nil
+}
+
+func (r *WebhookRepository) Create(subscription *models.WebhookSubscription) error {
+	return r.db.Create(subscription).Error
+}
+
+func (r *WebhookRepository) Save(subscription *models.WebhookSubscription) error {
+	return r.db.Save(subscription).Error
+}
+
+// Delete soft deletes the webhook subscription, its pending deliveries are dropped when they are attempted
+func (r *WebhookRepository) Delete(subscription *models.WebhookSubscription) error {
+	return r.db.Delete(subscription).Error
+}
+
+// Enqueue creates the deliveries, an event already delivered to a subscription is skipped
+func (r *WebhookRepository) Enqueue(deliveries []models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	for i := range deliveries {
+		if deliveries[i].NextAttemptAt.IsZero() {
+			deliveries[i].NextAttemptAt = time.Now()
+		}
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&deliveries).Error
+}
+
+// ListDeliveries lists the latest deliveries of a subscription, newest first, filtered by status when given
+func (r *WebhookRepository) ListDeliveries(subscriptionID uint, status string, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	query := r.db.Where("subscription_id = ?", subscriptionID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Order("id DESC").Limit(limit).Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookRepository) FindDelivery(subscriptionID uint, id string) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := r.db.Where("subscription_id = ? AND id = ?", subscriptionID, id).First(&delivery).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// Redeliver creates a new delivery of the payload of delivery, attempted right away
+func (r *WebhookRepository) Redeliver(delivery *models.WebhookDelivery) (*models.WebhookDelivery, error) {
+	redelivery := models.WebhookDelivery{
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		RedeliveryOf:   &delivery.ID,
+		Status:         models.DeliveryPending,
+		NextAttemptAt:  time.Now(),
+	}
+	if err := r.db.Create(&redelivery).Error; err != nil {
+		return nil, err
+	}
+	return &redelivery, nil
+}
+
+// Claim takes up to limit pending deliveries due at now and moves their next attempt to now + lease, so
+// other replicas leave them alone while they are attempted
+func (r *WebhookRepository) Claim(limit int, now time.Time, lease time.Duration) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := r.db.Raw(`UPDATE webhook_deliveries SET next_attempt_at = ?, updated_at = ?
+        WHERE id IN (SELECT id FROM webhook_deliveries WHERE status = ? AND next_attempt_at <= ?
+            ORDER BY next_attempt_at, id LIMIT ? FOR UPDATE SKIP LOCKED)
+        RETURNING *`, now.Add(lease), now, models.DeliveryPending, now, limit).Scan(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// Record saves the outcome of an attempt of the delivery
+func (r *WebhookRepository) Record(delivery *models.WebhookDelivery) error {
+	return r.db.Model(delivery).Select("status", "attempts", "next_attempt_at", "response_status", "last_error", "delivered_at").
+		Updates(delivery).Error
+}
@@ -0,0 +1,291 @@
+package rotation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"shyft/internal/models"
+)
+
+// Options customize how a rotation is laid out
+type Options struct {
+	Order        []int  // user ids in rotation order, defaults to the order of the schedule users
+	StartUserID  int    // user taking the first shift, defaults to the first user of the order
+	HandoverTime string // time of day ("15:04") shifts are handed over at, defaults to the schedule start time
+
+	// Unavailable reports people on leave, their turn is taken by the next available user of the order
+	Unavailable func(userID uint, start, end time.Time) bool
+
+	// Balance assigns every shift to the available user with the fewest hours, counting the hours of
+	// Load (worked before the schedule, per user id) and of the shifts generated so far. Ties follow the order.
+	Balance bool
+	Load    map[uint]float64
+
+	// Holidays are taken out of the regular rotation and assigned round-robin to the users of HolidayOrder
+	// (user ids), one shift per holiday day. IsHoliday reports whether the day starting at the given local
+	// midnight is a holiday.
+	HolidayOrder []int
+	IsHoliday    func(day time.Time) bool
+}
+
+var (
+	ErrNoUsers             = errors.New("shift schedule has no users to rotate")
+	ErrInvalidDateRange    = errors.New("shift schedule end date must be after its start date")
+	ErrInvalidHandoverTime = errors.New("handover time must be formatted as HH:MM")
+)
+
+// Generate lays out back-to-back round-robin shifts of Frequency days between the schedule
+// Start_Date and End_Date, the last shift ends at End_Date. With a handover time, shifts are handed over
+// from its first occurrence at or after Start_Date on, the first user takes the partial shift before it.
+func Generate(schedule *models.ShiftSchedule, opts Options) ([]models.Shift, error) {
+	if !schedule.End_Date.After(schedule.Start_Date) {
+		return nil, ErrInvalidDateRange
+	}
+	users, err := orderUsers(schedule.Users, opts.Order, opts.StartUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	frequency := schedule.Frequency
+	if frequency < 1 {
+		frequency = 7
+	}
+
+	loc := schedule.Location()
+	start, end := schedule.Start_Date.In(loc), schedule.End_Date.In(loc)
+	handover, err := firstHandover(start, opts.HandoverTime)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 1: Lay out the shift times, a partial shift until the first handover and then one every
+	// frequency days, the last shift is cut at the schedule end date
+	var times [][2]time.Time
+	if handover.After(start) {
+		times = append(times, [2]time.Time{start, earliest(handover, end)})
+	}
+	for shiftStart := handover; shiftStart.Before(end); shiftStart = shiftStart.AddDate(0, 0, frequency) {
+		times = append(times, [2]time.Time{shiftStart, earliest(shiftStart.AddDate(0, 0, frequency), end)})
+	}
+
+	// Step 2: Assign users round-robin until the schedule end date is covered
+	var shifts []models.Shift
+	load := map[uint]float64{}
+	for id, hours := range opts.Load {
+		load[id] = hours
+	}
+	for _, t := range times {
+		shiftStart, shiftEnd := t[0], t[1]
+		var user models.Contact
+		if opts.Balance {
+			user = balancedUser(users, len(shifts), shiftStart, shiftEnd, opts.Unavailable, load)
+		} else {
+			user = availableUser(users, len(shifts), shiftStart, shiftEnd, opts.Unavailable)
+		}
+		load[user.ID] += shiftEnd.Sub(shiftStart).Hours()
+		shifts = append(shifts, models.Shift{
+			ID:    len(shifts),
+			User:  user,
+			Start: shiftStart.Format(time.RFC3339),
+			End:   shiftEnd.Format(time.RFC3339),
+		})
+	}
+
+	// Step 3: Hand the holidays over to the holiday rotation
+	if len(opts.HolidayOrder) > 0 && opts.IsHoliday != nil {
+		holidayUsers, err := orderUsers(schedule.Users, opts.HolidayOrder, 0)
+		if err != nil {
+			return nil, err
+		}
+		shifts = withHolidayShifts(shifts, holidayUsers, start, end, opts)
+	}
+
+	return shifts, nil
+}
+
+// ToJSONB converts shifts to the shape stored in the schedule `shifts` column
+func ToJSONB(shifts []models.Shift) (models.JSONB, error) {
+	data, err := json.Marshal(shifts)
+	if err != nil {
+		return nil, err
+	}
+	result := models.JSONB{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// withHolidayShifts cuts the holidays between start and end out of the shifts and adds a shift per holiday
+// day, assigned round-robin to the holiday users. Shifts are sorted by start and renumbered.
+func withHolidayShifts(shifts []models.Shift, users []models.Contact, start, end time.Time, opts Options) []models.Shift {
+	loc := start.Location()
+
+	var holidays []models.Shift
+	for day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc); day.Before(end); day = day.AddDate(0, 0, 1) {
+		if !opts.IsHoliday(day) {
+			continue
+		}
+		holidayStart, holidayEnd := day, day.AddDate(0, 0, 1)
+		if holidayStart.Before(start) {
+			holidayStart = start
+		}
+		if holidayEnd.After(end) {
+			holidayEnd = end.In(loc)
+		}
+		if !holidayEnd.After(holidayStart) {
+			continue
+		}
+
+		var rest []models.Shift
+		for _, shift := range shifts {
+			rest = append(rest, cut(shift, holidayStart, holidayEnd)...)
+		}
+		shifts = rest
+		holidays = append(holidays, models.Shift{
+			Start: holidayStart.Format(time.RFC3339),
+			End:   holidayEnd.Format(time.RFC3339),
+			User:  availableUser(users, len(holidays), holidayStart, holidayEnd, opts.Unavailable),
+		})
+	}
+
+	shifts = append(shifts, holidays...)
+	sort.SliceStable(shifts, func(i, j int) bool {
+		a, _ := time.Parse(time.RFC3339, shifts[i].Start)
+		b, _ := time.Parse(time.RFC3339, shifts[j].Start)
+		return a.Before(b)
+	})
+	for i := range shifts {
+		shifts[i].ID = i
+	}
+	return shifts
+}
+
+// cut removes [start, end) from a generated shift
+func cut(shift models.Shift, start, end time.Time) []models.Shift {
+	shiftStart, err := time.Parse(time.RFC3339, shift.Start)
+	if err != nil {
+		return []models.Shift{shift}
+	}
+	shiftEnd, err := time.Parse(time.RFC3339, shift.End)
+	if err != nil {
+		return []models.Shift{shift}
+	}
+	if !shiftStart.Before(end) || !shiftEnd.After(start) {
+		return []models.Shift{shift}
+	}
+
+	var pieces []models.Shift
+	if shiftStart.Before(start) {
+		piece := shift
+		piece.End = start.Format(time.RFC3339)
+		pieces = append(pieces, piece)
+	}
+	if shiftEnd.After(end) {
+		piece := shift
+		piece.Start = end.Format(time.RFC3339)
+		pieces = append(pieces, piece)
+	}
+	return pieces
+}
+
+// availableUser returns the user whose turn it is, or the next available one when they are on leave.
+// The user whose turn it is stays assigned when nobody is available.
+func availableUser(users []models.Contact, turn int, start, end time.Time, unavailable func(uint, time.Time, time.Time) bool) models.Contact {
+	user := users[turn%len(users)]
+	if unavailable == nil {
+		return user
+	}
+	for i := 0; i < len(users); i++ {
+		candidate := users[(turn+i)%len(users)]
+		if !unavailable(candidate.ID, start, end) {
+			return candidate
+		}
+	}
+	return user
+}
+
+// balancedUser returns the available user with the fewest hours in load, the first one of the order
+// starting at turn on ties. The user whose turn it is stays assigned when nobody is available.
+func balancedUser(users []models.Contact, turn int, start, end time.Time, unavailable func(uint, time.Time, time.Time) bool, load map[uint]float64) models.Contact {
+	best := -1
+	for i := 0; i < len(users); i++ {
+		candidate := (turn + i) % len(users)
+		if unavailable != nil && unavailable(users[candidate].ID, start, end) {
+			continue
+		}
+		if best == -1 || load[users[candidate].ID] < load[users[best].ID] {
+			best = candidate
+		}
+	}
+	if best == -1 {
+		return users[turn%len(users)]
+	}
+	return users[best]
+}
+
+// order the schedule users by the requested order and rotate them so startUserID goes first
+func orderUsers(entries models.JSONB, order []int, startUserID int) ([]models.Contact, error) {
+	var users []models.Contact
+	byID := map[int]models.Contact{}
+	for _, entry := range entries {
+		values, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		user := models.ContactFromJSON(values)
+		users = append(users, user)
+		byID[int(user.ID)] = user
+	}
+
+	if len(order) > 0 {
+		users = nil
+		for _, id := range order {
+			user, ok := byID[id]
+			if !ok {
+				return nil, fmt.Errorf("user %d of the rotation order does not belong to the shift schedule", id)
+			}
+			users = append(users, user)
+		}
+	}
+	if len(users) == 0 {
+		return nil, ErrNoUsers
+	}
+
+	if startUserID != 0 {
+		for i, user := range users {
+			if int(user.ID) == startUserID {
+				rotated := append([]models.Contact{}, users[i:]...)
+				return append(rotated, users[:i]...), nil
+			}
+		}
+		return nil, fmt.Errorf("start user %d is not part of the rotation", startUserID)
+	}
+	return users, nil
+}
+
+// firstHandover returns the first occurrence of the handover time at or after start, start itself without one
+func firstHandover(start time.Time, handoverTime string) (time.Time, error) {
+	if handoverTime == "" {
+		return start, nil
+	}
+	handover, err := time.Parse("15:04", handoverTime)
+	if err != nil {
+		return start, ErrInvalidHandoverTime
+	}
+	first := time.Date(start.Year(), start.Month(), start.Day(), handover.Hour(), handover.Minute(), 0, 0, start.Location())
+	if first.Before(start) {
+		first = time.Date(start.Year(), start.Month(), start.Day()+1, handover.Hour(), handover.Minute(), 0, 0, start.Location())
+	}
+	return first, nil
+}
+
+func earliest(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,232 @@
+package rotation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"shyft/internal/models"
+	"shyft/internal/testutil"
+)
+
+func users(ids ...int) []models.Contact {
+	contacts := make([]models.Contact, 0, len(ids))
+	for _, id := range ids {
+		contacts = append(contacts, models.Contact{ID: uint(id), Name: fmt.Sprintf("user %d", id)})
+	}
+	return contacts
+}
+
+func schedule(timeZone, start, end string, frequency int, users []models.Contact) *models.ShiftSchedule {
+	shiftSchedule := testutil.Schedule(timeZone, start, end, users...)
+	shiftSchedule.Frequency = frequency
+	return shiftSchedule
+}
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule *models.ShiftSchedule
+		opts     Options
+		want     []string
+	}{
+		{
+			name:     "weekly round-robin",
+			schedule: schedule("UTC", "2026-01-05 09:00:00", "2026-01-26 09:00:00", 7, users(1, 2, 3)),
+			want: []string{
+				"shift 0 2026-01-05T09:00:00Z 2026-01-12T09:00:00Z 1",
+				"shift 1 2026-01-12T09:00:00Z 2026-01-19T09:00:00Z 2",
+				"shift 2 2026-01-19T09:00:00Z 2026-01-26T09:00:00Z 3",
+			},
+		},
+		{
+			name:     "the last shift is cut at the end date",
+			schedule: schedule("UTC", "2026-01-05 09:00:00", "2026-01-15 09:00:00", 7, users(1, 2)),
+			want: []string{
+				"shift 0 2026-01-05T09:00:00Z 2026-01-12T09:00:00Z 1",
+				"shift 1 2026-01-12T09:00:00Z 2026-01-15T09:00:00Z 2",
+			},
+		},
+		{
+			name:     "a week without frequency",
+			schedule: schedule("UTC", "2026-01-05 09:00:00", "2026-01-19 09:00:00", 0, users(1, 2)),
+			want: []string{
+				"shift 0 2026-01-05T09:00:00Z 2026-01-12T09:00:00Z 1",
+				"shift 1 2026-01-12T09:00:00Z 2026-01-19T09:00:00Z 2",
+			},
+		},
+		{
+			name:     "a handover later in the day starts with a partial shift",
+			schedule: schedule("UTC", "2026-01-05 09:00:00", "2026-01-19 09:00:00", 7, users(1, 2, 3)),
+			opts:     Options{HandoverTime: "15:00"},
+			want: []string{
+				"shift 0 2026-01-05T09:00:00Z 2026-01-05T15:00:00Z 1",
+				"shift 1 2026-01-05T15:00:00Z 2026-01-12T15:00:00Z 2",
+				"shift 2 2026-01-12T15:00:00Z 2026-01-19T09:00:00Z 3",
+			},
+		},
+		{
+			name:     "a handover earlier in the day is handed over the next day",
+			schedule: schedule("UTC", "2026-01-05 09:00:00", "2026-01-13 09:00:00", 7, users(1, 2)),
+			opts:     Options{HandoverTime: "08:00"},
+			want: []string{
+				"shift 0 2026-01-05T09:00:00Z 2026-01-06T08:00:00Z 1",
+				"shift 1 2026-01-06T08:00:00Z 2026-01-13T08:00:00Z 2",
+				"shift 2 2026-01-13T08:00:00Z 2026-01-13T09:00:00Z 1",
+			},
+		},
+		{
+			name:     "a handover at the start time",
+			schedule: schedule("UTC", "2026-01-05 09:00:00", "2026-01-12 09:00:00", 7, users(1, 2)),
+			opts:     Options{HandoverTime: "09:00"},
+			want:     []string{"shift 0 2026-01-05T09:00:00Z 2026-01-12T09:00:00Z 1"},
+		},
+		{
+			name:     "daily shifts keep the local time across a daylight saving time change",
+			schedule: schedule("Europe/Berlin", "2026-03-28 09:00:00", "2026-03-30 09:00:00", 1, users(1, 2)),
+			want: []string{
+				"shift 0 2026-03-28T09:00:00+01:00 2026-03-29T09:00:00+02:00 1",
+				"shift 1 2026-03-29T09:00:00+02:00 2026-03-30T09:00:00+02:00 2",
+			},
+		},
+		{
+			name:     "rotation order and start user",
+			schedule: schedule("UTC", "2026-01-05 09:00:00", "2026-01-26 09:00:00", 7, users(1, 2, 3)),
+			opts:     Options{Order: []int{3, 1}, StartUserID: 1},
+			want: []string{
+				"shift 0 2026-01-05T09:00:00Z 2026-01-12T09:00:00Z 1",
+				"shift 1 2026-01-12T09:00:00Z 2026-01-19T09:00:00Z 3",
+				"shift 2 2026-01-19T09:00:00Z 2026-01-26T09:00:00Z 1",
+			},
+		},
+		{
+			name:     "the next available user takes the turn of a user on leave",
+			schedule: schedule("UTC", "2026-01-05 09:00:00", "2026-01-26 09:00:00", 7, users(1, 2, 3)),
+			opts: Options{Unavailable: func(userID uint, start, end time.Time) bool {
+				return userID == 2 && start.Day() == 12
+			}},
+			want: []string{
+				"shift 0 2026-01-05T09:00:00Z 2026-01-12T09:00:00Z 1",
+				"shift 1 2026-01-12T09:00:00Z 2026-01-19T09:00:00Z 3",
+				"shift 2 2026-01-19T09:00:00Z 2026-01-26T09:00:00Z 3",
+			},
+		},
+		{
+			name:     "the user whose turn it is stays assigned when nobody is available",
+			schedule: schedule("UTC", "2026-01-05 09:00:00", "2026-01-19 09:00:00", 7, users(1, 2)),
+			opts:     Options{Unavailable: func(uint, time.Time, time.Time) bool { return true }},
+			want: []string{
+				"shift 0 2026-01-05T09:00:00Z 2026-01-12T09:00:00Z 1",
+				"shift 1 2026-01-12T09:00:00Z 2026-01-19T09:00:00Z 2",
+			},
+		},
+		{
+			name:     "balanced by hours worked",
+			schedule: schedule("UTC", "2026-01-05 09:00:00", "2026-01-26 09:00:00", 7, users(1, 2, 3)),
+			opts:     Options{Balance: true, Load: map[uint]float64{1: 100}},
+			want: []string{
+				"shift 0 2026-01-05T09:00:00Z 2026-01-12T09:00:00Z 2",
+				"shift 1 2026-01-12T09:00:00Z 2026-01-19T09:00:00Z 3",
+				"shift 2 2026-01-19T09:00:00Z 2026-01-26T09:00:00Z 1",
+			},
+		},
+		{
+			name:     "holidays are taken out of the regular rotation",
+			schedule: schedule("UTC", "2026-01-05 09:00:00", "2026-01-12 09:00:00", 7, users(1, 2)),
+			opts: Options{HolidayOrder: []int{2}, IsHoliday: func(day time.Time) bool {
+				return day.Day() == 7
+			}},
+			want: []string{
+				"shift 0 2026-01-05T09:00:00Z 2026-01-07T00:00:00Z 1",
+				"shift 1 2026-01-07T00:00:00Z 2026-01-08T00:00:00Z 2",
+				"shift 2 2026-01-08T00:00:00Z 2026-01-12T09:00:00Z 1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shifts, err := Generate(tt.schedule, tt.opts)
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			if got, want := testutil.DescribeShifts(shifts), strings.Join(tt.want, "\n"); got != want {
+				t.Errorf("Generate() =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	valid := func() *models.ShiftSchedule {
+		return schedule("UTC", "2026-01-05 09:00:00", "2026-01-26 09:00:00", 7, users(1, 2))
+	}
+	reversed := valid()
+	reversed.Start_Date, reversed.End_Date = reversed.End_Date, reversed.Start_Date
+	empty := valid()
+	empty.Users = models.JSONB{"not an object"}
+
+	tests := []struct {
+		name     string
+		schedule *models.ShiftSchedule
+		opts     Options
+		want     error
+	}{
+		{"end before start", reversed, Options{}, ErrInvalidDateRange},
+		{"empty range", schedule("UTC", "2026-01-05 09:00:00", "2026-01-05 09:00:00", 7, users(1)), Options{}, ErrInvalidDateRange},
+		{"no users", empty, Options{}, ErrNoUsers},
+		{"invalid handover time", valid(), Options{HandoverTime: "9 am"}, ErrInvalidHandoverTime},
+		{"unknown user in the order", valid(), Options{Order: []int{1, 4}}, nil},
+		{"unknown start user", valid(), Options{StartUserID: 4}, nil},
+		{"unknown user in the holiday order", valid(), Options{HolidayOrder: []int{4}, IsHoliday: func(time.Time) bool { return false }}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shifts, err := Generate(tt.schedule, tt.opts)
+			if err == nil {
+				t.Fatalf("Generate() = %v, want an error", testutil.DescribeShifts(shifts))
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("Generate() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestToJSONB(t *testing.T) {
+	rule := 1
+	shifts := []models.Shift{
+		{ID: 0, Start: "2026-01-05T09:00:00Z", End: "2026-01-12T09:00:00Z", User: models.Contact{ID: 1, Name: "user 1"}},
+		{ID: 1, Start: "2026-01-12T09:00:00Z", End: "2026-01-19T09:00:00Z", User: models.Contact{ID: 2}, Rule: &rule},
+	}
+	entries, err := ToJSONB(shifts)
+	if err != nil {
+		t.Fatalf("ToJSONB() error = %v", err)
+	}
+	if len(entries) != len(shifts) {
+		t.Fatalf("ToJSONB() returned %d entries, want %d", len(entries), len(shifts))
+	}
+	for i, entry := range entries {
+		values, ok := entry.(map[string]interface{})
+		if !ok {
+			t.Fatalf("entry %d is %T, want an object", i, entry)
+		}
+		if id, _ := models.JSONInt(values["id"]); id != shifts[i].ID {
+			t.Errorf("entry %d id = %v, want %d", i, values["id"], shifts[i].ID)
+		}
+		if values["start"] != shifts[i].Start || values["end"] != shifts[i].End {
+			t.Errorf("entry %d = %v, want the times of %+v", i, values, shifts[i])
+		}
+		if user := models.ContactFromJSON(values["user"].(map[string]interface{})); user.ID != shifts[i].User.ID {
+			t.Errorf("entry %d user = %+v, want %+v", i, user, shifts[i].User)
+		}
+	}
+	if _, ok := entries[0].(map[string]interface{})["rule"]; ok {
+		t.Error("entry 0 has a rule, want it omitted")
+	}
+	if rule, _ := models.JSONInt(entries[1].(map[string]interface{})["rule"]); rule != 1 {
+		t.Errorf("entry 1 rule = %d, want 1", rule)
+	}
+}
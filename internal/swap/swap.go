@@ -0,0 +1,104 @@
+package swap
+
+import (
+	"errors"
+
+	"shyft/internal/models"
+)
+
+// Action is an operation on a shift swap request
+type Action string
+
+const (
+	ActionAccept  Action = "accept"  // by the responder
+	ActionDecline Action = "decline" // by the responder
+	ActionCancel  Action = "cancel"  // by the requester
+	ActionApprove Action = "approve" // by the schedule manager
+	ActionReject  Action = "reject"  // by the schedule manager
+)
+
+var (
+	ErrIllegalTransition = errors.New("shift swap cannot be changed this way in its current status")
+	ErrShiftNotFound     = errors.New("shift not found in the shift schedule")
+	ErrShiftNotAssigned  = errors.New("shift is not assigned to a known user")
+	ErrSameUser          = errors.New("shifts of the same user cannot be swapped")
+	ErrShiftReassigned   = errors.New("shift was reassigned since the swap was proposed")
+)
+
+// Transition returns the status a swap request in status moves to on action
+func Transition(status string, action Action, requiresApproval bool) (string, error) {
+	switch {
+	case status == models.SwapPending && action == ActionAccept && requiresApproval:
+		return models.SwapAccepted, nil
+	case status == models.SwapPending && action == ActionAccept:
+		return models.SwapCompleted, nil
+	case status == models.SwapPending && action == ActionDecline:
+		return models.SwapDeclined, nil
+	case status == models.SwapAccepted && action == ActionApprove:
+		return models.SwapCompleted, nil
+	case status == models.SwapAccepted && action == ActionReject:
+		return models.SwapRejected, nil
+	case (status == models.SwapPending || status == models.SwapAccepted) && action == ActionCancel:
+		return models.SwapCancelled, nil
+	}
+	return "", ErrIllegalTransition
+}
+
+// AssignedUser returns the user of the shift with the given id
+func AssignedUser(schedule *models.ShiftSchedule, shiftID int) (models.Contact, error) {
+	shift, ok := findShift(schedule, shiftID)
+	if !ok {
+		return models.Contact{}, ErrShiftNotFound
+	}
+	user, ok := shift["user"].(map[string]interface{})
+	if !ok {
+		return models.Contact{}, ErrShiftNotAssigned
+	}
+	contact := models.ContactFromJSON(user)
+	if contact.ID == 0 {
+		return contact, ErrShiftNotAssigned
+	}
+	return contact, nil
+}
+
+// Apply swaps the users of the two shifts of the request in the schedule, provided they
+// are still assigned to the requester and the responder
+func Apply(schedule *models.ShiftSchedule, request *models.ShiftSwap) error {
+	requesterShift, ok := findShift(schedule, request.RequesterShiftID)
+	if !ok {
+		return ErrShiftNotFound
+	}
+	responderShift, ok := findShift(schedule, request.ResponderShiftID)
+	if !ok {
+		return ErrShiftNotFound
+	}
+
+	requester, err := AssignedUser(schedule, request.RequesterShiftID)
+	if err != nil {
+		return err
+	}
+	responder, err := AssignedUser(schedule, request.ResponderShiftID)
+	if err != nil {
+		return err
+	}
+	if requester.ID != request.RequesterID || responder.ID != request.ResponderID {
+		return ErrShiftReassigned
+	}
+
+	requesterShift["user"], responderShift["user"] = responderShift["user"], requesterShift["user"]
+	return nil
+}
+
+// find the shift entry with the given id
+func findShift(schedule *models.ShiftSchedule, shiftID int) (map[string]interface{}, bool) {
+	for _, entry := range schedule.Shifts {
+		shift, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if id, ok := models.JSONInt(shift["id"]); ok && id == shiftID {
+			return shift, true
+		}
+	}
+	return nil, false
+}
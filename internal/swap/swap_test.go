@@ -0,0 +1,100 @@
+package swap
+
+import (
+	"errors"
+	"testing"
+
+	"shyft/internal/models"
+)
+
+func TestTransition(t *testing.T) {
+	tests := []struct {
+		status           string
+		action           Action
+		requiresApproval bool
+		want             string
+		wantErr          error
+	}{
+		{models.SwapPending, ActionAccept, false, models.SwapCompleted, nil},
+		{models.SwapPending, ActionAccept, true, models.SwapAccepted, nil},
+		{models.SwapPending, ActionDecline, false, models.SwapDeclined, nil},
+		{models.SwapPending, ActionCancel, false, models.SwapCancelled, nil},
+		{models.SwapAccepted, ActionApprove, true, models.SwapCompleted, nil},
+		{models.SwapAccepted, ActionReject, true, models.SwapRejected, nil},
+		{models.SwapAccepted, ActionCancel, true, models.SwapCancelled, nil},
+		{models.SwapPending, ActionApprove, true, "", ErrIllegalTransition},
+		{models.SwapAccepted, ActionAccept, true, "", ErrIllegalTransition},
+		{models.SwapCompleted, ActionCancel, false, "", ErrIllegalTransition},
+		{models.SwapDeclined, ActionAccept, false, "", ErrIllegalTransition},
+		{models.SwapCancelled, ActionDecline, false, "", ErrIllegalTransition},
+	}
+	for _, tt := range tests {
+		got, err := Transition(tt.status, tt.action, tt.requiresApproval)
+		if got != tt.want || !errors.Is(err, tt.wantErr) {
+			t.Errorf("Transition(%s, %s, %v) = %q, %v, want %q, %v", tt.status, tt.action, tt.requiresApproval, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func schedule() *models.ShiftSchedule {
+	return &models.ShiftSchedule{Shifts: models.JSONB{
+		map[string]interface{}{"id": 0.0, "user": map[string]interface{}{"id": 1.0, "name": "Alice"}},
+		map[string]interface{}{"id": 1.0, "user": map[string]interface{}{"id": 2.0, "name": "Bob"}},
+		map[string]interface{}{"id": 2.0, "user": map[string]interface{}{"name": "Unknown"}},
+		map[string]interface{}{"id": 3.0},
+		map[string]interface{}{"rule": 0.0, "occurrence": 4.0, "user": map[string]interface{}{"id": 3.0}},
+		"not an object",
+	}}
+}
+
+func TestAssignedUser(t *testing.T) {
+	tests := []struct {
+		shiftID int
+		want    uint
+		wantErr error
+	}{
+		{0, 1, nil},
+		{1, 2, nil},
+		{2, 0, ErrShiftNotAssigned},
+		{3, 0, ErrShiftNotAssigned},
+		{4, 0, ErrShiftNotFound}, // occurrences are not stored shifts
+		{9, 0, ErrShiftNotFound},
+	}
+	for _, tt := range tests {
+		got, err := AssignedUser(schedule(), tt.shiftID)
+		if got.ID != tt.want || !errors.Is(err, tt.wantErr) {
+			t.Errorf("AssignedUser(%d) = %d, %v, want %d, %v", tt.shiftID, got.ID, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name    string
+		request models.ShiftSwap
+		wantErr error
+	}{
+		{"swaps the users", models.ShiftSwap{RequesterID: 1, RequesterShiftID: 0, ResponderID: 2, ResponderShiftID: 1}, nil},
+		{"unknown shift", models.ShiftSwap{RequesterID: 1, RequesterShiftID: 0, ResponderID: 2, ResponderShiftID: 9}, ErrShiftNotFound},
+		{"unassigned shift", models.ShiftSwap{RequesterID: 1, RequesterShiftID: 0, ResponderID: 2, ResponderShiftID: 3}, ErrShiftNotAssigned},
+		{"reassigned requester shift", models.ShiftSwap{RequesterID: 3, RequesterShiftID: 0, ResponderID: 2, ResponderShiftID: 1}, ErrShiftReassigned},
+		{"reassigned responder shift", models.ShiftSwap{RequesterID: 1, RequesterShiftID: 0, ResponderID: 3, ResponderShiftID: 1}, ErrShiftReassigned},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := schedule()
+			err := Apply(s, &tt.request)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Apply() error = %v, want %v", err, tt.wantErr)
+			}
+			requester, _ := AssignedUser(s, 0)
+			responder, _ := AssignedUser(s, 1)
+			if tt.wantErr == nil && (requester.ID != 2 || responder.ID != 1) {
+				t.Errorf("Apply() assigned %d and %d, want the users swapped", requester.ID, responder.ID)
+			}
+			if tt.wantErr != nil && (requester.ID != 1 || responder.ID != 2) {
+				t.Errorf("failed Apply() assigned %d and %d, want the schedule unchanged", requester.ID, responder.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Package testutil holds the fixtures the tests of the shift schedule packages share: times, shift schedules,
+// shift entries and the descriptions their results are compared by.
+package testutil
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"shyft/internal/models"
+)
+
+// At returns the time of a day of March 2026 in UTC, the month the tests plan their shifts in
+func At(day, hour, minute int) time.Time {
+	return time.Date(2026, 3, day, hour, minute, 0, 0, time.UTC)
+}
+
+// ParseIn parses a time of the shift time layout in the IANA time zone, it panics on invalid values
+func ParseIn(timeZone, value string) time.Time {
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		panic(err)
+	}
+	t, err := time.ParseInLocation(models.ShiftTimeLayout, value, loc)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Schedule returns a shift schedule of the IANA time zone from start to end (shift time layout) with the users
+func Schedule(timeZone, start, end string, users ...models.Contact) *models.ShiftSchedule {
+	return &models.ShiftSchedule{
+		Start_Date: ParseIn(timeZone, start),
+		End_Date:   ParseIn(timeZone, end),
+		TimeZone:   timeZone,
+		Users:      Users(users...),
+	}
+}
+
+// Users returns the `users` entries of the contacts
+func Users(users ...models.Contact) models.JSONB {
+	entries := models.JSONB{}
+	for _, user := range users {
+		entries = append(entries, user.Projection())
+	}
+	return entries
+}
+
+// Entry returns a shift entry from start to end (RFC 3339 or shift time layout) assigned to the user, the
+// reference keys (`id`, `rule` and `occurrence`, `override`) are left to the caller
+func Entry(start, end string, user models.Contact) map[string]interface{} {
+	return map[string]interface{}{"start": start, "end": end, "user": user.Projection()}
+}
+
+// DescribeShifts writes a shift as "<ref> <start> <end> <user id>", e.g. "shift 0 ..." or
+// "occurrence 3 of rule 0 ..." for the shifts of a recurrence rule
+func DescribeShifts(shifts []models.Shift) string {
+	var lines []string
+	for _, shift := range shifts {
+		id := shift.ID
+		ref := models.ShiftRef{ShiftID: &id}
+		if shift.Rule != nil {
+			ref = models.ShiftRef{Rule: shift.Rule, Occurrence: &id}
+		}
+		lines = append(lines, fmt.Sprintf("%s %s %s %d", ref, shift.Start, shift.End, shift.User.ID))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// DescribeEntries writes a shift entry like DescribeShifts, entries that are not objects as they are
+func DescribeEntries(entries models.JSONB) string {
+	var lines []string
+	for _, entry := range entries {
+		values, ok := entry.(map[string]interface{})
+		if !ok {
+			lines = append(lines, fmt.Sprint(entry))
+			continue
+		}
+		var user models.Contact
+		if projection, ok := values["user"].(map[string]interface{}); ok {
+			user = models.ContactFromJSON(projection)
+		}
+		lines = append(lines, fmt.Sprintf("%s %s %s %d", models.ShiftRefOf(values), values["start"], values["end"], user.ID))
+	}
+	return strings.Join(lines, "\n")
+}
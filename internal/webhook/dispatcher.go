@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+
+	"shyft/internal/events"
+	"shyft/internal/models"
+	"shyft/internal/repository"
+	"shyft/pkg/broker"
+	"shyft/pkg/logger"
+)
+
+// Dispatcher consumes the shift schedule events and queues a delivery for every webhook subscription of the
+// organization of the event that is subscribed to its type
+type Dispatcher struct {
+	webhooks *repository.WebhookRepository
+}
+
+func NewDispatcher(db *gorm.DB) *Dispatcher {
+	return &Dispatcher{webhooks: repository.NewWebhookRepository(db)}
+}
+
+// Run consumes the events until ctx is done, consuming again with backoff when the consumer fails
+func (d *Dispatcher) Run(ctx context.Context, consumer broker.Consumer) {
+	defer consumer.Close()
+	broker.ConsumeWithRetry(ctx, consumer, d.Handle, func(err error, delay time.Duration) {
+		logger.CLogger.Errorf("Webhook dispatcher consumer failed, consuming again in %s: %v", delay, err)
+	})
+}
+
+// Handle queues the deliveries of an event, events that cannot be decoded are skipped
+func (d *Dispatcher) Handle(ctx context.Context, message broker.Message) error {
+	var event events.Event
+	if err := json.Unmarshal(message.Value, &event); err != nil || event.ID == "" {
+		logger.CLogger.Warnf("Webhook dispatcher skips the undecodable event at %s/%d/%d", message.Topic, message.Partition, message.Offset)
+		return nil
+	}
+	if event.OrganizationID == nil {
+		return nil
+	}
+
+	subscriptions, err := d.webhooks.ListSubscribed(*event.OrganizationID, string(event.Type))
+	if err != nil {
+		return err
+	}
+	deliveries := make([]models.WebhookDelivery, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		deliveries = append(deliveries, models.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        event.ID,
+			EventType:      string(event.Type),
+			Payload:        string(message.Value),
+			Status:         models.DeliveryPending,
+		})
+	}
+	return d.webhooks.Enqueue(deliveries)
+}
@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress is returned for webhook urls resolving to private, loopback or link-local addresses
+var ErrForbiddenAddress = errors.New("webhook url must not resolve to a private, loopback or link-local address")
+
+// Guard keeps webhooks away from the internal network: their hosts must only resolve to public addresses,
+// unless they are allowed explicitly (e.g. the local webhook-echo stand-in)
+type Guard struct {
+	allowedHosts map[string]bool
+	resolver     *net.Resolver
+}
+
+func NewGuard(allowedHosts []string) *Guard {
+	g := &Guard{allowedHosts: map[string]bool{}, resolver: net.DefaultResolver}
+	for _, host := range allowedHosts {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			g.allowedHosts[host] = true
+		}
+	}
+	return g
+}
+
+// CheckUrl checks that rawUrl is an absolute http or https url whose host is allowed or only resolves to
+// public addresses
+func (g *Guard) CheckUrl(ctx context.Context, rawUrl string) error {
+	u, err := url.Parse(strings.TrimSpace(rawUrl))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return errors.New("webhook url must be an absolute http or https url")
+	}
+	host := u.Hostname()
+	if g.allowed(host) {
+		return nil
+	}
+	addresses, err := g.resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("cannot resolve webhook host %q", host)
+	}
+	for _, address := range addresses {
+		if forbidden(address.IP) {
+			return ErrForbiddenAddress
+		}
+	}
+	return nil
+}
+
+// DialContext dials like a net.Dialer with timeout, refusing to connect to forbidden addresses of hosts that
+// are not allowed. The address is checked when connecting, so hosts cannot be rebound to internal addresses
+// after CheckUrl.
+func (g *Guard) DialContext(timeout time.Duration) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		dialer := &net.Dialer{Timeout: timeout}
+		if host, _, err := net.SplitHostPort(address); err != nil || !g.allowed(host) {
+			dialer.Control = func(network, address string, _ syscall.RawConn) error {
+				host, _, err := net.SplitHostPort(address)
+				if err != nil {
+					return err
+				}
+				if ip := net.ParseIP(host); ip == nil || forbidden(ip) {
+					return ErrForbiddenAddress
+				}
+				return nil
+			}
+		}
+		return dialer.DialContext(ctx, network, address)
+	}
+}
+
+func (g *Guard) allowed(host string) bool {
+	return g.allowedHosts[strings.ToLower(strings.Trim(host, "[]"))]
+}
+
+// forbidden reports whether ip is a private, loopback, link-local or unspecified address
+func forbidden(ip net.IP) bool {
+	return ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsUnspecified()
+}
@@ -0,0 +1,103 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGuardCheckUrl(t *testing.T) {
+	guard := NewGuard([]string{" Webhook-Echo ", "127.0.0.2", ""})
+
+	tests := []struct {
+		name      string
+		url       string
+		wantErr   bool
+		forbidden bool
+	}{
+		{"public address", "https://93.184.216.34/hooks", false, false},
+		{"public ipv6 address", "https://[2606:2800:220:1::]/hooks", false, false},
+		{"allowed host", "http://webhook-echo:8080/hooks", false, false},
+		{"allowed address", "http://127.0.0.2/hooks", false, false},
+		{"loopback", "http://127.0.0.1:8080/hooks", true, true},
+		{"ipv6 loopback", "http://[::1]/hooks", true, true},
+		{"private network", "http://10.1.2.3/hooks", true, true},
+		{"private network 192.168", "https://192.168.0.1/hooks", true, true},
+		{"cloud metadata", "http://169.254.169.254/latest/meta-data", true, true},
+		{"unspecified", "http://0.0.0.0/hooks", true, true},
+		{"unsupported scheme", "ftp://93.184.216.34/hooks", true, false},
+		{"relative url", "/hooks", true, false},
+		{"not a url", "http://%zz", true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := guard.CheckUrl(context.Background(), tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckUrl(%q) error = %v, want error %v", tt.url, err, tt.wantErr)
+			}
+			if got := errors.Is(err, ErrForbiddenAddress); got != tt.forbidden {
+				t.Errorf("CheckUrl(%q) error = %v, want ErrForbiddenAddress %v", tt.url, err, tt.forbidden)
+			}
+		})
+	}
+}
+
+func TestForbidden(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"8.8.8.8", false},
+		{"2001:4860:4860::8888", false},
+		{"127.0.0.1", true},
+		{"::1", true},
+		{"10.0.0.1", true},
+		{"172.16.0.1", true},
+		{"192.168.1.1", true},
+		{"fd00::1", true},
+		{"169.254.169.254", true},
+		{"fe80::1", true},
+		{"ff02::1", true},
+		{"0.0.0.0", true},
+		{"::", true},
+	}
+	for _, tt := range tests {
+		if got := forbidden(net.ParseIP(tt.ip)); got != tt.want {
+			t.Errorf("forbidden(%s) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+}
+
+func TestGuardDialContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	address := server.Listener.Addr().String()
+
+	tests := []struct {
+		name    string
+		allowed []string
+		wantErr bool
+	}{
+		{"loopback is refused", nil, true},
+		{"allowed hosts connect", []string{"127.0.0.1"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dial := NewGuard(tt.allowed).DialContext(time.Second)
+			conn, err := dial(context.Background(), "tcp", address)
+			if conn != nil {
+				conn.Close()
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DialContext(%s) error = %v, want error %v", address, err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, ErrForbiddenAddress) {
+				t.Errorf("DialContext(%s) error = %v, want ErrForbiddenAddress", address, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+
+	"shyft/internal/models"
+	"shyft/internal/repository"
+	"shyft/pkg/logger"
+)
+
+const (
+	defaultSenderInterval = time.Second
+	defaultSenderBatch    = 20
+	defaultSenderTimeout  = 10 * time.Second
+	defaultMaxAttempts    = 10
+
+	minDeliveryBackoff = 10 * time.Second
+	maxDeliveryBackoff = time.Hour
+	maxResponseDrain   = 1 << 20 // bytes of a response read so the connection is reused
+)
+
+// SenderOptions of the sender, zero values take the defaults
+type SenderOptions struct {
+	Interval    time.Duration // between two polls of the pending deliveries
+	BatchSize   int           // deliveries attempted at once
+	Timeout     time.Duration // of a delivery request
+	MaxAttempts int           // a delivery fails after
+	Guard       *Guard        // keeps deliveries away from the internal network, nothing is allowed when nil
+}
+
+// Sender posts the pending deliveries to their subscriptions. A delivery answered with a 2xx status is
+// delivered, others are attempted again with exponential backoff until they fail after the last attempt.
+// Redirects are not followed and only the response status is kept.
+type Sender struct {
+	webhooks *repository.WebhookRepository
+	client   *http.Client
+	options  SenderOptions
+}
+
+func NewSender(db *gorm.DB, options SenderOptions) *Sender {
+	if options.Interval <= 0 {
+		options.Interval = defaultSenderInterval
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = defaultSenderBatch
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = defaultSenderTimeout
+	}
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = defaultMaxAttempts
+	}
+	if options.Guard == nil {
+		options.Guard = NewGuard(nil)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil // a proxy would connect to the addresses the guard refuses
+	transport.DialContext = options.Guard.DialContext(options.Timeout)
+	return &Sender{
+		webhooks: repository.NewWebhookRepository(db),
+		client: &http.Client{
+			Timeout:   options.Timeout,
+			Transport: transport,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		options: options,
+	}
+}
+
+// Run attempts the pending deliveries every interval until ctx is done
+func (s *Sender) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.options.Interval)
+	defer ticker.Stop()
+	for {
+		// full batches are attempted back to back
+		for {
+			attempted, err := s.send(ctx)
+			if err != nil {
+				logger.CLogger.Errorf("Cannot send webhook deliveries: %v", err)
+				break
+			}
+			if attempted < s.options.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// send attempts a batch of pending deliveries and returns the number of attempted deliveries
+func (s *Sender) send(ctx context.Context) (int, error) {
+	// deliveries are leased for the time the whole batch may take
+	lease := time.Duration(s.options.BatchSize)*s.options.Timeout + time.Minute
+	deliveries, err := s.webhooks.Claim(s.options.BatchSize, time.Now(), lease)
+	if err != nil || len(deliveries) == 0 {
+		return 0, err
+	}
+
+	ids := make([]uint, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		ids = append(ids, delivery.SubscriptionID)
+	}
+	subscriptions, err := s.webhooks.FindByIDs(ids)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		subscription, ok := subscriptions[delivery.SubscriptionID]
+		switch {
+		case !ok:
+			delivery.Status, delivery.LastError = models.DeliveryFailed, "subscription deleted"
+		case !subscription.Active:
+			delivery.Status, delivery.LastError = models.DeliveryFailed, "subscription inactive"
+		default:
+			s.attempt(ctx, subscription, delivery)
+		}
+		if err := s.webhooks.Record(delivery); err != nil {
+			return 0, err
+		}
+	}
+	return len(deliveries), nil
+}
+
+// attempt posts the delivery to the subscription and sets its outcome
+func (s *Sender) attempt(ctx context.Context, subscription models.WebhookSubscription, delivery *models.WebhookDelivery) {
+	delivery.Attempts++
+	status, err := s.post(ctx, subscription, delivery)
+	delivery.ResponseStatus, delivery.LastError = nil, ""
+	if status > 0 {
+		delivery.ResponseStatus = &status
+	}
+
+	switch {
+	case err != nil:
+		delivery.LastError = err.Error()
+	case status < 200 || status > 299:
+		delivery.LastError = fmt.Sprintf("unexpected status %d", status)
+	default:
+		now := time.Now()
+		delivery.Status, delivery.DeliveredAt = models.DeliveryDelivered, &now
+		return
+	}
+
+	if delivery.Attempts >= s.options.MaxAttempts {
+		delivery.Status = models.DeliveryFailed
+		logger.CLogger.Warnf("Webhook delivery %d to subscription %d failed after %d attempts: %s", delivery.ID, subscription.ID, delivery.Attempts, delivery.LastError)
+		return
+	}
+	delivery.Status, delivery.NextAttemptAt = models.DeliveryPending, time.Now().Add(backoff(delivery.Attempts))
+}
+
+// post sends the signed payload and returns the response status
+func (s *Sender) post(ctx context.Context, subscription models.WebhookSubscription, delivery *models.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := time.Now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "shyft-webhooks/1")
+	request.Header.Set(HeaderSignature, Sign(subscription.Secret, timestamp, body))
+	request.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(HeaderEvent, delivery.EventType)
+	request.Header.Set(HeaderEventID, delivery.EventID)
+	request.Header.Set(HeaderDelivery, strconv.FormatUint(delivery.ID, 10))
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(response.Body, maxResponseDrain)) // drained so the connection is reused
+	return response.StatusCode, nil
+}
+
+// backoff before the next attempt after the given number of failed attempts
+func backoff(attempts int) time.Duration {
+	delay := minDeliveryBackoff
+	for i := 1; i < attempts && delay < maxDeliveryBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxDeliveryBackoff {
+		delay = maxDeliveryBackoff
+	}
+	return delay
+}
@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"shyft/internal/models"
+)
+
+// Headers of a webhook delivery
+const (
+	HeaderSignature = "X-Shyft-Signature" // sha256=<hex HMAC-SHA256 of "<timestamp>.<body>" keyed by the subscription secret>
+	HeaderTimestamp = "X-Shyft-Timestamp" // unix seconds the delivery was signed at
+	HeaderEvent     = "X-Shyft-Event"     // type of the event
+	HeaderEventID   = "X-Shyft-Event-Id"  // id of the event, the same for every redelivery
+	HeaderDelivery  = "X-Shyft-Delivery"  // id of the delivery
+)
+
+// PingEvent is the type of the test event sent by the ping endpoint
+const PingEvent = "ping"
+
+// Sign returns the signature of body sent at timestamp. Receivers recompute it over the raw body with the
+// shared secret, compare it in constant time and reject old timestamps to prevent replays.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the signature of body sent at timestamp
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// pingEvent is the payload of a ping delivery
+type pingEvent struct {
+	ID             string    `json:"id"`
+	Type           string    `json:"type"`
+	OccurredAt     time.Time `json:"occurred_at"`
+	OrganizationID uint      `json:"organization_id"`
+	WebhookID      uint      `json:"webhook_id"`
+}
+
+// Ping returns a pending delivery of a test event to the subscription
+func Ping(subscription *models.WebhookSubscription) (*models.WebhookDelivery, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	event := pingEvent{
+		ID:             hex.EncodeToString(id),
+		Type:           PingEvent,
+		OccurredAt:     time.Now().UTC(),
+		OrganizationID: subscription.OrganizationID,
+		WebhookID:      subscription.ID,
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	return &models.WebhookDelivery{
+		SubscriptionID: subscription.ID,
+		EventID:        event.ID,
+		EventType:      event.Type,
+		Payload:        string(payload),
+		Status:         models.DeliveryPending,
+		NextAttemptAt:  event.OccurredAt,
+	}, nil
+}
@@ -0,0 +1,49 @@
+package workflow
+
+import (
+	"errors"
+
+	"shyft/internal/models"
+)
+
+// Action is a status change of a shift schedule
+type Action string
+
+const (
+	ActionSubmit  Action = "submit"  // ask the manager for approval
+	ActionApprove Action = "approve" // by the manager
+	ActionReject  Action = "reject"  // by the manager, with a reason
+	ActionReopen  Action = "reopen"  // by the manager, allows editing an approved schedule again
+)
+
+var (
+	ErrIllegalTransition = errors.New("shift schedule cannot be changed this way in its current status")
+	ErrReasonRequired    = errors.New("a reason is required to reject a shift schedule")
+	ErrLocked            = errors.New("approved shift schedule cannot be edited, reopen it first")
+)
+
+// Transition returns the status a shift schedule in status moves to on action
+func Transition(status int, action Action, reason string) (int, error) {
+	switch {
+	case action == ActionSubmit && (status == models.StatusPending || status == models.StatusRejected):
+		return models.StatusSubmitted, nil
+	case action == ActionApprove && status == models.StatusSubmitted:
+		return models.StatusApproved, nil
+	case action == ActionReject && status == models.StatusSubmitted:
+		if reason == "" {
+			return status, ErrReasonRequired
+		}
+		return models.StatusRejected, nil
+	case action == ActionReopen && (status == models.StatusApproved || status == models.StatusRejected):
+		return models.StatusPending, nil
+	}
+	return status, ErrIllegalTransition
+}
+
+// CheckEditable returns ErrLocked when the shift schedule cannot be edited in its current status
+func CheckEditable(schedule *models.ShiftSchedule) error {
+	if schedule.Status == models.StatusApproved {
+		return ErrLocked
+	}
+	return nil
+}
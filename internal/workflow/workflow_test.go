@@ -0,0 +1,54 @@
+package workflow
+
+import (
+	"errors"
+	"testing"
+
+	"shyft/internal/models"
+)
+
+func TestTransition(t *testing.T) {
+	tests := []struct {
+		status  int
+		action  Action
+		reason  string
+		want    int
+		wantErr error
+	}{
+		{models.StatusPending, ActionSubmit, "", models.StatusSubmitted, nil},
+		{models.StatusRejected, ActionSubmit, "", models.StatusSubmitted, nil},
+		{models.StatusSubmitted, ActionApprove, "", models.StatusApproved, nil},
+		{models.StatusSubmitted, ActionReject, "missing weekends", models.StatusRejected, nil},
+		{models.StatusSubmitted, ActionReject, "", models.StatusSubmitted, ErrReasonRequired},
+		{models.StatusApproved, ActionReopen, "", models.StatusPending, nil},
+		{models.StatusRejected, ActionReopen, "", models.StatusPending, nil},
+		{models.StatusApproved, ActionSubmit, "", models.StatusApproved, ErrIllegalTransition},
+		{models.StatusPending, ActionApprove, "", models.StatusPending, ErrIllegalTransition},
+		{models.StatusPending, ActionReject, "no", models.StatusPending, ErrIllegalTransition},
+		{models.StatusSubmitted, ActionReopen, "", models.StatusSubmitted, ErrIllegalTransition},
+		{models.StatusPending, Action("publish"), "", models.StatusPending, ErrIllegalTransition},
+	}
+	for _, tt := range tests {
+		got, err := Transition(tt.status, tt.action, tt.reason)
+		if got != tt.want || !errors.Is(err, tt.wantErr) {
+			t.Errorf("Transition(%d, %s, %q) = %d, %v, want %d, %v", tt.status, tt.action, tt.reason, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestCheckEditable(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{models.StatusPending, nil},
+		{models.StatusSubmitted, nil},
+		{models.StatusRejected, nil},
+		{models.StatusApproved, ErrLocked},
+	}
+	for _, tt := range tests {
+		if err := CheckEditable(&models.ShiftSchedule{Status: tt.status}); !errors.Is(err, tt.want) {
+			t.Errorf("CheckEditable() of status %d error = %v, want %v", tt.status, err, tt.want)
+		}
+	}
+}
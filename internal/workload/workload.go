@@ -0,0 +1,155 @@
+package workload
+
+import (
+	"sort"
+	"time"
+
+	"shyft/internal/conflict"
+	"shyft/internal/models"
+)
+
+// Night hours are worked between NightStart and NightEnd o'clock
+const (
+	NightStart = 22
+	NightEnd   = 6
+)
+
+// Options customize how workload is counted
+type Options struct {
+	// IsHoliday reports whether the day starting at the given local midnight is a holiday of the organization
+	IsHoliday func(organizationID *uint, day time.Time) bool
+}
+
+// Compute sums up, per person, the parts of the shifts falling in [from, to). Every user of the shift
+// schedules is listed, even without any shift, so the team average reflects the whole team. Weekends, holidays
+// and nights follow the time zone of every schedule, the period is reported in loc.
+func Compute(schedules []conflict.Schedule, from, to time.Time, loc *time.Location, opts Options) models.WorkloadReport {
+	report := models.WorkloadReport{From: from.In(loc), To: to.In(loc), People: []models.Workload{}}
+
+	byPerson := map[string]*models.Workload{}
+	var keys []string
+	person := func(user models.Contact) *models.Workload {
+		key := user.PersonKey()
+		if key == "" {
+			return nil
+		}
+		if w, ok := byPerson[key]; ok {
+			return w
+		}
+		byPerson[key] = &models.Workload{User: user}
+		keys = append(keys, key)
+		return byPerson[key]
+	}
+
+	for _, s := range schedules {
+		scheduleLoc := s.Schedule.Location()
+		for _, entry := range s.Schedule.Users {
+			if values, ok := entry.(map[string]interface{}); ok {
+				person(models.ContactFromJSON(values))
+			}
+		}
+		for _, entry := range s.Shifts {
+			values, ok := entry.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			user, ok := values["user"].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			w := person(models.ContactFromJSON(user))
+			if w == nil {
+				continue
+			}
+			start, _ := values["start"].(string)
+			end, _ := values["end"].(string)
+			startAt, err := models.ParseShiftTime(start, scheduleLoc)
+			if err != nil {
+				continue
+			}
+			endAt, err := models.ParseShiftTime(end, scheduleLoc)
+			if err != nil {
+				continue
+			}
+			if startAt.Before(from) {
+				startAt = from
+			}
+			if endAt.After(to) {
+				endAt = to
+			}
+			if !endAt.After(startAt) {
+				continue
+			}
+			w.Shifts++
+			add(w, startAt.In(scheduleLoc), endAt.In(scheduleLoc), s.Schedule.OrganizationID, opts)
+		}
+	}
+
+	sort.Strings(keys)
+	var total float64
+	for _, key := range keys {
+		total += byPerson[key].Hours
+	}
+	if len(keys) > 0 {
+		report.AverageHours = total / float64(len(keys))
+	}
+	for _, key := range keys {
+		w := byPerson[key]
+		w.Deviation = w.Hours - report.AverageHours
+		report.People = append(report.People, *w)
+	}
+	return report
+}
+
+// Hours returns the hours worked per user id, people without an id are left out
+func Hours(report models.WorkloadReport) map[uint]float64 {
+	hours := map[uint]float64{}
+	for _, w := range report.People {
+		if w.User.ID != 0 {
+			hours[w.User.ID] += w.Hours
+		}
+	}
+	return hours
+}
+
+// add counts [start, end) day by day, so weekend, holiday and night hours follow the local calendar
+func add(w *models.Workload, start, end time.Time, organizationID *uint, opts Options) {
+	for dayStart := midnight(start); dayStart.Before(end); dayStart = dayStart.AddDate(0, 0, 1) {
+		dayEnd := dayStart.AddDate(0, 0, 1)
+		hours := overlap(start, end, dayStart, dayEnd)
+		if hours == 0 {
+			continue
+		}
+		w.Hours += hours
+		if weekday := dayStart.Weekday(); weekday == time.Saturday || weekday == time.Sunday {
+			w.WeekendHours += hours
+		}
+		if opts.IsHoliday != nil && opts.IsHoliday(organizationID, dayStart) {
+			w.HolidayHours += hours
+		}
+		w.NightHours += overlap(start, end, dayStart, atHour(dayStart, NightEnd)) +
+			overlap(start, end, atHour(dayStart, NightStart), dayEnd)
+	}
+}
+
+// overlap returns the hours [start, end) and [from, to) have in common
+func overlap(start, end, from, to time.Time) float64 {
+	if start.Before(from) {
+		start = from
+	}
+	if end.After(to) {
+		end = to
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return end.Sub(start).Hours()
+}
+
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func atHour(day time.Time, hour int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, 0, 0, 0, day.Location())
+}
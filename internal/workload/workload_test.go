@@ -0,0 +1,128 @@
+package workload
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"shyft/internal/conflict"
+	"shyft/internal/models"
+	"shyft/internal/testutil"
+)
+
+var (
+	alice = models.Contact{ID: 1, Name: "Alice"}
+	bob   = models.Contact{ID: 2, Name: "Bob"}
+	carol = models.Contact{Name: "Carol", Mail: "carol@example.com"}
+)
+
+func schedule(timeZone string, users []models.Contact, shifts ...interface{}) conflict.Schedule {
+	organizationID := uint(1)
+	return conflict.Schedule{
+		Schedule: &models.ShiftSchedule{TimeZone: timeZone, Users: testutil.Users(users...), OrganizationID: &organizationID},
+		Shifts:   models.JSONB(shifts),
+	}
+}
+
+// describe writes a workload as "<name> <shifts> <hours>/<weekend>/<holiday>/<night> <deviation>"
+func describe(report models.WorkloadReport) string {
+	var lines []string
+	for _, w := range report.People {
+		lines = append(lines, fmt.Sprintf("%s %d %g/%g/%g/%g %.2f", w.User.Name, w.Shifts, w.Hours, w.WeekendHours, w.HolidayHours, w.NightHours, w.Deviation))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestCompute(t *testing.T) {
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC) // a monday
+	to := from.AddDate(0, 0, 7)
+	holidays := Options{IsHoliday: func(organizationID *uint, day time.Time) bool {
+		return organizationID != nil && *organizationID == 1 && day.Day() == 3
+	}}
+
+	tests := []struct {
+		name      string
+		schedules []conflict.Schedule
+		opts      Options
+		want      []string
+		average   float64
+	}{
+		{
+			name: "weekend, night and holiday hours",
+			schedules: []conflict.Schedule{schedule("UTC", []models.Contact{alice, bob},
+				testutil.Entry("2026-03-06 18:00:00", "2026-03-07 12:00:00", alice),
+				testutil.Entry("2026-03-03 09:00:00", "2026-03-03 17:00:00", bob),
+			)},
+			opts:    holidays,
+			want:    []string{"Alice 1 18/12/0/8 5.00", "Bob 1 8/0/8/0 -5.00"},
+			average: 13,
+		},
+		{
+			name:      "users without shifts count towards the average",
+			schedules: []conflict.Schedule{schedule("UTC", []models.Contact{alice, bob, carol}, testutil.Entry("2026-03-02 09:00:00", "2026-03-02 18:00:00", alice))},
+			want:      []string{"Alice 1 9/0/0/0 6.00", "Bob 0 0/0/0/0 -3.00", "Carol 0 0/0/0/0 -3.00"},
+			average:   3,
+		},
+		{
+			name: "shifts are clipped to the period",
+			schedules: []conflict.Schedule{schedule("UTC", []models.Contact{alice},
+				testutil.Entry("2026-03-01 12:00:00", "2026-03-02 12:00:00", alice),
+				testutil.Entry("2026-03-08 12:00:00", "2026-03-10 12:00:00", alice),
+				testutil.Entry("2026-03-20 12:00:00", "2026-03-21 12:00:00", alice),
+			)},
+			want:    []string{"Alice 2 24/12/0/8 0.00"},
+			average: 24,
+		},
+		{
+			name: "the same person across schedules, in the time zone of every schedule",
+			schedules: []conflict.Schedule{
+				schedule("UTC", []models.Contact{alice}, testutil.Entry("2026-03-02 20:00:00", "2026-03-03 00:00:00", alice)),
+				schedule("Europe/Istanbul", []models.Contact{{ID: 1, Name: "Alice A."}}, testutil.Entry("2026-03-04 20:00:00", "2026-03-05 00:00:00", models.Contact{ID: 1})),
+			},
+			want:    []string{"Alice 2 8/0/0/4 0.00"},
+			average: 8,
+		},
+		{
+			name: "people are matched by mail regardless of case, entries without a person are skipped",
+			schedules: []conflict.Schedule{schedule("UTC", []models.Contact{carol},
+				testutil.Entry("2026-03-02 09:00:00", "2026-03-02 10:00:00", models.Contact{Name: "C", Mail: "Carol@Example.com"}),
+				testutil.Entry("2026-03-02 09:00:00", "2026-03-02 10:00:00", models.Contact{Name: "Nobody"}),
+				testutil.Entry("2026-03-02 09:00:00", "later", carol),
+				map[string]interface{}{"start": "2026-03-02 09:00:00", "end": "2026-03-02 10:00:00"},
+			)},
+			want:    []string{"Carol 1 1/0/0/0 0.00"},
+			average: 1,
+		},
+		{
+			name:      "no people",
+			schedules: []conflict.Schedule{schedule("UTC", nil)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := Compute(tt.schedules, from, to, time.UTC, tt.opts)
+			if got, want := describe(report), strings.Join(tt.want, "\n"); got != want {
+				t.Errorf("Compute() =\n%s\nwant\n%s", got, want)
+			}
+			if report.AverageHours != tt.average {
+				t.Errorf("Compute() average = %g, want %g", report.AverageHours, tt.average)
+			}
+			if report.People == nil {
+				t.Error("Compute() people = nil, want an empty list")
+			}
+		})
+	}
+}
+
+func TestHours(t *testing.T) {
+	report := models.WorkloadReport{People: []models.Workload{
+		{User: alice, Hours: 10},
+		{User: bob, Hours: 4.5},
+		{User: carol, Hours: 7},
+	}}
+	hours := Hours(report)
+	if len(hours) != 2 || hours[alice.ID] != 10 || hours[bob.ID] != 4.5 {
+		t.Errorf("Hours() = %v, want the hours of alice and bob only", hours)
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"os"
 	"time"
 
@@ -18,7 +19,13 @@ import (
 	"github.com/uber/jaeger-lib/metrics"
 
 	"shyft/config"
+	"shyft/internal/events"
 	"shyft/internal/handlers"
+	"shyft/internal/hr"
+	"shyft/internal/notification"
+	"shyft/internal/policy"
+	"shyft/internal/webhook"
+	"shyft/pkg/broker"
 	"shyft/pkg/db/postgres"
 	"shyft/pkg/db/redis"
 	"shyft/pkg/logger"
@@ -53,6 +60,8 @@ func main() {
 	inAppCache := redis.NewInAppCacheStore(time.Minute)
 	cacheConn, cacheContext := redis.NewRedisCacheConnection(config.C.Cache.Url)
 	dbConn := postgres.NewPostgresDB(config.C.DB.Url)
+	eventBroker := newBroker(config.C.Broker)
+	defer eventBroker.Close()
 
 	jaegerCfgInstance := jaegercfg.Configuration{
 		ServiceName: config.C.Jaeger.ServiceName,
@@ -81,12 +90,58 @@ func main() {
 		cacheConn,
 		cacheContext,
 		dbConn,
+		policy.New(config.C.Auth.Roles),
 	)
 
+	// consume the employee events of the HR platform
+	if config.C.Broker.HrTopic != "" {
+		deadLetterTopic := config.C.Broker.DeadLetterTopic
+		if deadLetterTopic == "" {
+			deadLetterTopic = config.C.Broker.HrTopic + ".dlq"
+		}
+		consumer := newConsumer(config.C.Broker, config.C.Broker.HrTopic, config.C.Broker.ConsumerGroup)
+		go hr.NewWorker(dbConn, eventBroker, deadLetterTopic).Run(context.Background(), consumer)
+	}
+
+	// post the shift schedule events to the webhook subscriptions
+	webhookConsumer := newConsumer(config.C.Broker, config.C.Broker.Topic, config.C.Broker.ConsumerGroup+"-webhooks")
+	go webhook.NewDispatcher(dbConn).Run(context.Background(), webhookConsumer)
+	go webhook.NewSender(dbConn, webhook.SenderOptions{
+		Interval:    time.Duration(config.C.Webhook.Interval) * time.Millisecond,
+		BatchSize:   config.C.Webhook.BatchSize,
+		Timeout:     time.Duration(config.C.Webhook.Timeout) * time.Second,
+		MaxAttempts: config.C.Webhook.MaxAttempts,
+		Guard:       webhook.NewGuard(config.C.Webhook.AllowedHosts),
+	}).Run(context.Background())
+
+	// remind people of their upcoming shifts and handovers
+	if channels := newChannels(config.C.Notification); len(channels) > 0 {
+		leadTimes := make([]time.Duration, 0, len(config.C.Notification.LeadTimes))
+		for _, minutes := range config.C.Notification.LeadTimes {
+			leadTimes = append(leadTimes, time.Duration(minutes)*time.Minute)
+		}
+		go notification.NewScheduler(dbConn, channels, notification.Options{
+			Interval:     time.Duration(config.C.Notification.Interval) * time.Second,
+			LeadTimes:    leadTimes,
+			HandoverLead: time.Duration(config.C.Notification.HandoverLead) * time.Minute,
+			MaxAttempts:  config.C.Notification.MaxAttempts,
+		}).Run(context.Background())
+	} else {
+		logger.CLogger.Warn("No notification channel is configured, shift reminders are not sent")
+	}
+
 	// check env and set gin mode
 	setApplicationMode(mode, router)
 	shiftsvc.InitRouter(router)
 
+	// relay the outbox events to the broker, reported in the metrics created with the router
+	go events.NewRelay(dbConn, eventBroker, events.RelayOptions{
+		Topic:     config.C.Broker.Topic,
+		Interval:  time.Duration(config.C.Broker.RelayInterval) * time.Millisecond,
+		BatchSize: config.C.Broker.RelayBatchSize,
+		Retention: time.Duration(config.C.Broker.OutboxRetention) * time.Hour,
+	}).Run(context.Background(), shiftsvc.Metrics())
+
 	opentracing.SetGlobalTracer(tracer)
 	defer closer.Close()
 	logger.CLogger.Info("Tracing enabled: Jaeger host=", config.C.Jaeger.Host, " service=", config.C.Jaeger.ServiceName)
@@ -94,7 +149,7 @@ func main() {
 	logger.CLogger.Infof("Configuration loaded: service=%s mode=%s port=%s version=%s", config.C.App.Name, config.C.App.Mode, config.C.App.Port, config.C.App.Version)
 	logger.CLogger.Infof("Database connected: %s", config.C.DB.Url)
 	logger.CLogger.Infof("Cache connected: %s", config.C.Cache.Url)
-	logger.CLogger.Infof("Broker connected: %s", config.C.Broker.Url)
+	logger.CLogger.Infof("Broker connected: %s topic=%s", config.C.Broker.Url, config.C.Broker.Topic)
 	logger.CLogger.Infof("Logger initialized: level=%s encoding=%s development=%v", config.C.Logger.Level, config.C.Logger.Encoding, config.C.Logger.Development)
 
 	// run application
@@ -130,6 +185,45 @@ func configureApplication() bool {
 	}
 }
 
+// Create the broker shift schedule events are published to, a broker url is required
+func newBroker(cfg config.Broker) broker.Broker {
+	if cfg.Url == "" {
+		logger.CLogger.Fatal("Broker url is not set, shift schedule events cannot be published")
+	}
+	kafka, err := broker.NewKafkaBroker(cfg.Url)
+	if err != nil {
+		logger.CLogger.Fatalf("Cannot create broker: %v", err)
+	}
+	return kafka
+}
+
+// Create the consumer of a topic
+func newConsumer(cfg config.Broker, topic, group string) broker.Consumer {
+	consumer, err := broker.NewKafkaConsumer(cfg.Url, group, topic)
+	if err != nil {
+		logger.CLogger.Fatalf("Cannot create consumer of %s: %v", topic, err)
+	}
+	return consumer
+}
+
+// Create the channels notifications are sent through, in order: email, then webhook
+func newChannels(cfg config.Notification) []notification.Channel {
+	var channels []notification.Channel
+	if cfg.Smtp.Host != "" {
+		channels = append(channels, notification.NewSMTPChannel(notification.SMTPOptions{
+			Host:     cfg.Smtp.Host,
+			Port:     cfg.Smtp.Port,
+			Username: cfg.Smtp.Username,
+			Password: cfg.Smtp.Password,
+			From:     cfg.Smtp.From,
+		}))
+	}
+	if cfg.WebhookUrl != "" {
+		channels = append(channels, notification.NewWebhookChannel(cfg.WebhookUrl, cfg.WebhookSecret, 10*time.Second))
+	}
+	return channels
+}
+
 // Set Application Mode
 func setApplicationMode(md string, router *gin.Engine) {
 	gin.SetMode(gin.ReleaseMode)
@@ -0,0 +1,15 @@
+-- File Name: 20261018_120000_normalize_relations.down.sql
+-- Date: 2026-10-18 12:00:00
+-- Author: Yunus Emre Alpu
+
+-- The JSONB columns of shift_schedule still hold every entry, nothing is lost on the way down.
+
+DROP TABLE IF EXISTS shifts;
+DROP TABLE IF EXISTS shift_schedule_people;
+
+ALTER TABLE shift_schedule DROP COLUMN IF EXISTS manager_id;
+ALTER TABLE shift_schedule DROP COLUMN IF EXISTS organization_id;
+
+DROP TABLE IF EXISTS managers;
+DROP TABLE IF EXISTS people;
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,214 @@
+-- File Name: 20261018_120000_normalize_relations.up.sql
+-- Date: 2026-10-18 12:00:00
+-- Author: Yunus Emre Alpu
+
+-- Organizations, managers, people and shifts become real tables. The JSONB columns of
+-- shift_schedule stay as a read projection of these tables (API backward compatibility).
+
+CREATE TABLE IF NOT EXISTS organizations (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    mail VARCHAR(255) DEFAULT NULL,
+    phone VARCHAR(64) DEFAULT NULL,
+    description VARCHAR(1024) DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE TABLE IF NOT EXISTS people (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    mail VARCHAR(255) DEFAULT NULL,
+    phone VARCHAR(64) DEFAULT NULL,
+    description VARCHAR(1024) DEFAULT NULL,
+    organization_id INTEGER DEFAULT NULL REFERENCES organizations(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE TABLE IF NOT EXISTS managers (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    mail VARCHAR(255) DEFAULT NULL,
+    phone VARCHAR(64) DEFAULT NULL,
+    description VARCHAR(1024) DEFAULT NULL,
+    organization_id INTEGER DEFAULT NULL REFERENCES organizations(id) ON DELETE SET NULL,
+    person_id INTEGER DEFAULT NULL REFERENCES people(id) ON DELETE SET NULL, -- the user the manager is
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_organizations_deleted_at ON organizations (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_managers_deleted_at ON managers (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_people_deleted_at ON people (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_people_mail ON people (mail);
+CREATE INDEX IF NOT EXISTS idx_people_organization_id ON people (organization_id);
+CREATE INDEX IF NOT EXISTS idx_managers_person_id ON managers (person_id);
+
+ALTER TABLE shift_schedule ADD COLUMN IF NOT EXISTS organization_id INTEGER DEFAULT NULL REFERENCES organizations(id) ON DELETE SET NULL;
+ALTER TABLE shift_schedule ADD COLUMN IF NOT EXISTS manager_id INTEGER DEFAULT NULL REFERENCES managers(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_shift_schedule_organization_id ON shift_schedule (organization_id);
+CREATE INDEX IF NOT EXISTS idx_shift_schedule_manager_id ON shift_schedule (manager_id);
+
+CREATE TABLE IF NOT EXISTS shift_schedule_people (
+    shift_schedule_id INTEGER NOT NULL REFERENCES shift_schedule(id) ON DELETE CASCADE,
+    person_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (shift_schedule_id, person_id)
+);
+
+CREATE TABLE IF NOT EXISTS shifts (
+    id SERIAL PRIMARY KEY,
+    shift_schedule_id INTEGER NOT NULL REFERENCES shift_schedule(id) ON DELETE CASCADE,
+    shift_no INTEGER NOT NULL, -- id of the shift inside shift_schedule.shifts
+    person_id INTEGER DEFAULT NULL REFERENCES people(id) ON DELETE SET NULL,
+    start_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (shift_schedule_id, shift_no)
+);
+
+CREATE INDEX IF NOT EXISTS idx_shifts_person_id ON shifts (person_id);
+CREATE INDEX IF NOT EXISTS idx_shifts_start_at_end_at ON shifts (start_at, end_at);
+
+-- Back-fill from the JSONB columns. Entries are matched by their numeric "id"; the most
+-- recently updated schedule wins when the same id carries different details. Entries
+-- without an id are created the next time their schedule is saved through the API.
+-- Shift times written without a zone are read in the service default (Europe/Istanbul),
+-- shifts whose times cannot be read are skipped.
+CREATE OR REPLACE FUNCTION pg_temp.backfill_shift_time(raw TEXT) RETURNS TIMESTAMP WITH TIME ZONE AS $$
+BEGIN
+    IF raw IS NULL OR raw !~ '^[0-9]{4}-[0-9]{2}-[0-9]{2}[T ][0-9]{2}:[0-9]{2}:[0-9]{2}' THEN
+        RETURN NULL;
+    END IF;
+    IF raw ~ '(Z|[+-][0-9]{2}:[0-9]{2})$' THEN
+        RETURN raw::timestamptz;
+    END IF;
+    RETURN raw::timestamp AT TIME ZONE 'Europe/Istanbul';
+EXCEPTION WHEN data_exception THEN
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql STABLE;
+
+INSERT INTO organizations (id, name, mail, phone, description)
+SELECT DISTINCT ON ((o->>'id')::int)
+    (o->>'id')::int, COALESCE(o->>'name', ''), NULLIF(o->>'mail', ''), NULLIF(o->>'phone', ''), NULLIF(o->>'description', '')
+FROM shift_schedule AS s, jsonb_array_elements(s.organization) AS o
+WHERE o->>'id' ~ '^[0-9]+$'
+ORDER BY (o->>'id')::int, s.updated_at DESC NULLS LAST
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO managers (id, name, mail, phone, description, organization_id)
+SELECT DISTINCT ON ((m->>'id')::int)
+    (m->>'id')::int, COALESCE(m->>'name', ''), NULLIF(m->>'mail', ''), NULLIF(m->>'phone', ''), NULLIF(m->>'description', ''),
+    (SELECT o.id FROM organizations AS o WHERE o.id::text = s.organization->0->>'id')
+FROM shift_schedule AS s, jsonb_array_elements(s.manager) AS m
+WHERE m->>'id' ~ '^[0-9]+$'
+ORDER BY (m->>'id')::int, s.updated_at DESC NULLS LAST
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO people (id, name, mail, phone, description)
+SELECT DISTINCT ON (p.id) p.id, p.name, p.mail, p.phone, p.description
+FROM (
+    SELECT (u->>'id')::int AS id, COALESCE(u->>'name', '') AS name, NULLIF(u->>'mail', '') AS mail,
+        NULLIF(u->>'phone', '') AS phone, NULLIF(u->>'description', '') AS description, s.updated_at, 0 AS source
+    FROM shift_schedule AS s, jsonb_array_elements(s.users) AS u
+    WHERE jsonb_typeof(s.users) = 'array' AND u->>'id' ~ '^[0-9]+$'
+    UNION ALL
+    SELECT (sh->'user'->>'id')::int, COALESCE(sh->'user'->>'name', ''), NULLIF(sh->'user'->>'mail', ''),
+        NULLIF(sh->'user'->>'phone', ''), NULLIF(sh->'user'->>'description', ''), s.updated_at, 1
+    FROM shift_schedule AS s, jsonb_array_elements(s.shifts) AS sh
+    WHERE jsonb_typeof(s.shifts) = 'array' AND sh->'user'->>'id' ~ '^[0-9]+$'
+) AS p
+ORDER BY p.id, p.source, p.updated_at DESC NULLS LAST
+ON CONFLICT (id) DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('organizations', 'id'), COALESCE((SELECT MAX(id) FROM organizations), 0) + 1, false);
+SELECT setval(pg_get_serial_sequence('managers', 'id'), COALESCE((SELECT MAX(id) FROM managers), 0) + 1, false);
+SELECT setval(pg_get_serial_sequence('people', 'id'), COALESCE((SELECT MAX(id) FROM people), 0) + 1, false);
+
+UPDATE shift_schedule AS s SET organization_id = o.id
+FROM organizations AS o
+WHERE o.id::text = s.organization->0->>'id';
+
+UPDATE shift_schedule AS s SET manager_id = m.id
+FROM managers AS m
+WHERE m.id::text = s.manager->0->>'id';
+
+INSERT INTO shift_schedule_people (shift_schedule_id, person_id, position)
+SELECT s.id, p.id, MIN(u.position - 1)
+FROM shift_schedule AS s
+CROSS JOIN LATERAL jsonb_array_elements(s.users) WITH ORDINALITY AS u(value, position)
+JOIN people AS p ON p.id::text = u.value->>'id'
+WHERE jsonb_typeof(s.users) = 'array'
+GROUP BY s.id, p.id
+ON CONFLICT DO NOTHING;
+
+INSERT INTO shifts (shift_schedule_id, shift_no, person_id, start_at, end_at)
+SELECT s.id,
+    CASE WHEN sh.value->>'id' ~ '^[0-9]+$' THEN (sh.value->>'id')::int ELSE (sh.position - 1)::int END,
+    p.id,
+    pg_temp.backfill_shift_time(sh.value->>'start'),
+    pg_temp.backfill_shift_time(sh.value->>'end')
+FROM shift_schedule AS s
+CROSS JOIN LATERAL jsonb_array_elements(s.shifts) WITH ORDINALITY AS sh(value, position)
+LEFT JOIN people AS p ON p.id::text = sh.value->'user'->>'id'
+WHERE jsonb_typeof(s.shifts) = 'array'
+    AND jsonb_typeof(sh.value) = 'object'
+    AND pg_temp.backfill_shift_time(sh.value->>'start') IS NOT NULL
+    AND pg_temp.backfill_shift_time(sh.value->>'end') IS NOT NULL
+ON CONFLICT (shift_schedule_id, shift_no) DO NOTHING;
+
+-- Managers and people are only resolved within the organization of a schedule. People are assigned the
+-- organization of the schedules they appear in most often, managers without one that of the schedules they
+-- manage most often.
+UPDATE people AS p SET organization_id = m.organization_id
+FROM (
+    SELECT DISTINCT ON (person_id) person_id, organization_id
+    FROM (
+        SELECT sp.person_id, s.organization_id
+        FROM shift_schedule_people AS sp
+        JOIN shift_schedule AS s ON s.id = sp.shift_schedule_id
+        WHERE s.organization_id IS NOT NULL
+        UNION ALL
+        SELECT sh.person_id, s.organization_id
+        FROM shifts AS sh
+        JOIN shift_schedule AS s ON s.id = sh.shift_schedule_id
+        WHERE s.organization_id IS NOT NULL AND sh.person_id IS NOT NULL
+    ) AS memberships
+    GROUP BY person_id, organization_id
+    ORDER BY person_id, COUNT(*) DESC, organization_id
+) AS m
+WHERE p.id = m.person_id;
+
+UPDATE managers AS mg SET organization_id = m.organization_id
+FROM (
+    SELECT DISTINCT ON (manager_id) manager_id, organization_id
+    FROM shift_schedule
+    WHERE manager_id IS NOT NULL AND organization_id IS NOT NULL
+    GROUP BY manager_id, organization_id
+    ORDER BY manager_id, COUNT(*) DESC, organization_id
+) AS m
+WHERE mg.id = m.manager_id AND mg.organization_id IS NULL;
+
+-- Manager ids and user ids are different sequences, a manager is linked to the user it is by mail within its
+-- organization. Managers without such a user are linked through the managers API.
+UPDATE managers AS mg SET person_id = (
+    SELECT p.id FROM people AS p
+    WHERE p.organization_id = mg.organization_id AND lower(p.mail) = lower(mg.mail)
+    ORDER BY p.id
+    LIMIT 1
+)
+WHERE mg.mail IS NOT NULL AND mg.organization_id IS NOT NULL;
+
+-- Add the linked user to the manager projections
+UPDATE shift_schedule AS s SET manager = (
+    SELECT jsonb_agg(CASE WHEN m.id IS NULL THEN e ELSE e || jsonb_build_object('person_id', m.person_id) END ORDER BY position)
+    FROM jsonb_array_elements(s.manager) WITH ORDINALITY AS t(e, position)
+    LEFT JOIN managers AS m ON m.id::text = e->>'id'
+)
+WHERE jsonb_typeof(s.manager) = 'array' AND jsonb_array_length(s.manager) > 0;
@@ -0,0 +1,5 @@
+-- File Name: 20261018_130000_add_recurrence.down.sql
+-- Date: 2026-10-18 13:00:00
+-- Author: Yunus Emre Alpu
+
+ALTER TABLE shift_schedule DROP COLUMN IF EXISTS recurrence;
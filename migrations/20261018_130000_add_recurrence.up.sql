@@ -0,0 +1,6 @@
+-- File Name: 20261018_130000_add_recurrence.up.sql
+-- Date: 2026-10-18 13:00:00
+-- Author: Yunus Emre Alpu
+
+-- Recurring shift patterns (RRULE), expanded on read
+ALTER TABLE shift_schedule ADD COLUMN IF NOT EXISTS recurrence JSONB DEFAULT NULL;
@@ -0,0 +1,5 @@
+-- File Name: 20261018_140000_add_on_call_index.down.sql
+-- Date: 2026-10-18 14:00:00
+-- Author: Yunus Emre Alpu
+
+DROP INDEX IF EXISTS idx_shift_schedule_active_range;
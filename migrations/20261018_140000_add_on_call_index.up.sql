@@ -0,0 +1,8 @@
+-- File Name: 20261018_140000_add_on_call_index.up.sql
+-- Date: 2026-10-18 14:00:00
+-- Author: Yunus Emre Alpu
+
+-- The on-call endpoint looks up the schedules of an organization covering a given time
+CREATE INDEX IF NOT EXISTS idx_shift_schedule_active_range
+    ON shift_schedule (organization_id, start_date, end_date)
+    WHERE deleted_at IS NULL;
@@ -0,0 +1,5 @@
+-- File Name: 20261018_150000_create_shift_overrides.down.sql
+-- Date: 2026-10-18 15:00:00
+-- Author: Yunus Emre Alpu
+
+DROP TABLE IF EXISTS shift_overrides;
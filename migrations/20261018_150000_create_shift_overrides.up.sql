@@ -0,0 +1,22 @@
+-- File Name: 20261018_150000_create_shift_overrides.up.sql
+-- Date: 2026-10-18 15:00:00
+-- Author: Yunus Emre Alpu
+
+-- Temporary coverage layered on top of the shifts of a schedule
+
+CREATE TABLE IF NOT EXISTS shift_overrides (
+    id SERIAL PRIMARY KEY,
+    shift_schedule_id INTEGER NOT NULL REFERENCES shift_schedule(id) ON DELETE CASCADE,
+    person_id INTEGER NOT NULL REFERENCES people(id),
+    start_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    reason VARCHAR(1024) DEFAULT NULL,
+    created_by INTEGER DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    CHECK (end_at > start_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_shift_overrides_deleted_at ON shift_overrides (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_shift_overrides_schedule_range ON shift_overrides (shift_schedule_id, start_at, end_at);
@@ -0,0 +1,8 @@
+-- File Name: 20261018_160000_create_shift_swaps.down.sql
+-- Date: 2026-10-18 16:00:00
+-- Author: Yunus Emre Alpu
+
+DROP TABLE IF EXISTS shift_swap_events;
+DROP TABLE IF EXISTS shift_swaps;
+
+ALTER TABLE shift_schedule DROP COLUMN IF EXISTS swap_requires_approval;
@@ -0,0 +1,37 @@
+-- File Name: 20261018_160000_create_shift_swaps.up.sql
+-- Date: 2026-10-18 16:00:00
+-- Author: Yunus Emre Alpu
+
+-- Swap requests between team members, with the history of their status changes
+
+ALTER TABLE shift_schedule ADD COLUMN IF NOT EXISTS swap_requires_approval BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS shift_swaps (
+    id SERIAL PRIMARY KEY,
+    shift_schedule_id INTEGER NOT NULL REFERENCES shift_schedule(id) ON DELETE CASCADE,
+    requester_id INTEGER NOT NULL REFERENCES people(id),
+    requester_shift_id INTEGER NOT NULL,
+    responder_id INTEGER NOT NULL REFERENCES people(id),
+    responder_shift_id INTEGER NOT NULL,
+    status VARCHAR(32) NOT NULL DEFAULT 'pending',
+    requires_approval BOOLEAN NOT NULL DEFAULT FALSE,
+    comment VARCHAR(1024) DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_shift_swaps_schedule ON shift_swaps (shift_schedule_id, status);
+CREATE INDEX IF NOT EXISTS idx_shift_swaps_requester ON shift_swaps (requester_id);
+CREATE INDEX IF NOT EXISTS idx_shift_swaps_responder ON shift_swaps (responder_id);
+
+CREATE TABLE IF NOT EXISTS shift_swap_events (
+    id SERIAL PRIMARY KEY,
+    shift_swap_id INTEGER NOT NULL REFERENCES shift_swaps(id) ON DELETE CASCADE,
+    from_status VARCHAR(32) DEFAULT NULL,
+    to_status VARCHAR(32) NOT NULL,
+    actor_id INTEGER DEFAULT NULL,
+    comment VARCHAR(1024) DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_shift_swap_events_swap ON shift_swap_events (shift_swap_id);
@@ -0,0 +1,5 @@
+-- File Name: 20261018_170000_create_shift_schedule_transitions.down.sql
+-- Date: 2026-10-18 17:00:00
+-- Author: Yunus Emre Alpu
+
+DROP TABLE IF EXISTS shift_schedule_transitions;
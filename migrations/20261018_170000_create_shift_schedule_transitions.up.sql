@@ -0,0 +1,19 @@
+-- File Name: 20261018_170000_create_shift_schedule_transitions.up.sql
+-- Date: 2026-10-18 17:00:00
+-- Author: Yunus Emre Alpu
+
+-- Status changes (submit, approve, reject, reopen) of shift schedules.
+-- Status: 0: pending, 1: approved, 2: rejected, 3: submitted
+
+CREATE TABLE IF NOT EXISTS shift_schedule_transitions (
+    id SERIAL PRIMARY KEY,
+    shift_schedule_id INTEGER NOT NULL REFERENCES shift_schedule(id) ON DELETE CASCADE,
+    action VARCHAR(32) NOT NULL,
+    from_status INTEGER NOT NULL,
+    to_status INTEGER NOT NULL,
+    actor_id INTEGER DEFAULT NULL,
+    reason VARCHAR(1024) DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_shift_schedule_transitions_schedule ON shift_schedule_transitions (shift_schedule_id);
@@ -0,0 +1,5 @@
+-- File Name: 20261018_180000_create_leaves.down.sql
+-- Date: 2026-10-18 18:00:00
+-- Author: Yunus Emre Alpu
+
+DROP TABLE IF EXISTS leaves;
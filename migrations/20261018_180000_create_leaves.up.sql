@@ -0,0 +1,26 @@
+-- File Name: 20261018_180000_create_leaves.up.sql
+-- Date: 2026-10-18 18:00:00
+-- Author: Yunus Emre Alpu
+
+-- Vacation, sick and training leaves, people on an approved leave are unavailable for shifts
+
+CREATE TABLE IF NOT EXISTS leaves (
+    id SERIAL PRIMARY KEY,
+    person_id INTEGER NOT NULL REFERENCES people(id),
+    type VARCHAR(32) NOT NULL,
+    start_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(32) NOT NULL DEFAULT 'pending',
+    reason VARCHAR(1024) DEFAULT NULL,
+    created_by INTEGER DEFAULT NULL,
+    decided_by INTEGER DEFAULT NULL,
+    decided_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    decision_note VARCHAR(1024) DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    CHECK (end_at > start_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_leaves_deleted_at ON leaves (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_leaves_person_range ON leaves (person_id, start_at, end_at);
@@ -0,0 +1,5 @@
+-- File Name: 20261018_190000_create_holidays.down.sql
+-- Date: 2026-10-18 19:00:00
+-- Author: Yunus Emre Alpu
+
+DROP TABLE IF EXISTS holidays;
@@ -0,0 +1,18 @@
+-- File Name: 20261018_190000_create_holidays.up.sql
+-- Date: 2026-10-18 19:00:00
+-- Author: Yunus Emre Alpu
+
+-- Public holiday calendar of every organization, one row per holiday day
+
+CREATE TABLE IF NOT EXISTS holidays (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_holidays_deleted_at ON holidays (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_holidays_organization_date ON holidays (organization_id, date) WHERE deleted_at IS NULL;
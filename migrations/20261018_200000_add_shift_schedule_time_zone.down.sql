@@ -0,0 +1,5 @@
+-- File Name: 20261018_200000_add_shift_schedule_time_zone.down.sql
+-- Date: 2026-10-18 20:00:00
+-- Author: Yunus Emre Alpu
+
+ALTER TABLE shift_schedule DROP COLUMN IF EXISTS time_zone;
@@ -0,0 +1,7 @@
+-- File Name: 20261018_200000_add_shift_schedule_time_zone.up.sql
+-- Date: 2026-10-18 20:00:00
+-- Author: Yunus Emre Alpu
+
+-- IANA time zone the shifts, weeks and handovers of a schedule are laid out in
+
+ALTER TABLE shift_schedule ADD COLUMN IF NOT EXISTS time_zone VARCHAR(64) NOT NULL DEFAULT 'Europe/Istanbul';
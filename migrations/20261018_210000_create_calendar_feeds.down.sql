@@ -0,0 +1,6 @@
+-- File Name: 20261018_210000_create_calendar_feeds.down.sql
+-- Date: 2026-10-18 21:00:00
+-- Author: Yunus Emre Alpu
+
+DROP TABLE IF EXISTS calendar_events;
+DROP TABLE IF EXISTS calendar_tokens;
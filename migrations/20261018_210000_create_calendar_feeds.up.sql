@@ -0,0 +1,27 @@
+-- File Name: 20261018_210000_create_calendar_feeds.up.sql
+-- Date: 2026-10-18 21:00:00
+-- Author: Yunus Emre Alpu
+
+-- Secret tokens authenticating the iCalendar feeds of a person, calendar clients cannot send bearer tokens.
+-- Only the SHA-256 hash of a token is stored.
+
+CREATE TABLE IF NOT EXISTS calendar_tokens (
+    id SERIAL PRIMARY KEY,
+    person_id INTEGER NOT NULL UNIQUE REFERENCES people(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    role VARCHAR(64) NOT NULL,
+    mail VARCHAR(255) DEFAULT NULL,
+    organization_id INTEGER NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- SEQUENCE of every published calendar event, bumped whenever the event changes
+
+CREATE TABLE IF NOT EXISTS calendar_events (
+    uid VARCHAR(255) PRIMARY KEY,
+    fingerprint VARCHAR(64) NOT NULL,
+    sequence INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
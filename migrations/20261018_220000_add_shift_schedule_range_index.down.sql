@@ -0,0 +1,5 @@
+-- File Name: 20261018_220000_add_shift_schedule_range_index.down.sql
+-- Date: 2026-10-18 22:00:00
+-- Author: Yunus Emre Alpu
+
+DROP INDEX IF EXISTS idx_shift_schedule_range;
@@ -0,0 +1,7 @@
+-- File Name: 20261018_220000_add_shift_schedule_range_index.up.sql
+-- Date: 2026-10-18 22:00:00
+-- Author: Yunus Emre Alpu
+
+-- Timeline queries select the live shift schedules overlapping a window
+
+CREATE INDEX IF NOT EXISTS idx_shift_schedule_range ON shift_schedule (start_date, end_date) WHERE deleted_at IS NULL;
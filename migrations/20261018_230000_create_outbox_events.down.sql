@@ -0,0 +1,5 @@
+-- File Name: 20261018_230000_create_outbox_events.down.sql
+-- Date: 2026-10-18 23:00:00
+-- Author: Yunus Emre Alpu
+
+DROP TABLE IF EXISTS outbox_events;
@@ -0,0 +1,23 @@
+-- File Name: 20261018_230000_create_outbox_events.up.sql
+-- Date: 2026-10-18 23:00:00
+-- Author: Yunus Emre Alpu
+
+-- Shift schedule events written in the transaction of the change, the relay
+-- publishes them to the broker in order and marks them published
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id BIGSERIAL PRIMARY KEY,
+    event_id VARCHAR(64) NOT NULL UNIQUE,
+    shift_schedule_id INTEGER NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    event_version INTEGER NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    published_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_error TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events (id) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_published_at ON outbox_events (published_at) WHERE published_at IS NOT NULL;
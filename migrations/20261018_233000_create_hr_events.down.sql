@@ -0,0 +1,5 @@
+-- File Name: 20261018_233000_create_hr_events.down.sql
+-- Date: 2026-10-18 23:30:00
+-- Author: Yunus Emre Alpu
+
+DROP TABLE IF EXISTS hr_events;
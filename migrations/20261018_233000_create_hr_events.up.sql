@@ -0,0 +1,12 @@
+-- File Name: 20261018_233000_create_hr_events.up.sql
+-- Date: 2026-10-18 23:30:00
+-- Author: Yunus Emre Alpu
+
+-- HR events already applied, redelivered events are skipped
+
+CREATE TABLE IF NOT EXISTS hr_events (
+    event_id VARCHAR(128) PRIMARY KEY,
+    event_type VARCHAR(64) NOT NULL,
+    person_id INTEGER DEFAULT NULL,
+    processed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
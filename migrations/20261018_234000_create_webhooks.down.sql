@@ -0,0 +1,6 @@
+-- File Name: 20261018_234000_create_webhooks.down.sql
+-- Date: 2026-10-18 23:40:00
+-- Author: Yunus Emre Alpu
+
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_subscriptions;
@@ -0,0 +1,5 @@
+-- File Name: 20261018_235000_create_notifications.down.sql
+-- Date: 2026-10-18 23:50:00
+-- Author: Yunus Emre Alpu
+
+DROP TABLE IF EXISTS notifications;
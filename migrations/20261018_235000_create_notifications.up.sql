@@ -0,0 +1,27 @@
+-- File Name: 20261018_235000_create_notifications.up.sql
+-- Date: 2026-10-18 23:50:00
+-- Author: Yunus Emre Alpu
+
+-- Shift reminders and handover notices, one row per notification and channel.
+-- The unique dedup key lets a single replica claim a notification, so it is
+-- sent once however many replicas scan the upcoming shifts
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id BIGSERIAL PRIMARY KEY,
+    dedup_key VARCHAR(255) NOT NULL,
+    channel VARCHAR(32) NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    shift_schedule_id INTEGER NOT NULL REFERENCES shift_schedule(id) ON DELETE CASCADE,
+    person_id INTEGER DEFAULT NULL,
+    recipient VARCHAR(255) NOT NULL DEFAULT '',
+    shift_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'sending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    sent_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notifications_dedup ON notifications (dedup_key, channel);
+CREATE INDEX IF NOT EXISTS idx_notifications_shift_schedule ON notifications (shift_schedule_id, shift_start);
@@ -0,0 +1,5 @@
+-- File Name: 20261018_235700_expire_calendar_tokens.down.sql
+-- Date: 2026-10-18 23:57:00
+-- Author: Yunus Emre Alpu
+
+ALTER TABLE calendar_tokens DROP COLUMN IF EXISTS expires_at;
@@ -0,0 +1,12 @@
+-- File Name: 20261018_235700_expire_calendar_tokens.up.sql
+-- Date: 2026-10-18 23:57:00
+-- Author: Yunus Emre Alpu
+
+-- Calendar tokens expire, the role they were issued with is not trusted forever. Existing tokens expire
+-- 30 days after they were created.
+
+ALTER TABLE calendar_tokens ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+
+UPDATE calendar_tokens SET expires_at = created_at + INTERVAL '30 days' WHERE expires_at IS NULL;
+
+ALTER TABLE calendar_tokens ALTER COLUMN expires_at SET NOT NULL;
@@ -0,0 +1,5 @@
+-- File Name: 20261018_235800_drop_webhook_response_body.down.sql
+-- Date: 2026-10-18 23:58:00
+-- Author: Yunus Emre Alpu
+
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS response_body TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,7 @@
+-- File Name: 20261018_235800_drop_webhook_response_body.up.sql
+-- Date: 2026-10-18 23:58:00
+-- Author: Yunus Emre Alpu
+
+-- Webhook deliveries only keep the response status, the responses of subscribers are not exposed
+
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS response_body;
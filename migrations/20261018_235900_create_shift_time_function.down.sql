@@ -0,0 +1,5 @@
+-- File Name: 20261018_235900_create_shift_time_function.down.sql
+-- Date: 2026-10-18 23:59:00
+-- Author: Yunus Emre Alpu
+
+DROP FUNCTION IF EXISTS shyft_shift_time(TEXT, TEXT);
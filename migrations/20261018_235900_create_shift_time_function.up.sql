@@ -0,0 +1,21 @@
+-- File Name: 20261018_235900_create_shift_time_function.up.sql
+-- Date: 2026-10-18 23:59:00
+-- Author: Yunus Emre Alpu
+
+-- Reads the start or end of a shift JSONB entry as a timestamp: RFC 3339 times carry their offset, times
+-- written without one are in the given time zone. Other values, and invalid dates such as 2024-02-30 that
+-- match the format, are NULL instead of failing the whole query.
+
+CREATE OR REPLACE FUNCTION shyft_shift_time(raw TEXT, time_zone TEXT) RETURNS TIMESTAMP WITH TIME ZONE AS $$
+BEGIN
+    IF raw IS NULL OR raw !~ '^[0-9]{4}-[0-9]{2}-[0-9]{2}[T ][0-9]{2}:[0-9]{2}:[0-9]{2}' THEN
+        RETURN NULL;
+    END IF;
+    IF raw ~ '(Z|[+-][0-9]{2}:[0-9]{2})$' THEN
+        RETURN raw::timestamptz;
+    END IF;
+    RETURN raw::timestamp AT TIME ZONE time_zone;
+EXCEPTION WHEN data_exception THEN
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql STABLE;
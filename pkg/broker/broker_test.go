@@ -0,0 +1,209 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseUrl(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		want    Options
+		wantErr bool
+	}{
+		{"host only", "kafka://localhost:9092", Options{Brokers: []string{"localhost:9092"}}, false},
+		{"without scheme", "localhost:9092/shyft.events", Options{Brokers: []string{"localhost:9092"}, Topic: "shyft.events"}, false},
+		{
+			"credentials, several hosts and topic",
+			"kafka://user:p@ss@kafka-1:9092, kafka-2:9092,/shyft.events",
+			Options{Brokers: []string{"kafka-1:9092", "kafka-2:9092"}, Username: "user", Password: "p@ss", Topic: "shyft.events"},
+			false,
+		},
+		{"username only", "kafka://user@kafka:9092", Options{Brokers: []string{"kafka:9092"}, Username: "user"}, false},
+		{"no host", "kafka:///shyft.events", Options{}, true},
+		{"empty", "", Options{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseUrl(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseUrl(%q) error = %v, want error %v", tt.url, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseUrl(%q) = %+v, want %+v", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryBroker(t *testing.T) {
+	b := NewMemoryBroker()
+	ctx := context.Background()
+	if err := b.Publish(ctx, Message{Topic: "a", Value: []byte("1")}, Message{Topic: "b", Value: []byte("2")}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if err := b.Publish(ctx, Message{Topic: "a", Value: []byte("3")}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	tests := []struct {
+		topic string
+		want  []string
+	}{
+		{"a", []string{"1", "3"}},
+		{"b", []string{"2"}},
+		{"c", nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for i, message := range b.Messages(tt.topic) {
+			if message.Offset != int64(i) {
+				t.Errorf("message %d of %s has offset %d", i, tt.topic, message.Offset)
+			}
+			got = append(got, string(message.Value))
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Messages(%q) = %v, want %v", tt.topic, got, tt.want)
+		}
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := b.Publish(canceled, Message{Topic: "a"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Publish() with a canceled context error = %v, want context.Canceled", err)
+	}
+	b.Close()
+	if err := b.Publish(ctx, Message{Topic: "a"}); !errors.Is(err, ErrClosed) {
+		t.Errorf("Publish() after Close() error = %v, want ErrClosed", err)
+	}
+}
+
+// consume collects the values handed to the consumer until count are received or a second has passed
+func consume(t *testing.T, consumer Consumer, count int) []string {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	var values []string
+	consumer.Consume(ctx, func(ctx context.Context, message Message) error {
+		values = append(values, string(message.Value))
+		if len(values) == count {
+			cancel()
+		}
+		return nil
+	})
+	return values
+}
+
+func TestMemoryConsumer(t *testing.T) {
+	b := NewMemoryBroker()
+	ctx := context.Background()
+	b.Publish(ctx, Message{Topic: "events", Value: []byte("1")}, Message{Topic: "events", Value: []byte("2")}, Message{Topic: "other", Value: []byte("x")})
+
+	tests := []struct {
+		name    string
+		group   string
+		publish []string
+		count   int
+		want    []string
+	}{
+		{"a new group starts at the oldest message", "relay", nil, 2, []string{"1", "2"}},
+		{"the group continues at its offset and waits for new messages", "relay", []string{"3"}, 1, []string{"3"}},
+		{"another group has its own offset", "audit", nil, 3, []string{"1", "2", "3"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			go func() {
+				time.Sleep(10 * time.Millisecond)
+				for _, value := range tt.publish {
+					b.Publish(ctx, Message{Topic: "events", Value: []byte(value)})
+				}
+			}()
+			if got := consume(t, b.Consumer("events", tt.group), tt.count); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("consumed %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryConsumerRetriesFailedMessages(t *testing.T) {
+	b := NewMemoryBroker()
+	ctx := context.Background()
+	b.Publish(ctx, Message{Topic: "events", Value: []byte("1")}, Message{Topic: "events", Value: []byte("2")})
+
+	consumeCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	var values []string
+	b.Consumer("events", "relay").Consume(consumeCtx, func(ctx context.Context, message Message) error {
+		values = append(values, string(message.Value))
+		if len(values) == 1 {
+			return errors.New("unavailable")
+		}
+		if len(values) == 3 {
+			cancel()
+		}
+		return nil
+	})
+	if want := []string{"1", "1", "2"}; !reflect.DeepEqual(values, want) {
+		t.Errorf("consumed %v, want %v", values, want)
+	}
+}
+
+// failingConsumer fails the first calls of Consume, then consumes until ctx is done
+type failingConsumer struct {
+	failures int32
+	calls    atomic.Int32
+}
+
+func (c *failingConsumer) Consume(ctx context.Context, handler Handler) error {
+	if c.calls.Add(1) <= c.failures {
+		return errors.New("broker unreachable")
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func (c *failingConsumer) Close() error {
+	return nil
+}
+
+func TestConsumeWithRetry(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	consumer := &failingConsumer{failures: 1}
+	var delays []time.Duration
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ConsumeWithRetry(ctx, consumer, func(context.Context, Message) error { return nil }, func(err error, delay time.Duration) {
+			delays = append(delays, delay)
+		})
+	}()
+
+	deadline := time.After(3 * time.Second)
+	for consumer.calls.Load() < 2 {
+		select {
+		case <-deadline:
+			t.Fatalf("ConsumeWithRetry() consumed %d times, want it to consume again after a failure", consumer.calls.Load())
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	cancel()
+	<-done
+	if len(delays) != 1 || delays[0] != minHandlerBackoff {
+		t.Errorf("ConsumeWithRetry() reported delays %v, want [%s]", delays, minHandlerBackoff)
+	}
+
+	// a consumer failing once ctx is done is not consumed again
+	consumer = &failingConsumer{failures: 1}
+	ConsumeWithRetry(ctx, consumer, nil, func(error, time.Duration) {})
+	if calls := consumer.calls.Load(); calls != 1 {
+		t.Errorf("ConsumeWithRetry() after ctx is done consumed %d times, want 1", calls)
+	}
+}
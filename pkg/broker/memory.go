@@ -0,0 +1,93 @@
+package broker
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryBroker keeps the published messages in memory, it is meant for tests: messages are never removed
+type MemoryBroker struct {
+	mu        sync.Mutex
+	messages  map[string][]Message
+	offsets   map[string]int // next offset of every consumer group and topic
+	published chan struct{}  // closed on every publish
+	closed    bool
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{messages: map[string][]Message{}, offsets: map[string]int{}, published: make(chan struct{})}
+}
+
+func (b *MemoryBroker) Publish(ctx context.Context, messages ...Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return ErrClosed
+	}
+	for _, message := range messages {
+		message.Offset = int64(len(b.messages[message.Topic]))
+		b.messages[message.Topic] = append(b.messages[message.Topic], message)
+	}
+	close(b.published)
+	b.published = make(chan struct{})
+	return nil
+}
+
+// Messages returns the messages published to topic, oldest first
+func (b *MemoryBroker) Messages(topic string) []Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]Message(nil), b.messages[topic]...)
+}
+
+func (b *MemoryBroker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	return nil
+}
+
+// Consumer returns a consumer of the messages published to topic, the consumers of a group share their offset
+func (b *MemoryBroker) Consumer(topic, group string) *MemoryConsumer {
+	return &MemoryConsumer{broker: b, topic: topic, group: group}
+}
+
+// MemoryConsumer consumes the messages of a memory broker
+type MemoryConsumer struct {
+	broker *MemoryBroker
+	topic  string
+	group  string
+}
+
+func (c *MemoryConsumer) Consume(ctx context.Context, handler Handler) error {
+	key := c.group + "/" + c.topic
+	for {
+		c.broker.mu.Lock()
+		offset := c.broker.offsets[key]
+		pending := c.broker.messages[c.topic]
+		published := c.broker.published
+		c.broker.mu.Unlock()
+
+		if offset >= len(pending) {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-published:
+			}
+			continue
+		}
+		if err := handle(ctx, handler, pending[offset]); err != nil {
+			return nil
+		}
+		c.broker.mu.Lock()
+		c.broker.offsets[key] = offset + 1
+		c.broker.mu.Unlock()
+	}
+}
+
+func (c *MemoryConsumer) Close() error {
+	return nil
+}
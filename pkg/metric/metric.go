@@ -13,6 +13,12 @@ import (
 type Metrics interface {
 	IncHits(status int, method, path string)
 	ObserveResponseTime(status int, method, path string, observeTime float64)
+	SetUncoveredHours(organizationID, scheduleID string, hours float64)
+	ResetUncoveredHours()
+	SetOutboxBacklog(events float64)
+	SetOutboxLag(seconds float64)
+	AddOutboxPublished(events int)
+	IncOutboxFailures()
 }
 
 // Prometheus Metrics struct
@@ -20,6 +26,12 @@ type PrometheusMetrics struct {
 	HitsTotal prometheus.Counter
 	Hits      *prometheus.CounterVec
 	Times     *prometheus.HistogramVec
+	Uncovered *prometheus.GaugeVec
+
+	OutboxBacklog   prometheus.Gauge
+	OutboxLag       prometheus.Gauge
+	OutboxPublished prometheus.Counter
+	OutboxFailures  prometheus.Counter
 }
 
 // Create metrics with address and name
@@ -55,6 +67,54 @@ func CreateMetrics(address string, name string) (Metrics, error) {
 		return nil, err
 	}
 
+	metr.Uncovered = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: name + "_uncovered_hours",
+			Help: "Upcoming hours of a shift schedule nobody is assigned to",
+		},
+		[]string{"organization_id", "shift_schedule_id"},
+	)
+
+	if err := prometheus.Register(metr.Uncovered); err != nil {
+		return nil, err
+	}
+
+	metr.OutboxBacklog = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: name + "_outbox_backlog",
+		Help: "Events of the outbox not published yet",
+	})
+
+	if err := prometheus.Register(metr.OutboxBacklog); err != nil {
+		return nil, err
+	}
+
+	metr.OutboxLag = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: name + "_outbox_lag_seconds",
+		Help: "Age of the oldest event of the outbox not published yet",
+	})
+
+	if err := prometheus.Register(metr.OutboxLag); err != nil {
+		return nil, err
+	}
+
+	metr.OutboxPublished = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: name + "_outbox_published_total",
+		Help: "Events of the outbox published to the broker",
+	})
+
+	if err := prometheus.Register(metr.OutboxPublished); err != nil {
+		return nil, err
+	}
+
+	metr.OutboxFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: name + "_outbox_failures_total",
+		Help: "Failed attempts to publish events of the outbox",
+	})
+
+	if err := prometheus.Register(metr.OutboxFailures); err != nil {
+		return nil, err
+	}
+
 	if err := prometheus.Register(prometheus.NewBuildInfoCollector()); err != nil {
 		return nil, err
 	}
@@ -81,3 +141,33 @@ func (metr *PrometheusMetrics) IncHits(status int, method, path string) {
 func (metr *PrometheusMetrics) ObserveResponseTime(status int, method, path string, observeTime float64) {
 	metr.Times.WithLabelValues(strconv.Itoa(status), method, path).Observe(observeTime)
 }
+
+// Set upcoming uncovered hours of a shift schedule
+func (metr *PrometheusMetrics) SetUncoveredHours(organizationID, scheduleID string, hours float64) {
+	metr.Uncovered.WithLabelValues(organizationID, scheduleID).Set(hours)
+}
+
+// Reset uncovered hours, dropping the shift schedules that are not reported anymore
+func (metr *PrometheusMetrics) ResetUncoveredHours() {
+	metr.Uncovered.Reset()
+}
+
+// Set the number of events of the outbox not published yet
+func (metr *PrometheusMetrics) SetOutboxBacklog(events float64) {
+	metr.OutboxBacklog.Set(events)
+}
+
+// Set the age of the oldest event of the outbox not published yet
+func (metr *PrometheusMetrics) SetOutboxLag(seconds float64) {
+	metr.OutboxLag.Set(seconds)
+}
+
+// Add events of the outbox published to the broker
+func (metr *PrometheusMetrics) AddOutboxPublished(events int) {
+	metr.OutboxPublished.Add(float64(events))
+}
+
+// Increment the failed attempts to publish events of the outbox
+func (metr *PrometheusMetrics) IncOutboxFailures() {
+	metr.OutboxFailures.Inc()
+}
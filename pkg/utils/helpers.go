@@ -1,7 +1,6 @@
 package utils
 
 import (
-	"shyft/internal/models"
 	"strings"
 	"time"
 )
@@ -36,20 +35,12 @@ func UrlStringToOptions(url string) (string, string, string, string, string, str
 	return protocol, username, password, host, port, db
 }
 
-// The function calculates and sets the start and end dates for a series of shift based on user input.
-func CalculateSentryShiftTimes(startDate time.Time, frequency int, shifts []models.Shift) []models.Shift {
-	start := startDate
-	end := start.AddDate(0, 0, frequency)
-
-	for _, shift := range shifts {
-		// set start and end time for each shift
-		shift.Start = start.Format("2006-01-02 15:04:05")
-		shift.End = end.Format("2006-01-02 15:04:05")
-
-		// set start and end time for next shift
-		start = end
-		end = start.AddDate(0, 0, frequency)
-	}
-
-	return shifts
+// WeekRange returns the start (Monday 00:00) and the end (Sunday 23:59:59) of the week of t in loc. The
+// boundaries are built from calendar days, so they stay at midnight across daylight saving time changes.
+func WeekRange(t time.Time, loc *time.Location) (time.Time, time.Time) {
+	t = t.In(loc)
+	offset := (int(t.Weekday()) + 6) % 7 // days since Monday
+	weekStart := time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, loc)
+	weekEnd := time.Date(t.Year(), t.Month(), t.Day()-offset+6, 23, 59, 59, 0, loc)
+	return weekStart, weekEnd
 }
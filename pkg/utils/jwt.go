@@ -0,0 +1,18 @@
+package utils
+
+import (
+	"errors"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// ParseJWTPublicKey parses a PEM encoded RSA or ECDSA public key used to verify JWT signatures
+func ParseJWTPublicKey(pemKey string) (interface{}, error) {
+	if rsaKey, err := jwt.ParseRSAPublicKeyFromPEM([]byte(pemKey)); err == nil {
+		return rsaKey, nil
+	}
+	if ecKey, err := jwt.ParseECPublicKeyFromPEM([]byte(pemKey)); err == nil {
+		return ecKey, nil
+	}
+	return nil, errors.New("jwt public key must be a PEM encoded RSA or ECDSA public key")
+}